@@ -1,7 +1,18 @@
 package core
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/traditionalchinese"
 )
 
 var codePages = map[string]string{
@@ -21,6 +32,31 @@ var codePages = map[string]string{
 	"1258": "cp1258", // Vietnam
 }
 
+var encodings = map[string]encoding.Encoding{
+	"cp874":  charmap.Windows874,
+	"cp932":  japanese.ShiftJIS,
+	"gbk":    simplifiedchinese.GBK,
+	"cp949":  korean.EUCKR,
+	"cp950":  traditionalchinese.Big5,
+	"cp1250": charmap.Windows1250,
+	"cp1251": charmap.Windows1251,
+	"cp1252": charmap.Windows1252,
+	"cp1253": charmap.Windows1253,
+	"cp1254": charmap.Windows1254,
+	"cp1255": charmap.Windows1255,
+	"cp1256": charmap.Windows1256,
+	"cp1257": charmap.Windows1257,
+	"cp1258": charmap.Windows1258,
+}
+
+// mbcsEncodings has the encodings of the \M+nXXXX escapes by their n.
+var mbcsEncodings = map[byte]encoding.Encoding{
+	'1': japanese.ShiftJIS,
+	'2': traditionalchinese.Big5,
+	'3': korean.EUCKR,
+	'5': simplifiedchinese.GBK,
+}
+
 func toEncoding(dxfCodePage string) string {
 	for codePage, encoding := range codePages {
 		if strings.HasSuffix(dxfCodePage, codePage) {
@@ -29,3 +65,174 @@ func toEncoding(dxfCodePage string) string {
 	}
 	return "cp1252"
 }
+
+// CodePageEncoding returns the encoding of a $DWGCODEPAGE value, like
+// "ANSI_1252". The unknown code pages are read as cp1252.
+func CodePageEncoding(dxfCodePage string) encoding.Encoding {
+	return encodings[toEncoding(dxfCodePage)]
+}
+
+// codePageTracker follows the $ACADVER and $DWGCODEPAGE header variables
+// of a stream of tags, to know the encoding of its strings.
+type codePageTracker struct {
+	variable string
+	version  string
+	codePage string
+}
+
+// track updates the tracker with the next tag of the stream.
+func (c *codePageTracker) track(tag *Tag) {
+	if tag.Code == 9 {
+		c.variable = tag.Value.ToString()
+		return
+	}
+	switch c.variable {
+	case "$ACADVER":
+		c.version = tag.Value.ToString()
+	case "$DWGCODEPAGE":
+		c.codePage = tag.Value.ToString()
+	}
+	c.variable = ""
+}
+
+// encoding returns the encoding of the strings, nil for UTF-8: the files
+// since R2007 and the streams without a header.
+func (c codePageTracker) encoding() encoding.Encoding {
+	if (c.version == "" && c.codePage == "") || c.version >= "AC1021" {
+		return nil
+	}
+	return CodePageEncoding(c.codePage)
+}
+
+// DecodeString returns the UTF-8 text of a string read from a DXF file in
+// the enc encoding, nil for UTF-8, with its \U+XXXX and \M+nXXXX escapes
+// decoded.
+func DecodeString(value string, enc encoding.Encoding) string {
+	if enc != nil && !isASCII(value) {
+		if decoded, err := enc.NewDecoder().String(value); err == nil {
+			value = decoded
+		}
+	}
+	return decodeEscapes(value)
+}
+
+// EncodeString returns the text of the UTF-8 value to write in a DXF file
+// in the enc encoding, nil for UTF-8. The characters enc cannot represent
+// are written as \U+XXXX escapes.
+func EncodeString(value string, enc encoding.Encoding) string {
+	if enc == nil || isASCII(value) {
+		return value
+	}
+
+	encoder := enc.NewEncoder()
+	var builder strings.Builder
+	for _, char := range value {
+		encoded, err := encoder.String(string(char))
+		if err == nil && char != utf8.RuneError {
+			builder.WriteString(encoded)
+			continue
+		}
+		if r1, r2 := utf16.EncodeRune(char); r1 != utf8.RuneError {
+			fmt.Fprintf(&builder, `\U+%04X\U+%04X`, r1, r2)
+		} else {
+			fmt.Fprintf(&builder, `\U+%04X`, char)
+		}
+	}
+	return builder.String()
+}
+
+// decodeEscapes replaces the \U+XXXX unicode and \M+nXXXX multibyte escapes
+// of the value by their characters. The invalid escapes are kept and the
+// UTF-16 surrogates not in a high and low pair are replaced by U+FFFD.
+func decodeEscapes(value string) string {
+	if !strings.Contains(value, `\U+`) && !strings.Contains(value, `\M+`) {
+		return value
+	}
+
+	var builder strings.Builder
+	var high rune
+	for i := 0; i < len(value); {
+		if char, ok := unicodeEscape(value[i:]); ok {
+			i += 7
+			if high != 0 {
+				if isLowSurrogate(char) {
+					builder.WriteRune(utf16.DecodeRune(high, char))
+					high = 0
+					continue
+				}
+				builder.WriteRune(utf8.RuneError)
+				high = 0
+			}
+			switch {
+			case isHighSurrogate(char):
+				high = char
+			case utf16.IsSurrogate(char):
+				builder.WriteRune(utf8.RuneError)
+			default:
+				builder.WriteRune(char)
+			}
+			continue
+		}
+		if high != 0 {
+			builder.WriteRune(utf8.RuneError)
+			high = 0
+		}
+		if text, ok := mbcsEscape(value[i:]); ok {
+			builder.WriteString(text)
+			i += 8
+			continue
+		}
+		builder.WriteByte(value[i])
+		i++
+	}
+	if high != 0 {
+		builder.WriteRune(utf8.RuneError)
+	}
+	return builder.String()
+}
+
+// isHighSurrogate tells if the char is the first of a UTF-16 surrogate pair.
+func isHighSurrogate(char rune) bool {
+	return char >= 0xd800 && char < 0xdc00
+}
+
+// isLowSurrogate tells if the char is the second of a UTF-16 surrogate pair.
+func isLowSurrogate(char rune) bool {
+	return char >= 0xdc00 && char < 0xe000
+}
+
+// unicodeEscape decodes the \U+XXXX escape at the start of the value.
+func unicodeEscape(value string) (rune, bool) {
+	if len(value) < 7 || !strings.HasPrefix(value, `\U+`) {
+		return 0, false
+	}
+	code, err := strconv.ParseUint(value[3:7], 16, 32)
+	return rune(code), err == nil
+}
+
+// mbcsEscape decodes the \M+nXXXX escape at the start of the value, the
+// XXXX bytes of a character in the n multibyte code page.
+func mbcsEscape(value string) (string, bool) {
+	if len(value) < 8 || !strings.HasPrefix(value, `\M+`) {
+		return "", false
+	}
+	enc, ok := mbcsEncodings[value[3]]
+	if !ok {
+		return "", false
+	}
+	code, err := strconv.ParseUint(value[4:8], 16, 16)
+	if err != nil {
+		return "", false
+	}
+	text, err := enc.NewDecoder().String(string([]byte{byte(code >> 8), byte(code)}))
+	return text, err == nil
+}
+
+func isASCII(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
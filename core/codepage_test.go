@@ -1,7 +1,13 @@
 package core
 
 import (
+	"bytes"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"strings"
 	"testing"
 )
 
@@ -19,3 +25,84 @@ func TestToEncoding(t *testing.T) {
 		assert.Equal(t, toEncoding(test.dxfCodePage), test.encoding)
 	}
 }
+
+func TestCodePageEncoding(t *testing.T) {
+	assert.Equal(t, japanese.ShiftJIS, CodePageEncoding("ANSI_932"))
+	assert.Equal(t, charmap.Windows1250, CodePageEncoding("ANSI_1250"))
+	assert.Equal(t, charmap.Windows1252, CodePageEncoding("UNKNOWN"))
+}
+
+func TestDecodeString(t *testing.T) {
+	testCases := []struct {
+		value    string
+		encoding encoding.Encoding
+		expected string
+	}{
+		{"Plain text", charmap.Windows1252, "Plain text"},
+		{"\x93\xfa\x96\x7b", japanese.ShiftJIS, "日本"},
+		{"P\xf8\xedli\x9a", charmap.Windows1250, "Příliš"},
+		{"Příliš", nil, "Příliš"},
+		{`P\U+0159\U+00EDli\U+0161`, nil, "Příliš"},
+		{`\U+D83D\U+DE00!`, charmap.Windows1252, "😀!"},
+		{`\U+D83D\U+0041`, nil, "\uFFFDA"},
+		{`\U+DE00\U+D83D\U+DE00`, nil, "\uFFFD😀"},
+		{`\U+D83D\U+D83D\U+DE00`, nil, "\uFFFD😀"},
+		{`\U+D83D`, nil, "\uFFFD"},
+		{`\M+193FA\M+1967B`, nil, "日本"},
+		{`\M+4ABCD \U+12 \U+XYZW`, nil, `\M+4ABCD \U+12 \U+XYZW`},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, DecodeString(test.value, test.encoding))
+	}
+}
+
+func TestEncodeString(t *testing.T) {
+	testCases := []struct {
+		value    string
+		encoding encoding.Encoding
+		expected string
+	}{
+		{"Plain text", charmap.Windows1252, "Plain text"},
+		{"日本", japanese.ShiftJIS, "\x93\xfa\x96\x7b"},
+		{"Příliš", charmap.Windows1250, "P\xf8\xedli\x9a"},
+		{"Příliš", charmap.Windows1252, `P\U+0159` + "\xedli\x9a"},
+		{"😀!", charmap.Windows1252, `\U+D83D\U+DE00!`},
+		{"Příliš", nil, "Příliš"},
+	}
+
+	for _, test := range testCases {
+		encoded := EncodeString(test.value, test.encoding)
+		assert.Equal(t, test.expected, encoded)
+		assert.Equal(t, test.value, DecodeString(encoded, test.encoding))
+	}
+}
+
+const codePageDXF = "  0\nSECTION\n  2\nHEADER\n  9\n$ACADVER\n  1\n%v\n  9\n$DWGCODEPAGE\n  3\nANSI_932\n" +
+	"  0\nENDSEC\n  0\nSECTION\n  2\nENTITIES\n  0\nTEXT\n  1\n%v\n  0\nENDSEC\n  0\nEOF\n"
+
+func TestTaggerDecodesCodePage(t *testing.T) {
+	testCases := []struct {
+		version  string
+		value    string
+		expected string
+	}{
+		{"AC1015", "\x93\xfa\x96\x7b", "日本"},
+		{"AC1015", `\U+65E5\U+672C`, "日本"},
+		// UTF-8 from R2007 on.
+		{"AC1021", "日本", "日本"},
+	}
+
+	for _, test := range testCases {
+		tags := TagSlice(AllTags(Tagger(strings.NewReader(fmt.Sprintf(codePageDXF, test.version, test.value)))))
+		assert.Equal(t, test.expected, tags[len(tags)-3].Value.ToString())
+
+		var buffer bytes.Buffer
+		assert.Nil(t, WriteTags(&buffer, tags))
+		if test.version < "AC1021" {
+			assert.Contains(t, buffer.String(), "\n\x93\xfa\x96\x7b\n")
+		} else {
+			assert.Contains(t, buffer.String(), "\n日本\n")
+		}
+	}
+}
@@ -1,153 +1,264 @@
 // Package core provides functions and data structures for basic DXF operations.
 package core
 
-import "strconv"
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
 
-// DataType is an interface for a DXF type.
-type DataType interface {
-	DxfElement
+// Kind is the type of the value of a DataType.
+type Kind uint8
 
-	// ToString returns a string representation of the value
-	ToString() string
+// The kinds of DataType values.
+const (
+	StringKind Kind = iota
+	IntegerKind
+	FloatKind
+	// Integer64Kind is the kind of the 64-bit integers of the group codes
+	// 160-169.
+	Integer64Kind
+	// BinaryKind is the kind of the hex encoded binary chunks, like the
+	// ones of the group codes 310-319.
+	BinaryKind
+	// HandleKind is the kind of the hex handles of the objects, like the
+	// ones of the group codes 320-369.
+	HandleKind
+)
 
-	// Value returns the encapsulated value as an interface{} the caller should cast it appropriately
-	// or use the 'AsString', 'AsInt' and 'AsFloat' functions
-	Value() interface{}
+// kindNames are the names of the kinds, see Kind.String.
+var kindNames = [...]string{
+	StringKind:    "String",
+	IntegerKind:   "Integer",
+	FloatKind:     "Float",
+	Integer64Kind: "Integer64",
+	BinaryKind:    "Binary",
+	HandleKind:    "Handle",
 }
 
-// String DataType implementation
-type String struct {
-	value string
+// String returns the name of the kind, like "Integer".
+func (k Kind) String() string {
+	if int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", k)
 }
 
-// NewString creates a new String object with the provided value.
-func NewString(value string) (DataType, error) {
-	returnValue := new(String)
-	returnValue.value = value
-	return returnValue, nil
+// DataType is the value of a DXF tag: a string, an integer, a float, a
+// 64-bit integer, a binary chunk or a handle.
+//
+// It is a small value type, a tagged union of the kinds, so that the tags
+// hold their values without allocating them apart. The zero DataType is
+// the empty string.
+type DataType struct {
+	kind Kind
+	// text is the value of the strings, and the hex text of the binary
+	// chunks and the handles.
+	text string
+	// bits is the int64 of the integers and the IEEE 754 bits of the floats.
+	bits uint64
 }
 
-// NewStringValue creates a new String object with provided string.
-func NewStringValue(value string) *String {
-	returnValue := new(String)
-	returnValue.value = value
-	return returnValue
+// NewString creates a new String DataType with the provided value.
+func NewString(value string) (DataType, error) {
+	return NewStringValue(value), nil
 }
 
-// ToString returns a string representation of the value
-func (s String) ToString() string {
-	return s.value
+// NewStringValue creates a new String DataType with provided string.
+func NewStringValue(value string) DataType {
+	return DataType{kind: StringKind, text: value}
 }
 
-// Value returns the encapsulated value
-func (s String) Value() interface{} {
-	return s.value
+// NewInteger creates a new Integer DataType with the provided value as a string
+func NewInteger(value string) (DataType, error) {
+	v, err := strconv.Atoi(value)
+	return NewIntegerValue(v), err
 }
 
-// Equals Compares two Strings for equality.
-// If other cannot be casted to a String, returns false.
-func (s String) Equals(other DxfElement) bool {
-	if sStr, ok := other.(*String); ok {
-		return s.value == sStr.value
-	}
-	return false
+// NewIntegerValue creates a new Integer DataType with provided int.
+func NewIntegerValue(value int) DataType {
+	return DataType{kind: IntegerKind, bits: uint64(int64(value))}
 }
 
-// Integer DataType implementation
-type Integer struct {
-	value int
+// NewFloat creates a new Float DataType with the provided value as a string
+func NewFloat(value string) (DataType, error) {
+	v, err := parseDecimal(value)
+	return NewFloatValue(v), err
 }
 
-// NewInteger creates a new Integer object with the provided value as a string
-func NewInteger(value string) (DataType, error) {
-	returnValue := new(Integer)
-	v, err := strconv.Atoi(value)
-	returnValue.value = v
-	return returnValue, err
+// NewFloatValue creates a new Float DataType with provided float64.
+func NewFloatValue(value float64) DataType {
+	return DataType{kind: FloatKind, bits: math.Float64bits(value)}
 }
 
-// NewIntegerValue creates a new Integer object with provided int.
-func NewIntegerValue(value int) *Integer {
-	returnValue := new(Integer)
-	returnValue.value = value
-	return returnValue
+// NewInteger64 creates a new Integer64 DataType with the provided value as a
+// string.
+func NewInteger64(value string) (DataType, error) {
+	v, err := strconv.ParseInt(value, 10, 64)
+	return NewInteger64Value(v), err
 }
 
-// ToString returns a string representation of the value
-func (i Integer) ToString() string {
-	return strconv.Itoa(i.value)
+// NewInteger64Value creates a new Integer64 DataType with provided int64.
+func NewInteger64Value(value int64) DataType {
+	return DataType{kind: Integer64Kind, bits: uint64(value)}
 }
 
-// Value returns the encapsulated value
-func (i Integer) Value() interface{} {
-	return i.value
+// NewBinary creates a new Binary DataType with the provided hex encoded
+// value. The value is kept as it is, see AsBinary.
+func NewBinary(value string) (DataType, error) {
+	return DataType{kind: BinaryKind, text: value}, nil
 }
 
-// Equals Compares two Integers for equality.
-// If other cannot be casted to an Integer, returns false.
-func (i Integer) Equals(other DxfElement) bool {
-	if iValue, ok := other.(*Integer); ok {
-		return i.value == iValue.value
-	}
-	return false
+// NewBinaryValue creates a new Binary DataType with the hex encoding of the
+// provided bytes, in upper case like the DXF files.
+func NewBinaryValue(value []byte) DataType {
+	return DataType{kind: BinaryKind, text: strings.ToUpper(hex.EncodeToString(value))}
 }
 
-// Float DataType implementation
-type Float struct {
-	value float64
+// NewHandle creates a new Handle DataType with the provided hex value. The
+// value is kept as it is, see AsHandle.
+func NewHandle(value string) (DataType, error) {
+	return NewHandleValue(value), nil
 }
 
-// NewFloat creates a new Float object with the provided value as a string
-func NewFloat(value string) (DataType, error) {
-	returnValue := new(Float)
-	v, err := strconv.ParseFloat(value, 64)
-	returnValue.value = v
-	return returnValue, err
+// NewHandleValue creates a new Handle DataType with provided hex string.
+func NewHandleValue(value string) DataType {
+	return DataType{kind: HandleKind, text: value}
 }
 
-// NewFloatValue creates a new Float object with provided float64.
-func NewFloatValue(value float64) *Float {
-	returnValue := new(Float)
-	returnValue.value = value
-	return returnValue
+// Kind returns the kind of the value.
+func (d DataType) Kind() Kind {
+	return d.kind
 }
 
 // ToString returns a string representation of the value
-func (f Float) ToString() string {
-	return strconv.FormatFloat(f.value, 'f', -1, 64)
+func (d DataType) ToString() string {
+	switch d.kind {
+	case IntegerKind:
+		return strconv.Itoa(d.int())
+	case FloatKind:
+		return strconv.FormatFloat(d.float(), 'f', -1, 64)
+	case Integer64Kind:
+		return strconv.FormatInt(int64(d.bits), 10)
+	}
+	return d.text
 }
 
-// Value returns the encapsulated value
-func (f Float) Value() interface{} {
-	return f.value
+// Value returns the encapsulated value as an interface{}: a string, an int,
+// a float64 or an int64, and the hex string of the binary chunks and the
+// handles. The caller should cast it appropriately or use the 'AsString',
+// 'AsInt', 'AsFloat' and the other accessor functions, which do not
+// allocate.
+func (d DataType) Value() interface{} {
+	switch d.kind {
+	case IntegerKind:
+		return d.int()
+	case FloatKind:
+		return d.float()
+	case Integer64Kind:
+		return int64(d.bits)
+	}
+	return d.text
 }
 
-// Equals Compares two Floats for equality.
-// If other cannot be casted to a Float, returns false.
-func (f Float) Equals(other DxfElement) bool {
-	if fValue, ok := other.(*Float); ok {
-		return f.value == fValue.value
+// Equals compares two DataTypes for equality: they are equal when they are
+// of the same kind and value. If other is not a DataType, returns false.
+func (d DataType) Equals(other DxfElement) bool {
+	if otherValue, ok := other.(DataType); ok {
+		return d.equals(otherValue)
 	}
 	return false
 }
 
+// equals is Equals without boxing the other value in a DxfElement.
+func (d DataType) equals(other DataType) bool {
+	if d.kind != other.kind {
+		return false
+	}
+	switch d.kind {
+	case IntegerKind, Integer64Kind:
+		return d.bits == other.bits
+	case FloatKind:
+		return d.float() == other.float()
+	}
+	return d.text == other.text
+}
+
+func (d DataType) int() int {
+	return int(int64(d.bits))
+}
+
+func (d DataType) float() float64 {
+	return math.Float64frombits(d.bits)
+}
+
 // AsString is the acessor for a String DataType.
-// If d is String, it will return the (value, true), otherwise ("", false)
+// If d is String, it will return the (value, true), otherwise ("", false).
+// The Binary and Handle DataTypes are strings too, of their hex text.
 func AsString(d DataType) (string, bool) {
-	value, ok := d.Value().(string)
-	return value, ok
+	switch d.kind {
+	case StringKind, BinaryKind, HandleKind:
+		return d.text, true
+	}
+	return "", false
 }
 
 // AsInt is the acessor for an Integer DataType.
 // If d is Integer, it will return the (value, true), otherwise (0, false)
 func AsInt(d DataType) (int, bool) {
-	value, ok := d.Value().(int)
-	return value, ok
+	if d.kind != IntegerKind {
+		return 0, false
+	}
+	return d.int(), true
 }
 
 // AsFloat is the acessor for a Float DataType.
 // If d is Float, it will return the (value, true), otherwise (0.0, false)
 func AsFloat(d DataType) (float64, bool) {
-	value, ok := d.Value().(float64)
-	return value, ok
+	if d.kind != FloatKind {
+		return 0, false
+	}
+	return d.float(), true
+}
+
+// AsInt64 is the acessor for an Integer64 DataType.
+// If d is Integer64 or Integer, it will return the (value, true), otherwise
+// (0, false)
+func AsInt64(d DataType) (int64, bool) {
+	switch d.kind {
+	case IntegerKind, Integer64Kind:
+		return int64(d.bits), true
+	}
+	return 0, false
+}
+
+// AsBinary is the acessor for a Binary DataType.
+// If d is Binary, it will return its decoded (bytes, true), otherwise
+// (nil, false), also when it is not valid hex.
+func AsBinary(d DataType) ([]byte, bool) {
+	if d.kind != BinaryKind {
+		return nil, false
+	}
+	data, err := hex.DecodeString(d.text)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// AsHandle is the acessor for a Handle DataType.
+// If d is Handle, it will return the (value, true), otherwise (0, false),
+// also when it is not a valid hex number. The empty handle is 0, the null
+// handle.
+func AsHandle(d DataType) (uint64, bool) {
+	if d.kind != HandleKind {
+		return 0, false
+	}
+	if d.text == "" {
+		return 0, true
+	}
+	value, err := strconv.ParseUint(d.text, 16, 64)
+	return value, err == nil
 }
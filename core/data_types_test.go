@@ -1,6 +1,7 @@
 package core
 
 import (
+	"math"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
@@ -26,6 +27,16 @@ func (suite *DataTypesTestSuite) TestToString() {
 	suite.Equal("20.17", suite.floatType.ToString())
 }
 
+func (suite *DataTypesTestSuite) TestKindString() {
+	suite.Equal("String", suite.strType.Kind().String())
+	suite.Equal("Integer", suite.intType.Kind().String())
+	suite.Equal("Float", suite.floatType.Kind().String())
+	suite.Equal("Integer64", Integer64Kind.String())
+	suite.Equal("Binary", BinaryKind.String())
+	suite.Equal("Handle", HandleKind.String())
+	suite.Equal("Kind(9)", Kind(9).String())
+}
+
 func (suite *DataTypesTestSuite) TestAsString() {
 	value, ok := AsString(suite.strType)
 	suite.True(ok)
@@ -92,6 +103,114 @@ func (suite *DataTypesTestSuite) TestEquality() {
 	}
 }
 
+func (suite *DataTypesTestSuite) TestKind() {
+	suite.Equal(StringKind, suite.strType.Kind())
+	suite.Equal(IntegerKind, suite.intType.Kind())
+	suite.Equal(FloatKind, suite.floatType.Kind())
+
+	// the zero DataType is the empty string.
+	var zero DataType
+	value, ok := AsString(zero)
+	suite.True(ok)
+	suite.Equal("", value)
+	suite.True(zero.Equals(NewStringValue("")))
+}
+
+func (suite *DataTypesTestSuite) TestValue() {
+	suite.Equal("DXF", suite.strType.Value())
+	suite.Equal(2017, suite.intType.Value())
+	suite.Equal(20.17, suite.floatType.Value())
+	suite.Equal(-1, NewIntegerValue(-1).Value())
+}
+
+func (suite *DataTypesTestSuite) TestInteger64() {
+	value, err := NewInteger64("9223372036854775807")
+	suite.Nil(err)
+	suite.Equal(Integer64Kind, value.Kind())
+	suite.Equal("9223372036854775807", value.ToString())
+	suite.Equal(int64(math.MaxInt64), value.Value())
+	suite.True(value.Equals(NewInteger64Value(math.MaxInt64)))
+	suite.False(value.Equals(NewIntegerValue(-1)))
+
+	number, ok := AsInt64(value)
+	suite.True(ok)
+	suite.Equal(int64(math.MaxInt64), number)
+	number, ok = AsInt64(suite.intType)
+	suite.True(ok)
+	suite.Equal(int64(2017), number)
+	_, ok = AsInt(value)
+	suite.False(ok)
+	_, ok = AsInt64(suite.floatType)
+	suite.False(ok)
+
+	_, err = NewInteger64("1.5")
+	suite.NotNil(err)
+}
+
+func (suite *DataTypesTestSuite) TestBinary() {
+	value, err := NewBinary("00FF1a")
+	suite.Nil(err)
+	suite.Equal(BinaryKind, value.Kind())
+	suite.Equal("00FF1a", value.ToString())
+
+	data, ok := AsBinary(value)
+	suite.True(ok)
+	suite.Equal([]byte{0x00, 0xff, 0x1a}, data)
+	text, ok := AsString(value)
+	suite.True(ok)
+	suite.Equal("00FF1a", text)
+
+	suite.True(NewBinaryValue([]byte{0x00, 0xff, 0x1a}).Equals(NewBinaryValue(data)))
+	suite.Equal("00FF1A", NewBinaryValue(data).ToString())
+	suite.False(NewBinaryValue(data).Equals(NewStringValue("00FF1A")))
+
+	_, ok = AsBinary(NewBinaryValue(nil))
+	suite.True(ok)
+	_, ok = AsBinary(NewStringValue("00FF"))
+	suite.False(ok)
+	binary, _ := NewBinary("0G")
+	_, ok = AsBinary(binary)
+	suite.False(ok)
+}
+
+func (suite *DataTypesTestSuite) TestHandle() {
+	value, err := NewHandle("1F")
+	suite.Nil(err)
+	suite.Equal(HandleKind, value.Kind())
+	suite.Equal("1F", value.ToString())
+	suite.Equal("1F", value.Value())
+	suite.True(value.Equals(NewHandleValue("1F")))
+	suite.False(value.Equals(NewStringValue("1F")))
+
+	handle, ok := AsHandle(value)
+	suite.True(ok)
+	suite.Equal(uint64(0x1f), handle)
+	text, ok := AsString(value)
+	suite.True(ok)
+	suite.Equal("1F", text)
+
+	handle, ok = AsHandle(NewHandleValue(""))
+	suite.True(ok)
+	suite.Equal(uint64(0), handle)
+	_, ok = AsHandle(NewHandleValue("OWNER"))
+	suite.False(ok)
+	_, ok = AsHandle(NewStringValue("1F"))
+	suite.False(ok)
+}
+
+func (suite *DataTypesTestSuite) TestAllocations() {
+	allocations := testing.AllocsPerRun(100, func() {
+		value, _ := NewFloat("20.17")
+		if number, ok := AsFloat(value); !ok || number != 20.17 {
+			suite.Fail("Unexpected float")
+		}
+		if !NewStringValue("DXF").equals(suite.strType) {
+			suite.Fail("Unexpected string")
+		}
+	})
+	suite.Equal(0.0, allocations)
+}
+
 func TestDataTypesTestSuite(t *testing.T) {
 	suite.Run(t, new(DataTypesTestSuite))
 }
@@ -0,0 +1,57 @@
+package core
+
+import "strconv"
+
+// maxExactMantissa is the largest integer a float64 represents exactly.
+const maxExactMantissa = 1 << 53
+
+// exactPowersOfTen are the powers of 10 a float64 represents exactly.
+var exactPowersOfTen = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10,
+	1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22,
+}
+
+// parseDecimal parses a float64 like strconv.ParseFloat(s, 64), with the
+// same result and errors. The plain decimals of the DXF files, like
+// "-12.375", with up to 15 digits or so, are parsed with a single exact
+// division of their digits by a power of 10, which is correctly rounded.
+// The others, like the ones with an exponent, are parsed by strconv.
+func parseDecimal(s string) (float64, error) {
+	i := 0
+	negative := false
+	if i < len(s) && (s[i] == '-' || s[i] == '+') {
+		negative = s[i] == '-'
+		i++
+	}
+
+	var mantissa uint64
+	digits, decimals := 0, 0
+	point := false
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= '0' && c <= '9':
+			mantissa = mantissa*10 + uint64(c-'0')
+			digits++
+			if point {
+				decimals++
+			}
+			if mantissa >= maxExactMantissa || decimals >= len(exactPowersOfTen) {
+				return strconv.ParseFloat(s, 64)
+			}
+		case c == '.' && !point:
+			point = true
+		default:
+			return strconv.ParseFloat(s, 64)
+		}
+	}
+	if digits == 0 {
+		return strconv.ParseFloat(s, 64)
+	}
+
+	value := float64(mantissa) / exactPowersOfTen[decimals]
+	if negative {
+		value = -value
+	}
+	return value, nil
+}
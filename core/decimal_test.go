@@ -0,0 +1,59 @@
+package core
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertParsedLikeStrconv checks that parseDecimal returns the same float64,
+// to the bit, and the same error as strconv.ParseFloat.
+func assertParsedLikeStrconv(t *testing.T, s string) {
+	expected, expectedErr := strconv.ParseFloat(s, 64)
+	value, err := parseDecimal(s)
+
+	assert.Equal(t, expectedErr, err, "%q", s)
+	assert.Equal(t, math.Float64bits(expected), math.Float64bits(value), "%q: %v != %v", s, expected, value)
+}
+
+func TestParseDecimal(t *testing.T) {
+	for _, s := range []string{
+		"0", "0.0", "-0.0", "+1", "1.", ".5", "-.5", "20.17", "-12.375",
+		"0.1", "0.3", "1234567.0", "3.141592653589793", "0.000000000000000000001",
+		"9007199254740991", "9007199254740993", "0.1234567890123456789",
+		"1e10", "1.5E-3", "-2.5e+2", "Inf", "-inf", "NaN", "0x1p-2",
+		"1e400", "", ".", "-", "1.2.3", "1,5", " 1.0", "1_000.0", "im a fake float",
+	} {
+		assertParsedLikeStrconv(t, s)
+	}
+}
+
+func FuzzParseDecimal(f *testing.F) {
+	for _, s := range []string{"0.0", "-12.375", "3.141592653589793", "1e10", "9007199254740993", "."} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		assertParsedLikeStrconv(t, s)
+	})
+}
+
+var decimals = []string{"0.0", "1.0", "-12.375", "1234.5678", "0.7071067811865476", "3.141592653589793"}
+
+func BenchmarkParseDecimal(b *testing.B) {
+	b.Run("parseDecimal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := parseDecimal(decimals[i%len(decimals)]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("strconv.ParseFloat", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := strconv.ParseFloat(decimals[i%len(decimals)], 64); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
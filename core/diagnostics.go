@@ -0,0 +1,227 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Severity of a Diagnostic.
+type Severity int
+
+const (
+	// SeverityInfo is a note about the file, like an unsupported entity type
+	// kept as is.
+	SeverityInfo Severity = iota
+	// SeverityWarning is a problem worked around without losing data.
+	SeverityWarning
+	// SeverityError is a problem that lost data, the tag or element was
+	// skipped.
+	SeverityError
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+}
+
+// String returns the name of the Severity, like "warning".
+func (s Severity) String() string {
+	if name, ok := severityNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Diagnostic is a problem found parsing a DXF file, with its location when
+// it is known.
+type Diagnostic struct {
+	Severity Severity
+	// Line is the line of the group code of the tag in the file, 0 if it is
+	// not known.
+	Line       int
+	Code       int
+	EntityType string
+	Handle     string
+	Message    string
+}
+
+// String returns the description of the Diagnostic, starting with its
+// location.
+func (d Diagnostic) String() string {
+	var location []string
+	if d.Line > 0 {
+		location = append(location, fmt.Sprintf("line %v", d.Line))
+	}
+	if d.EntityType != "" {
+		location = append(location, d.EntityType)
+	}
+	if d.Handle != "" {
+		location = append(location, fmt.Sprintf("handle %v", d.Handle))
+	}
+	if len(location) == 0 {
+		return fmt.Sprintf("%v: %v", d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%v: %v (%v)", d.Severity, d.Message, strings.Join(location, ", "))
+}
+
+// ParseMode is the way a parsing handles the errors in the file.
+type ParseMode int
+
+const (
+	// Strict fails on the first negative group code or malformed value.
+	Strict ParseMode = iota
+	// Lenient skips the malformed tags and the elements that cannot be
+	// parsed, reporting them as Diagnostics, and goes on.
+	Lenient
+)
+
+// ParseOptions configures the parsing of a DXF file.
+type ParseOptions struct {
+	Mode ParseMode
+	// Logger gets the Diagnostics of the parsing as they are found, with
+	// their location as structured fields. They are collected in the List
+	// of the Diagnostics either way.
+	Logger Logger
+	// Limits bound the resources of the parsing, see NewLimiter.
+	Limits Limits
+	// Workers is the number of goroutines building the entities of the
+	// ENTITIES and BLOCKS sections of large files, like
+	// runtime.GOMAXPROCS(0). They are built sequentially when it is 0 or 1.
+	Workers int
+}
+
+// Diagnostics collects the Diagnostics of the parsing of a DXF file with
+// the Options. The nil *Diagnostics is strict and discards its messages.
+type Diagnostics struct {
+	Options ParseOptions
+	List    []Diagnostic
+}
+
+// NewDiagnostics creates new Diagnostics for a parsing with the options.
+func NewDiagnostics(options ParseOptions) *Diagnostics {
+	return &Diagnostics{Options: options}
+}
+
+// Report adds a Diagnostic about the element made of tags.
+func (d *Diagnostics) Report(severity Severity, tags TagSlice, format string, args ...interface{}) {
+	d.add(newDiagnostic(severity, tags, fmt.Sprintf(format, args...)))
+}
+
+// Recover handles the err parsing the element made of tags. In Strict mode
+// it returns err. In Lenient mode err is reported as an error and Recover
+// returns nil, for the element to be skipped, but for the ErrLimitExceeded
+// and context errors that are always returned.
+func (d *Diagnostics) Recover(tags TagSlice, err error) error {
+	return d.recover(tags, err, SeverityError)
+}
+
+func (d *Diagnostics) recover(tags TagSlice, err error, severity Severity) error {
+	if d == nil || d.Options.Mode == Strict || isFatal(err) {
+		return err
+	}
+
+	diagnostic := newDiagnostic(severity, tags, err.Error())
+	var parseError *ParseError
+	if errors.As(err, &parseError) {
+		diagnostic.Message = parseError.Err.Error()
+		diagnostic.Line, diagnostic.Code = parseError.Line, parseError.Code
+		if parseError.EntityType != "" {
+			diagnostic.EntityType, diagnostic.Handle = parseError.EntityType, parseError.Handle
+		}
+	}
+	d.add(diagnostic)
+	return nil
+}
+
+// add appends the diagnostic to the List and logs it to the Logger.
+func (d *Diagnostics) add(diagnostic Diagnostic) {
+	if d == nil {
+		return
+	}
+
+	if d.Options.Logger != nil {
+		diagnostic.log(d.Options.Logger)
+	}
+	d.List = append(d.List, diagnostic)
+}
+
+// newDiagnostic returns a Diagnostic located at the element made of tags.
+func newDiagnostic(severity Severity, tags TagSlice, message string) Diagnostic {
+	diagnostic := Diagnostic{Severity: severity, Message: message}
+	if len(tags) > 0 {
+		diagnostic.Line, diagnostic.Code = tags[0].Line, tags[0].Code
+		diagnostic.EntityType, diagnostic.Handle = elementIdentity(tags)
+	}
+	return diagnostic
+}
+
+// log logs the Diagnostic at the level of its Severity, with its location
+// as the "line", "code", "type" and "handle" fields.
+func (d Diagnostic) log(logger Logger) {
+	var args []interface{}
+	if d.Line > 0 {
+		args = append(args, "line", d.Line, "code", d.Code)
+	}
+	if d.EntityType != "" {
+		args = append(args, "type", d.EntityType)
+	}
+	if d.Handle != "" {
+		args = append(args, "handle", d.Handle)
+	}
+
+	switch d.Severity {
+	case SeverityInfo:
+		logger.Info(d.Message, args...)
+	case SeverityWarning:
+		logger.Warn(d.Message, args...)
+	default:
+		logger.Error(d.Message, args...)
+	}
+}
+
+// ReadAllTags iterates until next finishes and returns all returned tags as
+// a slice. In Strict mode it stops at the first error. In Lenient mode the
+// malformed tags are skipped and the ones with a negative group code kept as
+// strings, both reported, and it only stops at the errors reading the
+// stream.
+func (d *Diagnostics) ReadAllTags(next NextTagFunction) (TagSlice, error) {
+	tags := make(TagSlice, 0)
+
+	for {
+		tag, err := next()
+		if err != nil {
+			severity := SeverityError
+			if errors.Is(err, ErrUnknownGroupCode) {
+				severity = SeverityWarning
+			}
+			var parseError *ParseError
+			if !errors.As(err, &parseError) || d.recover(nil, err, severity) != nil {
+				return tags, err
+			}
+		}
+		if *tag == NoneTag {
+			if err == nil {
+				return tags, nil
+			}
+			continue
+		}
+		tags = append(tags, tag)
+	}
+}
+
+// elementIdentity returns the type of the element made of tags, from its
+// leading (0, type) tag, and its handle, from its first 5 or 105 tag.
+func elementIdentity(tags TagSlice) (elementType string, handle string) {
+	if len(tags) > 0 && tags[0].Code == 0 {
+		elementType = tags[0].Value.ToString()
+	}
+	for _, tag := range tags {
+		if tag.Code == 5 || tag.Code == 105 {
+			handle = tag.Value.ToString()
+			break
+		}
+	}
+	return elementType, handle
+}
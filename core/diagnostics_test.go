@@ -0,0 +1,90 @@
+package core
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestDiagnosticString(t *testing.T) {
+	testCases := []struct {
+		diagnostic Diagnostic
+		expected   string
+	}{
+		{Diagnostic{Severity: SeverityInfo, Message: "Kept"}, "info: Kept"},
+		{
+			Diagnostic{Severity: SeverityError, Line: 12, EntityType: "LINE", Handle: "2A", Message: "Invalid"},
+			"error: Invalid (line 12, LINE, handle 2A)",
+		},
+		{Diagnostic{Severity: Severity(7), Message: "Odd"}, "Severity(7): Odd"},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, test.diagnostic.String())
+	}
+}
+
+func TestDiagnosticsRecover(t *testing.T) {
+	tags := TagSlice(AllTags(Tagger(strings.NewReader("  0\nLINE\n  5\n2A\n 10\n1.0\n"))))
+	err := errors.New("Invalid LINE.")
+
+	var strict *Diagnostics
+	assert.Equal(t, err, strict.Recover(tags, err))
+	diagnostics := NewDiagnostics(ParseOptions{})
+	assert.Equal(t, err, diagnostics.Recover(tags, err))
+	assert.Len(t, diagnostics.List, 0)
+
+	diagnostics = NewDiagnostics(ParseOptions{Mode: Lenient})
+	assert.Nil(t, diagnostics.Recover(tags, err))
+	assert.Nil(t, diagnostics.Recover(tags, NewParseError(tags, tags[2], errors.New("Not a float."))))
+	assert.Equal(t, []Diagnostic{
+		{Severity: SeverityError, Line: 1, Code: 0, EntityType: "LINE", Handle: "2A", Message: "Invalid LINE."},
+		{Severity: SeverityError, Line: 5, Code: 10, EntityType: "LINE", Handle: "2A", Message: "Not a float."},
+	}, diagnostics.List)
+
+	diagnostics.Report(SeverityInfo, nil, "Keeping %v", "MTEXT")
+	assert.Equal(t, Diagnostic{Severity: SeverityInfo, Message: "Keeping MTEXT"}, diagnostics.List[2])
+}
+
+const malformedTags = "  0\nLINE\n 10\n1,5\n 20\n2.0\n500\nGap\n -1\nUnknown\n  0\nEOF\n"
+
+func TestDiagnosticsReadAllTags(t *testing.T) {
+	tags, err := ReadAllTags(Tagger(strings.NewReader(malformedTags)))
+	assert.Len(t, tags, 1)
+	var parseError *ParseError
+	assert.True(t, errors.As(err, &parseError))
+	assert.Equal(t, 3, parseError.Line)
+
+	diagnostics := NewDiagnostics(ParseOptions{Mode: Lenient})
+	tags, err = diagnostics.ReadAllTags(Tagger(strings.NewReader(malformedTags)))
+	assert.Nil(t, err)
+	// the malformed tag is skipped, the gap and the unknown group codes are
+	// kept, only the unknown one reported.
+	assert.True(t, TagSlice{
+		NewStringTag(0, "LINE"),
+		NewFloatTag(20, 2.0),
+		NewStringTag(500, "Gap"),
+		NewStringTag(-1, "Unknown"),
+		NewStringTag(0, "EOF"),
+	}.Equals(tags))
+	assert.Len(t, diagnostics.List, 2)
+	assert.Equal(t, SeverityError, diagnostics.List[0].Severity)
+	assert.Equal(t, 3, diagnostics.List[0].Line)
+	assert.Equal(t, 10, diagnostics.List[0].Code)
+	assert.Equal(t, SeverityWarning, diagnostics.List[1].Severity)
+	assert.Equal(t, 9, diagnostics.List[1].Line)
+	assert.Equal(t, ErrUnknownGroupCode.Error(), diagnostics.List[1].Message)
+}
+
+func TestDiagnosticsReadAllTagsStreamError(t *testing.T) {
+	reader := &MockReader{
+		Data: []string{""},
+		Done: []bool{true},
+		Err:  []error{errors.New("Broken stream.")}}
+
+	diagnostics := NewDiagnostics(ParseOptions{Mode: Lenient})
+	_, err := diagnostics.ReadAllTags(Tagger(reader))
+	assert.NotNil(t, err)
+	assert.Len(t, diagnostics.List, 0)
+}
@@ -29,7 +29,7 @@ func (parser StringTypeParser) Parse(d DataType) error {
 	if value, ok := AsString(d); ok {
 		parser.setter(value)
 	} else {
-		return fmt.Errorf("Error parsing type of %#v as a String", d)
+		return fmt.Errorf("Error parsing type of %v %q as a String", d.Kind(), d.ToString())
 	}
 	return nil
 }
@@ -62,7 +62,7 @@ func (parser IntTypeParser) Parse(d DataType) error {
 	if value, ok := AsInt(d); ok {
 		parser.setter(value)
 	} else {
-		return fmt.Errorf("Error parsing type of %#v as an Integer", d)
+		return fmt.Errorf("Error parsing type of %v %q as an Integer", d.Kind(), d.ToString())
 	}
 	return nil
 }
@@ -95,7 +95,7 @@ func (parser FloatTypeParser) Parse(d DataType) error {
 	if value, ok := AsFloat(d); ok {
 		parser.setter(value)
 	} else {
-		return fmt.Errorf("Error parsing type of %#v as a Float", d)
+		return fmt.Errorf("Error parsing type of %v %q as a Float", d.Kind(), d.ToString())
 	}
 	return nil
 }
@@ -122,8 +122,28 @@ func NewFloatTypeParserToVar(variable *float64) *FloatTypeParser {
 // composes the element.
 type DxfParseable struct {
 	tagParsers map[int]TypeParser
+	unparsed   TagSlice
+	// places has the place of each unparsed tag among the tags of the
+	// element, to write it back there.
+	places []tagPlace
 }
 
+// tagPlace is the place of an unparsed tag among the tags of its element:
+// the subclass it is in, "" before the first one, and the tag before it in
+// the subclass, the occurrence of its group code.
+type tagPlace struct {
+	subclass string
+	// previous is the group code of the tag before, noTag for the first
+	// tag of the subclass and endOfSubclass for the subclass markers.
+	previous   int
+	occurrence int
+}
+
+const (
+	noTag         = -1
+	endOfSubclass = -2
+)
+
 // Init initializes the DxfParseable's parser map so that it can be used by the Parse method.
 func (element *DxfParseable) Init(parsers map[int]TypeParser) {
 	element.tagParsers = parsers
@@ -140,18 +160,145 @@ func (element *DxfParseable) Update(parsers map[int]TypeParser) {
 	}
 }
 
-// Parse parses the slice of tags using the configured parser map.
-// Returns an error if any error happens during the process, otherwise it returns nil.
+// Parse parses the slice of tags using the configured parser map. The tags
+// without a parser, the application groups (102) and the XDATA are kept
+// with their place among the tags, see UnparsedTags.
+// Returns a *ParseError if any error happens during the process, otherwise it returns nil.
 func (element *DxfParseable) Parse(tags TagSlice) error {
-	for _, tag := range tags.RegularTags() {
-		if parser, ok := element.tagParsers[tag.Code]; ok {
-			err := parser.Parse(tag.Value)
-			if err != nil {
-				return err
+	element.unparsed = nil
+	element.places = nil
+	inAppData := false
+	subclass := ""
+	previous := noTag
+	occurrences := make(map[int]int)
+	for _, tag := range tags {
+		place := tagPlace{subclass: subclass, previous: previous, occurrence: occurrences[previous]}
+		if tag.Code == appDataMarker {
+			inAppData = tag.Value.ToString() != "}"
+		}
+
+		parsed := false
+		if parser, ok := element.tagParsers[tag.Code]; ok && !inAppData &&
+			tag.Code != appDataMarker && tag.Code < 1000 {
+			if err := parser.Parse(tag.Value); err != nil {
+				return NewParseError(tags, tag, err)
 			}
+			parsed = true
+		}
+
+		if tag.Code == subclassMarker && !inAppData {
+			place.previous = endOfSubclass
+			subclass = tag.Value.ToString()
+			previous = noTag
+			occurrences = make(map[int]int)
 		} else {
-			Log.Printf("Discarding tag: %+v\n", tag.ToString())
+			previous = tag.Code
+			occurrences[tag.Code]++
+		}
+		if !parsed {
+			element.unparsed = append(element.unparsed, tag)
+			element.places = append(element.places, place)
 		}
 	}
 	return nil
 }
+
+// UnparsedTags returns the tags Parse kept, in their original order, to
+// write them back unchanged.
+func (element DxfParseable) UnparsedTags() TagSlice {
+	return element.unparsed
+}
+
+// WithUnparsedTags returns the tags of the element, as its writer builds
+// them, with its unparsed tags, see UnparsedTags, back in the place they
+// had: in their subclass, after the tag they followed. The structure tags
+// the writer already has, the (0, type) tag and the subclass markers, are
+// not repeated, and the XDATA goes last.
+func (element DxfParseable) WithUnparsedTags(tags TagSlice) TagSlice {
+	result := append(TagSlice{}, tags...)
+	var xData TagSlice
+	inAppData := false
+	for i, tag := range element.unparsed {
+		place := element.places[i]
+		switch {
+		case tag.Code == appDataMarker:
+			inAppData = tag.Value.ToString() != "}"
+		case inAppData:
+		case tag.Code >= 1000:
+			xData = append(xData, tag)
+			continue
+		case i == 0 && tag.Code == 0 && len(result) > 0 && result[0].Code == 0:
+			continue
+		case tag.Code == subclassMarker && subclassIndex(result, tag.Value.ToString()) >= 0:
+			continue
+		}
+		result = insertTag(result, placeIndex(result, place), tag)
+	}
+	return append(result, xData...)
+}
+
+// subclassIndex returns the index of the marker of the subclass in tags,
+// 0 for the "" subclass before the first marker, or -1.
+func subclassIndex(tags TagSlice, subclass string) int {
+	if subclass == "" {
+		return 0
+	}
+	for i, tag := range tags {
+		if tag.Code == subclassMarker && tag.Value.ToString() == subclass {
+			return i
+		}
+	}
+	return -1
+}
+
+// placeIndex returns the index of tags where a tag in the place goes:
+// after the occurrence of the tag before it in its subclass, or after the
+// last one there is, at the end of the subclass if there is none and at
+// the end of the tags if there is no such subclass.
+func placeIndex(tags TagSlice, place tagPlace) int {
+	end := len(tags)
+	for end > 0 && tags[end-1].Code >= 1000 {
+		end--
+	}
+	start := subclassIndex(tags, place.subclass)
+	if start < 0 {
+		return end
+	}
+	if place.subclass != "" {
+		start++
+	}
+	inAppData := false
+	for i := start; i < end; i++ {
+		if tags[i].Code == appDataMarker {
+			inAppData = tags[i].Value.ToString() != "}"
+		} else if tags[i].Code == subclassMarker && !inAppData {
+			end = i
+			break
+		}
+	}
+
+	switch place.previous {
+	case noTag:
+		return start
+	case endOfSubclass:
+		return end
+	}
+	index, count := end, 0
+	for i := start; i < end; i++ {
+		if tags[i].Code == place.previous {
+			index = i + 1
+			if count++; count == place.occurrence {
+				break
+			}
+		}
+	}
+	return index
+}
+
+// insertTag inserts the tag in tags at the index.
+func insertTag(tags TagSlice, index int, tag *Tag) TagSlice {
+	tags = append(tags, nil)
+	copy(tags[index+1:], tags[index:])
+	tags[index] = tag
+	return tags
+}
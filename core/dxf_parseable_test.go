@@ -1,8 +1,10 @@
 package core
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"strings"
 	"testing"
 )
 
@@ -27,7 +29,7 @@ func TestStringTypeParserInvalidType(t *testing.T) {
 	err := parser.Parse(NewIntegerValue(expected))
 
 	assert.Equal(t,
-		"Error parsing type of &core.Integer{value:1000} as a String",
+		"Error parsing type of Integer \"1000\" as a String",
 		err.Error())
 }
 
@@ -61,7 +63,7 @@ func TestIntTypeParserInvalidType(t *testing.T) {
 	err := parser.Parse(NewFloatValue(expected))
 
 	assert.Equal(t,
-		"Error parsing type of &core.Float{value:10.5} as an Integer",
+		"Error parsing type of Float \"10.5\" as an Integer",
 		err.Error())
 }
 
@@ -95,7 +97,7 @@ func TestFloatTypeParserInvalidType(t *testing.T) {
 	err := parser.Parse(NewStringValue(expected))
 
 	assert.Equal(t,
-		"Error parsing type of &core.String{value:\"INVALID\"} as a Float",
+		"Error parsing type of String \"INVALID\" as a Float",
 		err.Error())
 }
 
@@ -164,10 +166,25 @@ func (suite *DxfElementTestSuite) TestInvalidValidTagType() {
 
 	err := suite.element.Parse(tags)
 	suite.Equal(
-		"Error parsing type of &core.Integer{value:15} as a String",
+		"Error parsing \"15\" (group code 2): Error parsing type of Integer \"15\" as a String",
 		err.Error())
 }
 
+func (suite *DxfElementTestSuite) TestInvalidTagTypeLocation() {
+	tags := TagSlice(AllTags(Tagger(strings.NewReader("  0\nLINE\n  5\n2A\n 20\n1.5\n  2\nName\n"))))
+	tags[3].Value = NewIntegerValue(15)
+
+	err := suite.element.Parse(tags)
+	var parseError *ParseError
+	suite.True(errors.As(err, &parseError))
+	suite.Equal(7, parseError.Line)
+	suite.Equal(2, parseError.Code)
+	suite.Equal("LINE", parseError.EntityType)
+	suite.Equal("2A", parseError.Handle)
+	suite.Equal("Error parsing \"15\" (line 7, group code 2, LINE, handle 2A): "+
+		"Error parsing type of Integer \"15\" as a String", err.Error())
+}
+
 func (suite *DxfElementTestSuite) TestUnregisteredTagIsIgnored() {
 	tags := TagSlice{
 		NewTag(60, NewIntegerValue(15)),
@@ -179,6 +196,98 @@ func (suite *DxfElementTestSuite) TestUnregisteredTagIsIgnored() {
 	suite.Equal(15, suite.intValue)
 }
 
+func (suite *DxfElementTestSuite) TestUnparsedTagsAreKept() {
+	tags := TagSlice{
+		NewTag(0, NewStringValue("ELEMENT")),
+		NewTag(102, NewStringValue("{ACAD_REACTORS")),
+		NewTag(2, NewStringValue("Inside")),
+		NewTag(102, NewStringValue("}")),
+		NewTag(60, NewIntegerValue(15)),
+		NewTag(3, NewStringValue("Fifteen")),
+		NewTag(1001, NewStringValue("APP")),
+		NewTag(1000, NewStringValue("Data")),
+	}
+
+	err := suite.element.Parse(tags)
+	suite.Nil(err)
+	suite.Equal(15, suite.intValue)
+	// the tags of the application groups are not parsed.
+	suite.Equal("", suite.stringValue)
+
+	unparsed := append(TagSlice{tags[0]}, tags[1:4]...)
+	unparsed = append(unparsed, tags[5:]...)
+	suite.Equal(unparsed, suite.element.UnparsedTags())
+
+	suite.Nil(suite.element.Parse(TagSlice{NewTag(60, NewIntegerValue(1))}))
+	suite.Len(suite.element.UnparsedTags(), 0)
+}
+
+func TestWithUnparsedTags(t *testing.T) {
+	var name string
+	var flags int
+	parsers := map[int]TypeParser{
+		2:  NewStringTypeParserToVar(&name),
+		70: NewIntTypeParserToVar(&flags),
+	}
+	// the tags as the writer of the element builds them.
+	written := func() TagSlice {
+		return TagSlice{
+			NewStringTag(0, "LAYER"),
+			NewStringTag(100, "AcDbSymbolTableRecord"),
+			NewStringTag(100, "AcDbLayerTableRecord"),
+			NewStringTag(2, name),
+			NewIntegerTag(70, flags),
+		}
+	}
+
+	tests := []TagSlice{
+		{
+			NewStringTag(0, "LAYER"),
+			NewHandleTag(5, "2F"),
+			NewStringTag(102, "{ACAD_XDICTIONARY"),
+			NewHandleTag(360, "30"),
+			NewStringTag(102, "}"),
+			NewHandleTag(330, "2"),
+			NewStringTag(100, "AcDbSymbolTableRecord"),
+			NewStringTag(100, "AcDbLayerTableRecord"),
+			NewStringTag(2, "WALLS"),
+			NewIntegerTag(70, 4),
+			NewIntegerTag(62, 7),
+			NewIntegerTag(370, -3),
+			NewStringTag(1001, "APP"),
+			NewStringTag(1000, "Data"),
+		},
+		// an unknown subclass, and a code the element parses inside an
+		// application group.
+		{
+			NewStringTag(0, "LAYER"),
+			NewStringTag(100, "AcDbSymbolTableRecord"),
+			NewIntegerTag(71, 0),
+			NewStringTag(100, "AcDbUnknown"),
+			NewStringTag(3, "OTHER"),
+			NewStringTag(102, "{APP"),
+			NewIntegerTag(70, 1),
+			NewStringTag(102, "}"),
+			NewStringTag(100, "AcDbLayerTableRecord"),
+			NewStringTag(2, "WALLS"),
+			NewIntegerTag(70, 4),
+			NewIntegerTag(62, 7),
+		},
+	}
+
+	for _, tags := range tests {
+		var element DxfParseable
+		element.Init(parsers)
+		assert.Nil(t, element.Parse(tags))
+
+		result := element.WithUnparsedTags(written())
+		assert.True(t, tags.Equals(result))
+	}
+
+	var element DxfParseable
+	assert.True(t, written().Equals(element.WithUnparsedTags(written())))
+}
+
 func TestDxfElementTestSuite(t *testing.T) {
 	suite.Run(t, new(DxfElementTestSuite))
 }
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParseError is an error reading or parsing a tag, with its location in the
+// DXF file.
+type ParseError struct {
+	// Line is the line of the group code of the tag in the file, 0 if the tag
+	// was not read from a file.
+	Line  int
+	Code  int
+	Value string
+	// EntityType and Handle identify the element of the tag, like "LINE" and
+	// "2A", when it is known.
+	EntityType string
+	Handle     string
+	Err        error
+}
+
+// Error returns the description of the error, starting with its location.
+func (e *ParseError) Error() string {
+	var location []string
+	if e.Line > 0 {
+		location = append(location, fmt.Sprintf("line %v", e.Line))
+	}
+	location = append(location, fmt.Sprintf("group code %v", e.Code))
+	if e.EntityType != "" {
+		location = append(location, e.EntityType)
+	}
+	if e.Handle != "" {
+		location = append(location, fmt.Sprintf("handle %v", e.Handle))
+	}
+	return fmt.Sprintf("Error parsing %#v (%v): %v", e.Value, strings.Join(location, ", "), e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnknownGroupCode is the cause of the ParseError of a tag with a
+// negative group code. The Tagger returns the tag with its value as a string
+// along with the error. The group codes not in the DXF reference are not
+// errors, their values are read as strings.
+var ErrUnknownGroupCode = errors.New("Unknown group code.")
+
+// ErrTruncatedFile is the cause of the ParseError of a group code at the
+// end of the stream, without its value.
+var ErrTruncatedFile = errors.New("Truncated DXF file, the group code has no value.")
+
+// ErrInvalidSection is the cause of the ParseError of a section that does
+// not start with a (0, SECTION) tag followed by its (2, name) one.
+var ErrInvalidSection = errors.New("Invalid section. Expected a SECTION tag followed by its name.")
+
+// ErrMissingEndSection is the cause of the ParseError of a section without
+// its ENDSEC tag, in a truncated file.
+var ErrMissingEndSection = errors.New("Missing ENDSEC, the section is truncated.")
+
+// ErrInvalidTable is the cause of the error of a table that does not start
+// with a TABLE tag or does not end with an ENDTAB one.
+var ErrInvalidTable = errors.New("Invalid table. Missing TABLE AND/OR ENDTAB tags.")
+
+// ErrInvalidBlock is the cause of the ParseError of an ENDBLK without its
+// BLOCK, or of a BLOCK without its ENDBLK.
+var ErrInvalidBlock = errors.New("Invalid block. Missing BLOCK AND/OR ENDBLK tags.")
+
+// NewParseError returns the ParseError of a tag of the element made of tags.
+func NewParseError(tags TagSlice, tag *Tag, err error) *ParseError {
+	parseError := &ParseError{Line: tag.Line, Code: tag.Code, Value: tag.Value.ToString(), Err: err}
+	parseError.EntityType, parseError.Handle = elementIdentity(tags)
+	return parseError
+}
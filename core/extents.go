@@ -0,0 +1,70 @@
+package core
+
+import "math"
+
+// Extents is an axis aligned bounding box.
+// The zero value is a degenerated box on the origin, use NewExtents to
+// create an empty one that can be grown with Add and Merge.
+type Extents struct {
+	Min Point
+	Max Point
+}
+
+// NewExtents creates an empty Extents.
+func NewExtents() Extents {
+	inf := math.Inf(1)
+	return Extents{
+		Min: Point{X: inf, Y: inf, Z: inf},
+		Max: Point{X: -inf, Y: -inf, Z: -inf},
+	}
+}
+
+// IsEmpty returns true if no point was ever added to the Extents.
+func (e Extents) IsEmpty() bool {
+	return e.Min.X > e.Max.X || e.Min.Y > e.Max.Y || e.Min.Z > e.Max.Z
+}
+
+// Add grows the Extents so that it contains p.
+func (e *Extents) Add(p Point) {
+	e.Min.X = math.Min(e.Min.X, p.X)
+	e.Min.Y = math.Min(e.Min.Y, p.Y)
+	e.Min.Z = math.Min(e.Min.Z, p.Z)
+	e.Max.X = math.Max(e.Max.X, p.X)
+	e.Max.Y = math.Max(e.Max.Y, p.Y)
+	e.Max.Z = math.Max(e.Max.Z, p.Z)
+}
+
+// Merge grows the Extents so that it contains other.
+// Empty Extents are ignored.
+func (e *Extents) Merge(other Extents) {
+	if other.IsEmpty() {
+		return
+	}
+	e.Add(other.Min)
+	e.Add(other.Max)
+}
+
+// Corners returns the 8 corners of the box. An empty Extents has no corners.
+func (e Extents) Corners() PointSlice {
+	if e.IsEmpty() {
+		return PointSlice{}
+	}
+
+	corners := make(PointSlice, 0, 8)
+	for _, x := range []float64{e.Min.X, e.Max.X} {
+		for _, y := range []float64{e.Min.Y, e.Max.Y} {
+			for _, z := range []float64{e.Min.Z, e.Max.Z} {
+				corners = append(corners, Point{X: x, Y: y, Z: z})
+			}
+		}
+	}
+	return corners
+}
+
+// Equals compares two Extents for equality.
+func (e Extents) Equals(other Extents) bool {
+	if e.IsEmpty() || other.IsEmpty() {
+		return e.IsEmpty() == other.IsEmpty()
+	}
+	return e.Min.Equals(other.Min) && e.Max.Equals(other.Max)
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExtents(t *testing.T) {
+	extents := NewExtents()
+	assert.True(t, extents.IsEmpty())
+	assert.Equal(t, 0, len(extents.Corners()))
+
+	extents.Add(Point{1.0, 2.0, 3.0})
+	assert.False(t, extents.IsEmpty())
+	assert.True(t, extents.Min.Equals(Point{1.0, 2.0, 3.0}))
+	assert.True(t, extents.Max.Equals(Point{1.0, 2.0, 3.0}))
+
+	extents.Add(Point{-1.0, 5.0, 0.0})
+	assert.True(t, extents.Min.Equals(Point{-1.0, 2.0, 0.0}))
+	assert.True(t, extents.Max.Equals(Point{1.0, 5.0, 3.0}))
+
+	extents.Merge(NewExtents())
+	assert.True(t, extents.Equals(Extents{Point{-1.0, 2.0, 0.0}, Point{1.0, 5.0, 3.0}}))
+
+	extents.Merge(Extents{Point{0.0, 0.0, 0.0}, Point{10.0, 1.0, 1.0}})
+	assert.True(t, extents.Equals(Extents{Point{-1.0, 0.0, 0.0}, Point{10.0, 5.0, 3.0}}))
+	assert.Equal(t, 8, len(extents.Corners()))
+}
+
+func TestExtentsEquality(t *testing.T) {
+	testCases := []struct {
+		e1     Extents
+		e2     Extents
+		equals bool
+	}{
+		{NewExtents(), NewExtents(), true},
+		{NewExtents(), Extents{}, false},
+		{Extents{}, Extents{}, true},
+		{Extents{Max: Point{1.0, 1.0, 1.0}}, Extents{}, false},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.equals, test.e1.Equals(test.e2), "Test case: %+v", test)
+	}
+}
@@ -54,6 +54,15 @@ var acadRelease = map[string]string{
 	"AC1018": "R2004",
 	"AC1021": "R2007",
 	"AC1024": "R2010",
+	"AC1027": "R2013",
+	"AC1032": "R2018",
+}
+
+// AcadRelease returns the AutoCAD release, like "R2000", of the $ACADVER
+// version and whether it is a known one.
+func AcadRelease(version string) (string, bool) {
+	release, ok := acadRelease[version]
+	return release, ok
 }
 
 func init() {
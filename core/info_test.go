@@ -86,3 +86,16 @@ func TestDXFInfoDefaultAcadVer(t *testing.T) {
 
 	assert.Equal(t, "R12", info.Release)
 }
+
+func TestAcadRelease(t *testing.T) {
+	release, ok := AcadRelease("AC1032")
+	assert.True(t, ok)
+	assert.Equal(t, "R2018", release)
+
+	release, ok = AcadRelease("AC1009")
+	assert.True(t, ok)
+	assert.Equal(t, "R12", release)
+
+	_, ok = AcadRelease("AC1000")
+	assert.False(t, ok)
+}
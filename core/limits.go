@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Limits bound the resources used parsing a DXF file, for the files of
+// untrusted sources. A zero limit is no limit.
+type Limits struct {
+	// MaxTags is the largest number of tags of the file.
+	MaxTags int
+	// MaxEntities is the largest number of entities of the file, counting
+	// the ones in blocks and the nested VERTEX, ATTRIB and SEQEND ones.
+	MaxEntities int
+	// MaxInsertDepth is the deepest nesting of the blocks referenced by an
+	// INSERT. An INSERT of a block without INSERTs has a depth of 1, and
+	// the blocks inserting themselves exceed any limit.
+	MaxInsertDepth int
+	// MaxStringLength is the length of the longest string value, in bytes.
+	MaxStringLength int
+}
+
+// ErrLimitExceeded is the cause of the errors of the files exceeding the
+// Limits of their parsing. They fail the parsing in Lenient mode too.
+var ErrLimitExceeded = errors.New("Parse limit exceeded.")
+
+// cancelCheckInterval is the number of tags or entities read between the
+// checks of the cancellation of the parsing.
+const cancelCheckInterval = 1024
+
+// Limiter enforces the Limits of a parsing and stops it when its context is
+// done. The nil *Limiter limits nothing.
+type Limiter struct {
+	ctx      context.Context
+	limits   Limits
+	tags     int
+	entities int
+}
+
+// NewLimiter creates a new Limiter of a parsing with the limits, cancelled
+// with the ctx.
+func NewLimiter(ctx context.Context, limits Limits) *Limiter {
+	return &Limiter{ctx: ctx, limits: limits}
+}
+
+// Err returns the error of the context of the parsing when it is done, the
+// ErrLimitExceeded ones are returned as they happen.
+func (l *Limiter) Err() error {
+	if l == nil {
+		return nil
+	}
+	return l.ctx.Err()
+}
+
+// Tags returns a NextTagFunction that returns the tags of next, failing when
+// there are more than MaxTags or a string value is longer than
+// MaxStringLength, and when the context is done.
+func (l *Limiter) Tags(next NextTagFunction) NextTagFunction {
+	if l == nil {
+		return next
+	}
+
+	return func() (*Tag, error) {
+		tag, err := next()
+		if *tag == NoneTag {
+			return tag, err
+		}
+
+		l.tags++
+		if l.limits.MaxTags > 0 && l.tags > l.limits.MaxTags {
+			return &NoneTag, fmt.Errorf("%w More than %v tags.", ErrLimitExceeded, l.limits.MaxTags)
+		}
+		if value, ok := AsString(tag.Value); ok && l.limits.MaxStringLength > 0 &&
+			len(value) > l.limits.MaxStringLength {
+			return &NoneTag, &ParseError{Line: tag.Line, Code: tag.Code, Value: value[:l.limits.MaxStringLength],
+				Err: fmt.Errorf("%w String longer than %v bytes.", ErrLimitExceeded, l.limits.MaxStringLength)}
+		}
+		if l.tags%cancelCheckInterval == 0 {
+			if err := l.ctx.Err(); err != nil {
+				return &NoneTag, err
+			}
+		}
+		return tag, err
+	}
+}
+
+// Tagger returns the Tagger of the stream limited by Tags. The lines longer
+// than MaxStringLength fail as soon as they are read, before they are
+// buffered whole, but the lines of the group codes and numbers are allowed
+// shortLineLength bytes at least.
+func (l *Limiter) Tagger(stream io.Reader) NextTagFunction {
+	if l == nil || l.limits.MaxStringLength <= 0 {
+		return l.Tags(Tagger(stream))
+	}
+
+	maxLength := l.limits.MaxStringLength
+	if maxLength < shortLineLength {
+		maxLength = shortLineLength
+	}
+	tooLong := fmt.Errorf("%w String longer than %v bytes.", ErrLimitExceeded, l.limits.MaxStringLength)
+	return l.Tags(tagger(newLimitedLineReader(stream, maxLength, tooLong)))
+}
+
+// AddEntity counts an entity of the file, failing when there are more than
+// MaxEntities and when the context is done.
+func (l *Limiter) AddEntity() error {
+	if l == nil {
+		return nil
+	}
+
+	l.entities++
+	if l.limits.MaxEntities > 0 && l.entities > l.limits.MaxEntities {
+		return fmt.Errorf("%w More than %v entities.", ErrLimitExceeded, l.limits.MaxEntities)
+	}
+	if l.entities%cancelCheckInterval == 0 {
+		return l.ctx.Err()
+	}
+	return nil
+}
+
+// CheckEntities fails when count entities more than the ones counted by
+// AddEntity would be more than MaxEntities, to stop before building them.
+// It does not count them.
+func (l *Limiter) CheckEntities(count int) error {
+	if l != nil && l.limits.MaxEntities > 0 && l.entities+count > l.limits.MaxEntities {
+		return fmt.Errorf("%w More than %v entities.", ErrLimitExceeded, l.limits.MaxEntities)
+	}
+	return nil
+}
+
+// CheckInsertDepth fails when the depth of the blocks nested by INSERTs is
+// deeper than MaxInsertDepth.
+func (l *Limiter) CheckInsertDepth(depth int) error {
+	if l != nil && l.limits.MaxInsertDepth > 0 && depth > l.limits.MaxInsertDepth {
+		return fmt.Errorf("%w INSERTs nested deeper than %v blocks.", ErrLimitExceeded, l.limits.MaxInsertDepth)
+	}
+	return nil
+}
+
+// MaxInsertDepth returns the MaxInsertDepth limit, 0 when there is none.
+func (l *Limiter) MaxInsertDepth() int {
+	if l == nil {
+		return 0
+	}
+	return l.limits.MaxInsertDepth
+}
+
+// isFatal tells if the err stops the parsing in Lenient mode too: an
+// exceeded limit or a cancellation.
+func isFatal(err error) bool {
+	return errors.Is(err, ErrLimitExceeded) || errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLimiterTags(t *testing.T) {
+	limiter := NewLimiter(context.Background(), Limits{MaxTags: 4})
+	tags, err := ReadAllTags(limiter.Tags(Tagger(strings.NewReader(regularDXF))))
+
+	assert.Len(t, tags, 4)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	assert.Equal(t, "Parse limit exceeded. More than 4 tags.", err.Error())
+}
+
+func TestLimiterStringLength(t *testing.T) {
+	limiter := NewLimiter(context.Background(), Limits{MaxStringLength: 5})
+	tags, err := ReadAllTags(limiter.Tags(Tagger(strings.NewReader("  0\nLINE\n  8\nLAYER_0\n 10\n1234567.0\n"))))
+
+	assert.Len(t, tags, 1)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	var parseError *ParseError
+	assert.True(t, errors.As(err, &parseError))
+	assert.Equal(t, 3, parseError.Line)
+	assert.Equal(t, "LAYER", parseError.Value)
+
+	// the limits are not recovered from in lenient mode.
+	diagnostics := NewDiagnostics(ParseOptions{Mode: Lenient})
+	limiter = NewLimiter(context.Background(), Limits{MaxStringLength: 5})
+	_, err = diagnostics.ReadAllTags(limiter.Tags(Tagger(strings.NewReader("  0\nLINE\n  8\nLAYER_0\n"))))
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	assert.Len(t, diagnostics.List, 0)
+}
+
+func TestLimiterTaggerLongLine(t *testing.T) {
+	// an endless line fails as soon as it is longer than the limit.
+	limiter := NewLimiter(context.Background(), Limits{MaxStringLength: 1000})
+	stream := io.MultiReader(strings.NewReader("  0\nLINE\n  1\n"), &repeatReader{'A'})
+	tags, err := ReadAllTags(limiter.Tagger(stream))
+
+	assert.Len(t, tags, 1)
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	assert.Equal(t, "Error parsing \"\" (line 3, group code 1): Parse limit exceeded. String longer than 1000 bytes.", err.Error())
+
+	// the short lines are allowed shortLineLength bytes at least.
+	limiter = NewLimiter(context.Background(), Limits{MaxStringLength: 2})
+	tags, err = ReadAllTags(limiter.Tagger(strings.NewReader("  0\nAB\n 10\n0.123456789\n")))
+	assert.Nil(t, err)
+	assert.Len(t, tags, 2)
+}
+
+func TestLimiterCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter := NewLimiter(ctx, Limits{})
+
+	dxf := strings.Repeat("999\ncomment\n", 2*cancelCheckInterval)
+	tags, err := ReadAllTags(limiter.Tags(Tagger(strings.NewReader(dxf))))
+
+	assert.Len(t, tags, cancelCheckInterval-1)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, context.Canceled, limiter.Err())
+}
+
+func TestLimiterEntities(t *testing.T) {
+	limiter := NewLimiter(context.Background(), Limits{MaxEntities: 2, MaxInsertDepth: 3})
+
+	assert.Nil(t, limiter.AddEntity())
+	assert.Nil(t, limiter.AddEntity())
+	err := limiter.AddEntity()
+	assert.True(t, errors.Is(err, ErrLimitExceeded))
+	assert.Equal(t, "Parse limit exceeded. More than 2 entities.", err.Error())
+
+	assert.Equal(t, 3, limiter.MaxInsertDepth())
+	assert.Nil(t, limiter.CheckInsertDepth(3))
+	assert.True(t, errors.Is(limiter.CheckInsertDepth(4), ErrLimitExceeded))
+}
+
+func TestNilLimiter(t *testing.T) {
+	var limiter *Limiter
+
+	tags, err := ReadAllTags(limiter.Tags(Tagger(strings.NewReader(regularDXF))))
+	assert.Nil(t, err)
+	assert.True(t, TagSlice(expectedRegularDxfTags).Equals(tags))
+	assert.Nil(t, limiter.AddEntity())
+	assert.Nil(t, limiter.Err())
+	assert.Equal(t, 0, limiter.MaxInsertDepth())
+	assert.Nil(t, limiter.CheckInsertDepth(1000))
+}
@@ -0,0 +1,173 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+)
+
+// readChunkSize is the size of the reads of the stream of a lineReader.
+const readChunkSize = 64 * 1024
+
+// maxEmptyReads is the number of reads returning no data and no error after
+// which a lineReader fails with io.ErrNoProgress.
+const maxEmptyReads = 100
+
+// lineReader reads the lines of a stream without copying them one by one:
+// each chunk read from the stream is converted to a string once and the
+// lines are slices of it. A chunk is kept in memory while any of its lines,
+// or the values sliced from them, is in use.
+type lineReader struct {
+	stream io.Reader
+	// maxLength is the length of the longest line, the longer ones fail
+	// with the tooLong error as soon as they are read.
+	maxLength int
+	tooLong   error
+	buffer    []byte
+	// data is the part of the last chunk not returned yet.
+	data string
+	eof  bool
+	err  error
+}
+
+// newLineReader creates a lineReader of the stream failing with a
+// bufio.ErrTooLong error on the lines longer than maxLineLength.
+func newLineReader(stream io.Reader) *lineReader {
+	return newLimitedLineReader(stream, maxLineLength, bufio.ErrTooLong)
+}
+
+// newLimitedLineReader creates a lineReader of the stream failing with the
+// tooLong error on the lines longer than maxLength, up to maxLineLength.
+func newLimitedLineReader(stream io.Reader, maxLength int, tooLong error) *lineReader {
+	if maxLength > maxLineLength {
+		maxLength, tooLong = maxLineLength, bufio.ErrTooLong
+	}
+	return &lineReader{stream: stream, maxLength: maxLength, tooLong: tooLong}
+}
+
+// readLine returns the next line without its line ending and false at the
+// end of the stream. The errors reading the stream are returned after its
+// last line, a line longer than maxLength as the tooLong one.
+func (r *lineReader) readLine() (string, bool, error) {
+	for {
+		if advance, line, ok := splitLine(r.data, r.eof); ok {
+			if len(line) > r.maxLength {
+				return "", false, r.tooLong
+			}
+			r.data = r.data[advance:]
+			return line, true, nil
+		}
+		if r.eof {
+			return "", false, r.err
+		}
+		if len(r.data) > r.maxLength {
+			return "", false, r.tooLong
+		}
+		r.fill()
+	}
+}
+
+// fill reads the stream after the data not returned yet until it reads a
+// line ending, or more than maxLength bytes, and converts the data to a
+// string. The buffer grows for the long lines, up to maxLength.
+func (r *lineReader) fill() {
+	bufferLimit := r.maxLength + 1
+	if bufferLimit < readChunkSize {
+		bufferLimit = readChunkSize
+	}
+	size := readChunkSize
+	if 2*len(r.data) > size {
+		size = 2 * len(r.data)
+	}
+	if size > bufferLimit {
+		size = bufferLimit
+	}
+	if len(r.buffer) < size {
+		r.buffer = make([]byte, size)
+	}
+	n := copy(r.buffer, r.data)
+
+	for emptyReads := 0; n <= r.maxLength; {
+		if n == len(r.buffer) {
+			grown := 2 * len(r.buffer)
+			if grown > bufferLimit {
+				grown = bufferLimit
+			}
+			buffer := make([]byte, grown)
+			copy(buffer, r.buffer)
+			r.buffer = buffer
+		}
+
+		read, err := r.stream.Read(r.buffer[n:])
+		n += read
+		if err != nil {
+			r.eof = true
+			if err != io.EOF {
+				r.err = err
+			}
+			break
+		}
+		if bytes.IndexAny(r.buffer[n-read:n], "\r\n") >= 0 {
+			break
+		}
+		if read > 0 {
+			emptyReads = 0
+		} else if emptyReads++; emptyReads == maxEmptyReads {
+			r.eof = true
+			r.err = io.ErrNoProgress
+			break
+		}
+	}
+	r.data = string(r.buffer[:n])
+}
+
+// splitLine splits the first line of data, ending with CR LF, LF or a
+// single CR, returning the length of the line with its line ending, the
+// line without it and true. It returns false when data has no complete
+// line yet: the last line is complete only atEOF.
+func splitLine(data string, atEOF bool) (advance int, line string, ok bool) {
+	if atEOF && len(data) == 0 {
+		return 0, "", false
+	}
+	if i := indexLineEnding(data); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], true
+		}
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], true
+			}
+			return i + 1, data[:i], true
+		}
+		if atEOF {
+			return i + 1, data[:i], true
+		}
+		// a CR at the end of the data, it may be followed by a LF.
+		return 0, "", false
+	}
+	if atEOF {
+		return len(data), data, true
+	}
+	return 0, "", false
+}
+
+// shortLineLength is the length of the window of the data where
+// indexLineEnding looks for a LF first: most lines are shorter.
+const shortLineLength = 256
+
+// indexLineEnding returns the index of the first CR or LF of data, or -1.
+// It is strings.IndexAny, faster for the short lines ending with a LF.
+func indexLineEnding(data string) int {
+	window := data
+	if len(window) > shortLineLength {
+		window = window[:shortLineLength]
+	}
+	if i := strings.IndexByte(window, '\n'); i >= 0 {
+		if cr := strings.IndexByte(window[:i], '\r'); cr >= 0 {
+			return cr
+		}
+		return i
+	}
+	return strings.IndexAny(data, "\r\n")
+}
@@ -1,8 +1,40 @@
 package core
 
-import (
-	"log"
-	"os"
-)
+// Logger is the interface of the loggers of this library, a *slog.Logger
+// implements it. The args are the structured fields of the message as
+// alternating keys and values, like "type", "LINE", "handle", "2A".
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
 
-var Log *log.Logger = log.New(os.Stderr, "dxf-go", 0)
+// NopLogger is a Logger that discards all its messages.
+type NopLogger struct{}
+
+// Debug discards the message.
+func (NopLogger) Debug(msg string, args ...interface{}) {}
+
+// Info discards the message.
+func (NopLogger) Info(msg string, args ...interface{}) {}
+
+// Warn discards the message.
+func (NopLogger) Warn(msg string, args ...interface{}) {}
+
+// Error discards the message.
+func (NopLogger) Error(msg string, args ...interface{}) {}
+
+// WriteOptions configures the writing of a DXF file.
+type WriteOptions struct {
+	// Logger gets the messages of the writing, like the entities skipped
+	// because they cannot be written. They are discarded when it is nil.
+	Logger Logger
+}
+
+// Warn logs the warning of the writing to the Logger, if any.
+func (options WriteOptions) Warn(msg string, args ...interface{}) {
+	if options.Logger != nil {
+		options.Logger.Warn(msg, args...)
+	}
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+var _ Logger = slog.Default()
+var _ Logger = NopLogger{}
+
+func TestDiagnosticsLogger(t *testing.T) {
+	var buffer bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buffer, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	diagnostics := NewDiagnostics(ParseOptions{Mode: Lenient, Logger: logger})
+
+	tags := TagSlice(AllTags(Tagger(strings.NewReader("  0\nLINE\n  5\n2A\n 10\n1.0\n"))))
+	diagnostics.Report(SeverityInfo, tags, "Filtered by the level")
+	diagnostics.Report(SeverityWarning, tags, "Odd %v", "LINE")
+
+	var record map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &record))
+	assert.Equal(t, "WARN", record["level"])
+	assert.Equal(t, "Odd LINE", record["msg"])
+	assert.Equal(t, float64(1), record["line"])
+	assert.Equal(t, float64(0), record["code"])
+	assert.Equal(t, "LINE", record["type"])
+	assert.Equal(t, "2A", record["handle"])
+	assert.Len(t, diagnostics.List, 2)
+}
+
+func TestDiagnosticsWithoutLogger(t *testing.T) {
+	// the nil Diagnostics discard the messages, the others collect them.
+	var strict *Diagnostics
+	strict.Report(SeverityError, nil, "Broken")
+	diagnostics := NewDiagnostics(ParseOptions{Mode: Lenient})
+	diagnostics.Report(SeverityWarning, nil, "Odd")
+	assert.Len(t, diagnostics.List, 1)
+}
+
+func TestWriteOptionsWarn(t *testing.T) {
+	var buffer bytes.Buffer
+	options := WriteOptions{Logger: slog.New(slog.NewTextHandler(&buffer, nil))}
+	options.Warn("Skipped", "type", "LINE")
+	assert.Contains(t, buffer.String(), "msg=Skipped type=LINE")
+
+	// the messages are discarded without a Logger.
+	WriteOptions{}.Warn("Skipped")
+}
@@ -0,0 +1,114 @@
+package core
+
+import "math"
+
+// Matrix an affine transformation of 3d points. It is stored by rows, the
+// last column holds the translation.
+type Matrix [3][4]float64
+
+// IdentityMatrix returns the transformation that keeps points unchanged.
+func IdentityMatrix() Matrix {
+	return Matrix{
+		{1.0, 0.0, 0.0, 0.0},
+		{0.0, 1.0, 0.0, 0.0},
+		{0.0, 0.0, 1.0, 0.0},
+	}
+}
+
+// TranslationMatrix returns the transformation that moves points by offset.
+func TranslationMatrix(offset Point) Matrix {
+	return Matrix{
+		{1.0, 0.0, 0.0, offset.X},
+		{0.0, 1.0, 0.0, offset.Y},
+		{0.0, 0.0, 1.0, offset.Z},
+	}
+}
+
+// ScaleMatrix returns the transformation that scales points from the origin.
+func ScaleMatrix(x float64, y float64, z float64) Matrix {
+	return Matrix{
+		{x, 0.0, 0.0, 0.0},
+		{0.0, y, 0.0, 0.0},
+		{0.0, 0.0, z, 0.0},
+	}
+}
+
+// RotationZMatrix returns the transformation that rotates points around the Z
+// axis, counter-clockwise by angle radians.
+func RotationZMatrix(angle float64) Matrix {
+	cos, sin := math.Cos(angle), math.Sin(angle)
+	return Matrix{
+		{cos, -sin, 0.0, 0.0},
+		{sin, cos, 0.0, 0.0},
+		{0.0, 0.0, 1.0, 0.0},
+	}
+}
+
+// OCSMatrix returns the transformation from the OCS defined by extrusion to
+// World Coordinates, see OCSToWCS.
+func OCSMatrix(extrusion Point) Matrix {
+	xAxis, yAxis, zAxis := OCSAxes(extrusion)
+	return Matrix{
+		{xAxis.X, yAxis.X, zAxis.X, 0.0},
+		{xAxis.Y, yAxis.Y, zAxis.Y, 0.0},
+		{xAxis.Z, yAxis.Z, zAxis.Z, 0.0},
+	}
+}
+
+// Multiply returns the composition of m and other, the transformation that
+// applies other first and then m.
+func (m Matrix) Multiply(other Matrix) Matrix {
+	var result Matrix
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 4; column++ {
+			value := 0.0
+			for i := 0; i < 3; i++ {
+				value += m[row][i] * other[i][column]
+			}
+			if column == 3 {
+				value += m[row][3]
+			}
+			result[row][column] = value
+		}
+	}
+	return result
+}
+
+// Apply transforms the point p.
+func (m Matrix) Apply(p Point) Point {
+	return Point{
+		X: m[0][0]*p.X + m[0][1]*p.Y + m[0][2]*p.Z + m[0][3],
+		Y: m[1][0]*p.X + m[1][1]*p.Y + m[1][2]*p.Z + m[1][3],
+		Z: m[2][0]*p.X + m[2][1]*p.Y + m[2][2]*p.Z + m[2][3],
+	}
+}
+
+// ApplyVector transforms the direction v, ignoring the translation.
+func (m Matrix) ApplyVector(v Point) Point {
+	return Point{
+		X: m[0][0]*v.X + m[0][1]*v.Y + m[0][2]*v.Z,
+		Y: m[1][0]*v.X + m[1][1]*v.Y + m[1][2]*v.Z,
+		Z: m[2][0]*v.X + m[2][1]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+// ApplySlice transforms all the points, returning a new PointSlice.
+func (m Matrix) ApplySlice(points PointSlice) PointSlice {
+	result := make(PointSlice, len(points))
+	for i, point := range points {
+		result[i] = m.Apply(point)
+	}
+	return result
+}
+
+// Equals compares the matrix to the other Matrix for equality.
+func (m Matrix) Equals(other Matrix) bool {
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 4; column++ {
+			if !FloatEquals(m[row][column], other[row][column]) {
+				return false
+			}
+		}
+	}
+	return true
+}
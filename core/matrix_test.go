@@ -0,0 +1,54 @@
+package core
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestMatrixApply(t *testing.T) {
+	p := Point{1.0, 2.0, 3.0}
+
+	testCases := []struct {
+		matrix   Matrix
+		expected Point
+	}{
+		{IdentityMatrix(), Point{1.0, 2.0, 3.0}},
+		{TranslationMatrix(Point{1.0, -1.0, 0.5}), Point{2.0, 1.0, 3.5}},
+		{ScaleMatrix(2.0, -1.0, 0.0), Point{2.0, -2.0, 0.0}},
+		{RotationZMatrix(math.Pi / 2.0), Point{-2.0, 1.0, 3.0}},
+		{OCSMatrix(Point{0.0, 0.0, -1.0}), Point{-1.0, 2.0, -3.0}},
+		{OCSMatrix(Point{0.3, -0.5, 0.8}), OCSToWCS(p, Point{0.3, -0.5, 0.8})},
+	}
+
+	for _, test := range testCases {
+		assert.True(t, test.expected.Equals(test.matrix.Apply(p)),
+			"Test case: %+v, got %+v", test, test.matrix.Apply(p))
+	}
+}
+
+func TestMatrixMultiply(t *testing.T) {
+	translation := TranslationMatrix(Point{10.0, 0.0, 0.0})
+	rotation := RotationZMatrix(math.Pi / 2.0)
+	p := Point{1.0, 0.0, 0.0}
+
+	// rotates first, then translates.
+	m := translation.Multiply(rotation)
+	assert.True(t, Point{10.0, 1.0, 0.0}.Equals(m.Apply(p)))
+	assert.True(t, translation.Apply(rotation.Apply(p)).Equals(m.Apply(p)))
+
+	// the translation is rotated.
+	m = rotation.Multiply(translation)
+	assert.True(t, Point{0.0, 11.0, 0.0}.Equals(m.Apply(p)))
+
+	assert.True(t, m.Equals(m.Multiply(IdentityMatrix())))
+	assert.True(t, m.Equals(IdentityMatrix().Multiply(m)))
+	assert.False(t, m.Equals(IdentityMatrix()))
+}
+
+func TestMatrixApplyVector(t *testing.T) {
+	m := TranslationMatrix(Point{10.0, 5.0, 0.0}).Multiply(ScaleMatrix(2.0, 2.0, 2.0))
+	assert.True(t, Point{2.0, 0.0, 0.0}.Equals(m.ApplyVector(Point{1.0, 0.0, 0.0})))
+	assert.True(t, PointSlice{{12.0, 5.0, 0.0}, {10.0, 7.0, 0.0}}.Equals(
+		m.ApplySlice(PointSlice{{1.0, 0.0, 0.0}, {0.0, 1.0, 0.0}})))
+}
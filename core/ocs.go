@@ -0,0 +1,38 @@
+package core
+
+import "math"
+
+// arbitraryAxisLimit is the threshold used by the DXF arbitrary axis algorithm
+// to decide which world axis is used to build the OCS X axis.
+const arbitraryAxisLimit = 1.0 / 64.0
+
+// OCSAxes returns the X, Y and Z unit axes of the Object Coordinate System
+// defined by the extrusion direction, following the DXF arbitrary axis algorithm.
+func OCSAxes(extrusion Point) (Point, Point, Point) {
+	zAxis := extrusion.Normalize()
+	if zAxis.Length() == 0 {
+		zAxis = Point{X: 0.0, Y: 0.0, Z: 1.0}
+	}
+
+	var xAxis Point
+	if math.Abs(zAxis.X) < arbitraryAxisLimit && math.Abs(zAxis.Y) < arbitraryAxisLimit {
+		xAxis = Point{Y: 1.0}.Cross(zAxis).Normalize()
+	} else {
+		xAxis = Point{Z: 1.0}.Cross(zAxis).Normalize()
+	}
+	yAxis := zAxis.Cross(xAxis).Normalize()
+
+	return xAxis, yAxis, zAxis
+}
+
+// OCSToWCS converts p from the OCS defined by extrusion to World Coordinates.
+func OCSToWCS(p Point, extrusion Point) Point {
+	xAxis, yAxis, zAxis := OCSAxes(extrusion)
+	return xAxis.Scale(p.X).Add(yAxis.Scale(p.Y)).Add(zAxis.Scale(p.Z))
+}
+
+// WCSToOCS converts p from World Coordinates to the OCS defined by extrusion.
+func WCSToOCS(p Point, extrusion Point) Point {
+	xAxis, yAxis, zAxis := OCSAxes(extrusion)
+	return Point{X: p.Dot(xAxis), Y: p.Dot(yAxis), Z: p.Dot(zAxis)}
+}
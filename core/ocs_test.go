@@ -0,0 +1,35 @@
+package core
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestOCSAxes(t *testing.T) {
+	testCases := []struct {
+		extrusion Point
+		x, y, z   Point
+	}{
+		{Point{0.0, 0.0, 1.0}, Point{1.0, 0.0, 0.0}, Point{0.0, 1.0, 0.0}, Point{0.0, 0.0, 1.0}},
+		{Point{0.0, 0.0, -1.0}, Point{-1.0, 0.0, 0.0}, Point{0.0, 1.0, 0.0}, Point{0.0, 0.0, -1.0}},
+		{Point{0.0, 0.0, 0.0}, Point{1.0, 0.0, 0.0}, Point{0.0, 1.0, 0.0}, Point{0.0, 0.0, 1.0}},
+		{Point{1.0, 0.0, 0.0}, Point{0.0, 1.0, 0.0}, Point{0.0, 0.0, 1.0}, Point{1.0, 0.0, 0.0}},
+	}
+
+	for _, test := range testCases {
+		x, y, z := OCSAxes(test.extrusion)
+		assert.True(t, test.x.Equals(x), "Test case: %+v, x: %+v", test, x)
+		assert.True(t, test.y.Equals(y), "Test case: %+v, y: %+v", test, y)
+		assert.True(t, test.z.Equals(z), "Test case: %+v, z: %+v", test, z)
+	}
+}
+
+func TestOCSConversion(t *testing.T) {
+	extrusion := Point{0.3, -0.5, 0.8}
+	p := Point{1.0, 2.0, 3.0}
+
+	wcs := OCSToWCS(p, extrusion)
+	assert.InDelta(t, p.Length(), wcs.Length(), MinFloatDelta)
+	assert.True(t, p.Equals(WCSToOCS(wcs, extrusion)))
+	assert.True(t, Point{-1.0, 2.0, -3.0}.Equals(OCSToWCS(p, Point{0.0, 0.0, -1.0})))
+}
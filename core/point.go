@@ -1,5 +1,7 @@
 package core
 
+import "math"
+
 // Point 3d point representation
 type Point struct {
 	X float64
@@ -33,3 +35,66 @@ func (p PointSlice) Equals(other PointSlice) bool {
 
 	return true
 }
+
+// Add returns the vector sum of p and other.
+func (p Point) Add(other Point) Point {
+	return Point{X: p.X + other.X, Y: p.Y + other.Y, Z: p.Z + other.Z}
+}
+
+// Sub returns the vector difference p - other.
+func (p Point) Sub(other Point) Point {
+	return Point{X: p.X - other.X, Y: p.Y - other.Y, Z: p.Z - other.Z}
+}
+
+// Scale returns p with all its components multiplied by factor.
+func (p Point) Scale(factor float64) Point {
+	return Point{X: p.X * factor, Y: p.Y * factor, Z: p.Z * factor}
+}
+
+// Dot returns the dot product of p and other.
+func (p Point) Dot(other Point) float64 {
+	return p.X*other.X + p.Y*other.Y + p.Z*other.Z
+}
+
+// Cross returns the cross product of p and other.
+func (p Point) Cross(other Point) Point {
+	return Point{
+		X: p.Y*other.Z - p.Z*other.Y,
+		Y: p.Z*other.X - p.X*other.Z,
+		Z: p.X*other.Y - p.Y*other.X,
+	}
+}
+
+// Length returns the euclidean length of p as a vector.
+func (p Point) Length() float64 {
+	return math.Sqrt(p.Dot(p))
+}
+
+// Normalize returns the unit vector with the same direction as p.
+// A zero length vector is returned unchanged.
+func (p Point) Normalize() Point {
+	length := p.Length()
+	if length == 0 {
+		return p
+	}
+	return p.Scale(1 / length)
+}
+
+// Distance returns the euclidean distance between p and other.
+func (p Point) Distance(other Point) float64 {
+	return p.Sub(other).Length()
+}
+
+// DistanceToSegment returns the euclidean distance between p and the closest
+// point of the segment from start to end.
+func (p Point) DistanceToSegment(start Point, end Point) float64 {
+	direction := end.Sub(start)
+	lengthSquared := direction.Dot(direction)
+	if lengthSquared == 0 {
+		return p.Distance(start)
+	}
+
+	t := p.Sub(start).Dot(direction) / lengthSquared
+	t = math.Max(0.0, math.Min(1.0, t))
+	return p.Distance(start.Add(direction.Scale(t)))
+}
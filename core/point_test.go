@@ -51,3 +51,35 @@ func TestPointSliceEquality(t *testing.T) {
 		assert.Equal(t, test.p1.Equals(test.p2), test.equals)
 	}
 }
+
+func TestPointVectorOperations(t *testing.T) {
+	p1 := Point{1.0, 2.0, 3.0}
+	p2 := Point{4.0, -5.0, 6.0}
+
+	assert.True(t, Point{5.0, -3.0, 9.0}.Equals(p1.Add(p2)))
+	assert.True(t, Point{-3.0, 7.0, -3.0}.Equals(p1.Sub(p2)))
+	assert.True(t, Point{2.0, 4.0, 6.0}.Equals(p1.Scale(2.0)))
+	assert.Equal(t, 12.0, p1.Dot(p2))
+	assert.True(t, Point{27.0, 6.0, -13.0}.Equals(p1.Cross(p2)))
+	assert.Equal(t, 5.0, Point{3.0, 4.0, 0.0}.Length())
+	assert.True(t, Point{0.6, 0.8, 0.0}.Equals(Point{3.0, 4.0, 0.0}.Normalize()))
+	assert.True(t, Point{}.Equals(Point{}.Normalize()))
+	assert.Equal(t, 5.0, Point{1.0, 1.0, 1.0}.Distance(Point{4.0, 5.0, 1.0}))
+}
+
+func TestPointDistanceToSegment(t *testing.T) {
+	testCases := []struct {
+		p, start, end Point
+		distance      float64
+	}{
+		{Point{1.0, 1.0, 0.0}, Point{0.0, 0.0, 0.0}, Point{2.0, 0.0, 0.0}, 1.0},
+		{Point{-3.0, 4.0, 0.0}, Point{0.0, 0.0, 0.0}, Point{2.0, 0.0, 0.0}, 5.0},
+		{Point{5.0, 4.0, 0.0}, Point{0.0, 0.0, 0.0}, Point{2.0, 0.0, 0.0}, 5.0},
+		{Point{3.0, 4.0, 0.0}, Point{0.0, 0.0, 0.0}, Point{0.0, 0.0, 0.0}, 5.0},
+	}
+
+	for _, test := range testCases {
+		assert.InDelta(t, test.distance, test.p.DistanceToSegment(test.start, test.end),
+			MinFloatDelta, "Test case: %+v", test)
+	}
+}
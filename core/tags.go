@@ -1,7 +1,7 @@
 package core
 
 import (
-	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -12,6 +12,9 @@ import (
 type Tag struct {
 	Code  int
 	Value DataType
+	// Line is the line of the group code in the file the tag was read from,
+	// 0 for the tags built in code. It is not compared by Equals.
+	Line int
 }
 
 // ToString returns a string representation of this Tag.
@@ -22,7 +25,7 @@ func (tag Tag) ToString() string {
 // Equals tests equality against another Tag.
 func (tag Tag) Equals(other DxfElement) bool {
 	if otherTag, ok := other.(*Tag); ok {
-		return tag.Code == otherTag.Code && tag.Value.Equals(otherTag.Value)
+		return tag.Code == otherTag.Code && tag.Value.equals(otherTag.Value)
 	}
 	return false
 }
@@ -36,7 +39,7 @@ func NewTag(code int, value DataType) *Tag {
 }
 
 // NoneTag a constant that represents a nul tag.
-var NoneTag = Tag{999999, &String{"NONE"}}
+var NoneTag = Tag{Code: 999999, Value: NewStringValue("NONE")}
 
 const appDataMarker = 102
 const subclassMarker = 100
@@ -44,49 +47,120 @@ const subclassMarker = 100
 // NextTagFunction is the prototype of a function that returns the next Tag in a stream.
 type NextTagFunction func() (*Tag, error)
 
+// maxLineLength is the length of the longest line the Tagger reads.
+const maxLineLength = 64 * 1024 * 1024
+
+// byteOrderMark is the UTF-8 BOM some editors write at the start of the
+// files.
+const byteOrderMark = "\ufeff"
+
+// tagSlabSize is the number of tags the Tagger allocates at once.
+const tagSlabSize = 256
+
 // Tagger function. Returns a NextTagFunction that, in turn, returns the tags
 // from the stream sequentially each time it is called. It finishes when it returns
-// an error or a NoneTag.
+// an error or a NoneTag, after the (0, EOF) tag or at the end of the stream.
+// The malformed group codes and values are returned as a *ParseError with
+// their line, a group code without its value as an ErrTruncatedFile one and
+// the tags with a negative group code along with an ErrUnknownGroupCode one.
+// The values of the group codes not in the DXF reference, like the ones of
+// the gaps between its ranges, are read as strings.
+// The lines can end with CR LF, LF or CR only, and a leading BOM is skipped.
+// The errors reading the stream, like a bufio.ErrTooLong line, are returned
+// as they are.
+//
+// The string values are decoded to UTF-8: the files older than R2007 in the
+// encoding of their $DWGCODEPAGE and the \U+XXXX and \M+nXXXX escapes of
+// all of them (see DecodeString). The values that need no decoding are not
+// copied: they share the memory of the chunk of the stream they were read
+// from. The tags are allocated in slabs of tagSlabSize.
 func Tagger(stream io.Reader) NextTagFunction {
-	counter := 0
-	scanner := bufio.NewScanner(stream)
+	return tagger(newLineReader(stream))
+}
 
-	readLine := func() (string, error) {
-		if scanner.Scan() {
-			return scanner.Text(), nil
-		} else if err := scanner.Err(); err != nil {
-			return "", err
+// tagger is the Tagger of the lines. Their ErrLimitExceeded errors are
+// returned as a *ParseError with the line of the group code.
+func tagger(lines *lineReader) NextTagFunction {
+	counter := 0
+	done := false
+	var codePage codePageTracker
+	var slab []Tag
+
+	// readLine returns the next line and false at the end of the stream.
+	readLine := func() (string, bool, error) {
+		line, ok, err := lines.readLine()
+		if !ok {
+			return "", false, err
+		}
+		counter++
+		if counter == 1 {
+			line = strings.TrimPrefix(line, byteOrderMark)
 		}
+		return line, true, nil
+	}
 
-		return "", nil
+	newTag := func(code int, value DataType, line int) *Tag {
+		if len(slab) == 0 {
+			slab = make([]Tag, tagSlabSize)
+		}
+		tag := &slab[0]
+		slab = slab[1:]
+		*tag = Tag{Code: code, Value: value, Line: line}
+		return tag
 	}
 
 	return func() (*Tag, error) {
-		code, err := readLine()
-		if err != nil {
+		if done {
+			return &NoneTag, nil
+		}
+
+		code, ok, err := readLine()
+		if err != nil || !ok {
+			done = true
+			if errors.Is(err, ErrLimitExceeded) {
+				err = &ParseError{Line: counter + 1, Err: err}
+			}
 			return &NoneTag, err
 		}
-		value, err := readLine()
+		line := counter
+		code = trimSpace(code)
+		value, ok, err := readLine()
 		if err != nil {
+			done = true
+			if errors.Is(err, ErrLimitExceeded) {
+				intCode, _ := parseGroupCode(code)
+				err = &ParseError{Line: line, Code: intCode, Err: err}
+			}
 			return &NoneTag, err
 		}
 
-		charsToTrim := " \r\n"
-		counter += 2
-		if len(code) > 0 {
-			intCode, err := strconv.Atoi(strings.Trim(code, charsToTrim))
-			if err != nil {
-				return &NoneTag, err
+		if !ok {
+			done = true
+			if code == "" {
+				// a blank line at the end of the stream.
+				return &NoneTag, nil
 			}
-			valueType, _ := groupCodeTypes[intCode](strings.Trim(value, charsToTrim))
-			tag := new(Tag)
-			tag.Code = intCode
-			tag.Value = valueType
-			return tag, nil
+			return &NoneTag, &ParseError{Line: line, Value: code, Err: ErrTruncatedFile}
 		}
 
-		// EOF
-		return &NoneTag, nil
+		intCode, err := parseGroupCode(code)
+		if err != nil {
+			return &NoneTag, &ParseError{Line: line, Value: code, Err: fmt.Errorf("Invalid group code: %w", err)}
+		}
+		value = DecodeString(trimSpace(value), codePage.encoding())
+		factory, ok := groupCodeType(intCode)
+		if !ok {
+			tag := newTag(intCode, NewStringValue(value), line)
+			return tag, &ParseError{Line: line, Code: intCode, Value: value, Err: ErrUnknownGroupCode}
+		}
+		valueType, err := factory(value)
+		if err != nil {
+			return &NoneTag, &ParseError{Line: line, Code: intCode, Value: value, Err: err}
+		}
+		tag := newTag(intCode, valueType, line)
+		codePage.track(tag)
+		done = intCode == 0 && value == "EOF"
+		return tag, nil
 	}
 }
 
@@ -103,9 +177,22 @@ func AllTags(next NextTagFunction) []*Tag {
 	return tags
 }
 
+// ReadAllTags iterates until next finishes and returns all returned tags as a
+// slice, or stops at the first error next returns.
+func ReadAllTags(next NextTagFunction) (TagSlice, error) {
+	var strict *Diagnostics
+	return strict.ReadAllTags(next)
+}
+
 // TagSlice a slice specialization for tag pointers.
 type TagSlice []*Tag
 
+// TagSeq is an iterator over tags: it calls yield with each tag until yield
+// returns false. With Go 1.23 or newer it can be ranged over.
+//
+// The TagSeqs of a TagSlice filter it without allocating a new slice.
+type TagSeq func(yield func(*Tag) bool)
+
 // Equals tests equality against another TagSlice.
 func (slice TagSlice) Equals(other DxfElement) bool {
 	if otherSlice, ok := other.(TagSlice); ok {
@@ -137,57 +224,72 @@ func (slice TagSlice) TagIndex(tagCode int, startingIndex int, endIndex int) int
 	return -1
 }
 
+// WithCode returns a TagSeq of the tags that have the code tagCode.
+func (slice TagSlice) WithCode(tagCode int) TagSeq {
+	return func(yield func(*Tag) bool) {
+		for _, tag := range slice {
+			if tag.Code == tagCode && !yield(tag) {
+				return
+			}
+		}
+	}
+}
+
 // AllWithCode returns a slice of tags that have the code tagCode.
 func (slice TagSlice) AllWithCode(tagCode int) []*Tag {
-	tags := make([]*Tag, 0)
+	return collect(slice.WithCode(tagCode))
+}
 
-	for _, tag := range slice {
-		if tag.Code == tagCode {
-			tags = append(tags, tag)
+// Regular returns a TagSeq of the tags that are not XDATA or APP_DATA.
+func (slice TagSlice) Regular() TagSeq {
+	return func(yield func(*Tag) bool) {
+		inAppDataRange := false
+		for _, tag := range slice {
+			if tag.Code >= 1000 {
+				continue
+			}
+
+			if tag.Code == appDataMarker {
+				inAppDataRange = !inAppDataRange
+				continue
+			}
+
+			if !inAppDataRange && !yield(tag) {
+				return
+			}
 		}
 	}
-
-	return tags
 }
 
 // RegularTags returns a slice of Tags. It will return all tags
-// that are not XDATA, APP_DATA or SUBCLASS.
+// that are not XDATA or APP_DATA.
 func (slice TagSlice) RegularTags() []*Tag {
-	tags := make([]*Tag, 0)
-
-	inAppDataRange := false
-	for _, tag := range slice {
-		if tag.Code >= 1000 {
-			continue
-		}
+	return collect(slice.Regular())
+}
 
-		if tag.Code == appDataMarker {
-			if inAppDataRange {
-				inAppDataRange = false
-			} else {
-				inAppDataRange = true
+// XData returns a TagSeq of the tags with code >= 1000.
+func (slice TagSlice) XData() TagSeq {
+	return func(yield func(*Tag) bool) {
+		for _, tag := range slice {
+			if tag.Code > 999 && !yield(tag) {
+				return
 			}
-			continue
-		}
-
-		if !inAppDataRange {
-			tags = append(tags, tag)
 		}
 	}
-
-	return tags
 }
 
 // XDataTags returns a slice of Tags that contains code >= 1000.
 func (slice TagSlice) XDataTags() []*Tag {
-	tags := make([]*Tag, 0)
-
-	for _, tag := range slice {
-		if tag.Code > 999 {
-			tags = append(tags, tag)
-		}
-	}
+	return collect(slice.XData())
+}
 
+// collect returns the tags of seq as a slice, never nil.
+func collect(seq TagSeq) []*Tag {
+	tags := make([]*Tag, 0)
+	seq(func(tag *Tag) bool {
+		tags = append(tags, tag)
+		return true
+	})
 	return tags
 }
 
@@ -222,7 +324,7 @@ func (slice TagSlice) SubclassesTags() map[string][]*Tag {
 	tags := make([]*Tag, 0)
 	name := "noname"
 
-	for _, tag := range slice.RegularTags() {
+	slice.Regular()(func(tag *Tag) bool {
 		if tag.Code == subclassMarker {
 			classes[name] = tags
 			tags = tags[:0]
@@ -230,7 +332,8 @@ func (slice TagSlice) SubclassesTags() map[string][]*Tag {
 		} else {
 			tags = append(tags, tag)
 		}
-	}
+		return true
+	})
 	classes[name] = tags
 	return classes
 }
@@ -238,24 +341,23 @@ func (slice TagSlice) SubclassesTags() map[string][]*Tag {
 // TagGroups splits a TagSlice into Groups of TagSlices starting with a Split Tag
 // and ending before the next Split Tag.
 // A Split Tag is a tag with Code == splitCode, like (0, 'SECTION') for splitCode = 0.
+// The groups share the array of tags, but with no capacity past their end:
+// appending to a group copies it.
 func TagGroups(tags TagSlice, splitCode int) []TagSlice {
 	groups := make([]TagSlice, 0)
 
-	group := make(TagSlice, 0)
-	for _, tag := range tags {
+	start := -1
+	for index, tag := range tags {
 		if tag.Code == splitCode {
-			if len(group) > 0 {
-				groups = append(groups, group)
-				group = make(TagSlice, 0)
+			if start >= 0 {
+				groups = append(groups, tags[start:index:index])
 			}
-			group = append(group, tag)
-		} else if len(group) > 0 {
-			group = append(group, tag)
+			start = index
 		}
 	}
 
-	if len(group) > 0 && group[0].Code == splitCode {
-		groups = append(groups, group)
+	if start >= 0 {
+		groups = append(groups, tags[start:len(tags):len(tags)])
 	}
 
 	return groups
@@ -265,116 +367,117 @@ func TagGroups(tags TagSlice, splitCode int) []TagSlice {
 // of a DataType. The string should contain the DataType value.
 type dataTypeFactory func(string) (DataType, error)
 
-// groupCodeTypes maps DXF group codes to DataTypeFactory functions. See the init
-// functions for the known group codes.
-var groupCodeTypes map[int]dataTypeFactory
-
-func init() {
-	groupCodeTypes = make(map[int]dataTypeFactory)
-
-	for code := 0; code < 10; code++ {
-		groupCodeTypes[code] = NewString
-	}
-
-	for code := 10; code < 20; code++ {
-		groupCodeTypes[code] = NewFloat
-	}
-
-	for code := 20; code < 60; code++ {
-		groupCodeTypes[code] = NewFloat
-	}
-
-	for code := 60; code < 100; code++ {
-		groupCodeTypes[code] = NewInteger
-	}
-
-	for code := 100; code < 106; code++ {
-		groupCodeTypes[code] = NewString
-	}
-
-	for code := 110; code < 113; code++ {
-		groupCodeTypes[code] = NewFloat
-	}
-
-	for code := 113; code < 150; code++ {
-		groupCodeTypes[code] = NewFloat
-	}
-
-	for code := 170; code < 180; code++ {
-		groupCodeTypes[code] = NewInteger
-	}
-
-	groupCodeTypes[210] = NewFloat
-
-	for code := 211; code < 240; code++ {
-		groupCodeTypes[code] = NewFloat
-	}
-
-	for code := 270; code < 290; code++ {
-		groupCodeTypes[code] = NewInteger
-	}
-
-	for code := 290; code < 300; code++ {
-		groupCodeTypes[code] = NewInteger
-	}
-
-	for code := 300; code < 370; code++ {
-		groupCodeTypes[code] = NewString
-	}
-
-	for code := 370; code < 390; code++ {
-		groupCodeTypes[code] = NewInteger
-	}
-
-	for code := 390; code < 400; code++ {
-		groupCodeTypes[code] = NewString
-	}
-
-	for code := 400; code < 410; code++ {
-		groupCodeTypes[code] = NewInteger
-	}
+// groupCodeRange is a range of group codes, from first to last, of the
+// values of a DataType.
+type groupCodeRange struct {
+	first, last int
+	factory     dataTypeFactory
+}
 
-	for code := 410; code < 420; code++ {
-		groupCodeTypes[code] = NewString
-	}
+// groupCodeRanges are the types of the values of the group codes, as the
+// DXF reference defines them. The group codes of the gaps between the
+// ranges and the ones above them are not in the reference: their values are
+// read as strings, see groupCodeType.
+var groupCodeRanges = []groupCodeRange{
+	{0, 9, NewString},
+	{10, 59, NewFloat},
+	{60, 99, NewInteger},
+	{100, 104, NewString},
+	{105, 105, NewHandle},
+	{110, 149, NewFloat},
+	{160, 169, NewInteger64},
+	{170, 179, NewInteger},
+	{210, 239, NewFloat},
+	{270, 299, NewInteger},
+	{300, 309, NewString},
+	{310, 319, NewBinary},
+	{320, 369, NewHandle},
+	{370, 389, NewInteger},
+	{390, 399, NewHandle},
+	{400, 409, NewInteger},
+	{410, 419, NewString},
+	{420, 429, NewInteger},
+	{430, 439, NewString},
+	{440, 459, NewInteger},
+	{460, 469, NewFloat},
+	{470, 479, NewString},
+	{480, 481, NewHandle},
+	{999, 1003, NewString},
+	{1004, 1004, NewBinary},
+	{1005, 1005, NewHandle},
+	{1006, 1009, NewString},
+	{1010, 1059, NewFloat},
+	{1060, 1071, NewInteger},
+}
 
-	for code := 420; code < 430; code++ {
-		groupCodeTypes[code] = NewInteger
-	}
+// maxGroupCode is the largest group code of the DXF files.
+const maxGroupCode = 1071
 
-	for code := 430; code < 440; code++ {
-		groupCodeTypes[code] = NewString
-	}
+// groupCodeTypes has the dataTypeFactory of each group code, NewString for
+// the gaps. It is a dense table indexed by the group code, built from the
+// groupCodeRanges.
+var groupCodeTypes [maxGroupCode + 1]dataTypeFactory
 
-	for code := 440; code < 460; code++ {
-		groupCodeTypes[code] = NewInteger
+func init() {
+	for _, codes := range groupCodeRanges {
+		for code := codes.first; code <= codes.last; code++ {
+			groupCodeTypes[code] = codes.factory
+		}
 	}
-
-	for code := 460; code < 470; code++ {
-		groupCodeTypes[code] = NewFloat
+	for code, factory := range groupCodeTypes {
+		if factory == nil {
+			groupCodeTypes[code] = NewString
+		}
 	}
+}
 
-	for code := 470; code < 480; code++ {
-		groupCodeTypes[code] = NewString
+// groupCodeType returns the dataTypeFactory of the values of the group code,
+// NewString for the codes not in the DXF reference, and false when the group
+// code is negative.
+func groupCodeType(code int) (dataTypeFactory, bool) {
+	if code < 0 {
+		return nil, false
 	}
-
-	for code := 480; code < 482; code++ {
-		groupCodeTypes[code] = NewString
+	if code > maxGroupCode {
+		return NewString, true
 	}
+	return groupCodeTypes[code], true
+}
 
-	for code := 999; code < 1010; code++ {
-		groupCodeTypes[code] = NewString
+// maxGroupCodeDigits is the number of digits of the group codes parsed
+// without strconv.
+const maxGroupCodeDigits = 9
+
+// parseGroupCode parses the group code, a number of a few digits. The
+// others, like the malformed ones, are parsed by strconv.Atoi.
+func parseGroupCode(code string) (int, error) {
+	if len(code) == 0 || len(code) > maxGroupCodeDigits {
+		return strconv.Atoi(code)
+	}
+	value := 0
+	for i := 0; i < len(code); i++ {
+		digit := code[i] - '0'
+		if digit > 9 {
+			return strconv.Atoi(code)
+		}
+		value = value*10 + int(digit)
 	}
+	return value, nil
+}
 
-	for code := 1010; code < 1020; code++ {
-		groupCodeTypes[code] = NewFloat
+// trimSpace returns s without the spaces, tabs, CRs and LFs around it. It
+// is a slice of s, with no allocation.
+func trimSpace(s string) string {
+	start, end := 0, len(s)
+	for start < end && isSpace(s[start]) {
+		start++
 	}
-
-	for code := 1020; code < 1060; code++ {
-		groupCodeTypes[code] = NewFloat
+	for end > start && isSpace(s[end-1]) {
+		end--
 	}
+	return s[start:end]
+}
 
-	for code := 1060; code < 1072; code++ {
-		groupCodeTypes[code] = NewInteger
-	}
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
 }
@@ -1,7 +1,16 @@
 package core
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"testing"
+	"testing/iotest"
 
 	"strings"
 
@@ -209,7 +218,7 @@ func (suite *TaggerTestSuite) TestNextTag() {
 
 func (suite *TaggerTestSuite) TestAllTags() {
 	next := Tagger(strings.NewReader(regularDXF))
-	suite.Equal(expectedRegularDxfTags, AllTags(next))
+	suite.True(TagSlice(expectedRegularDxfTags).Equals(TagSlice(AllTags(next))))
 }
 
 func (suite *TaggerTestSuite) TestAllTagsWithoutEof() {
@@ -225,7 +234,7 @@ func (suite *TaggerTestSuite) TestAllTagsWithoutEof() {
 		NewTag(0, NewStringValue("ENDSEC")),
 	}
 
-	suite.Equal(expected, AllTags(next))
+	suite.True(TagSlice(expected).Equals(TagSlice(AllTags(next))))
 }
 
 func (suite *TaggerTestSuite) TestDXFWithComments() {
@@ -244,16 +253,17 @@ func (suite *TaggerTestSuite) TestDXFWithComments() {
 		NewTag(0, NewStringValue("EOF")),
 	}
 
-	suite.Equal(expected, AllTags(next))
+	suite.True(TagSlice(expected).Equals(TagSlice(AllTags(next))))
 }
 
 func (suite *TaggerTestSuite) TestIntAndFloatTags() {
 	intAndFloatTags := "  60\n1001\n  20\n15.54"
 	next := Tagger(strings.NewReader(intAndFloatTags))
 
+	// the tags keep the line of their group code.
 	expected := []*Tag{
-		NewTag(60, NewIntegerValue(1001)),
-		NewTag(20, NewFloatValue(15.54)),
+		{Code: 60, Value: NewIntegerValue(1001), Line: 1},
+		{Code: 20, Value: NewFloatValue(15.54), Line: 3},
 	}
 
 	suite.Equal(expected, AllTags(next))
@@ -320,7 +330,163 @@ func (suite *TaggerTestSuite) TestNextTagFunctionInvalidCodeError() {
 	tag, err := next()
 
 	suite.Equal(NoneTag, *tag)
-	suite.Equal("strconv.Atoi: parsing \"INVALID\": invalid syntax", err.Error())
+	var parseError *ParseError
+	suite.True(errors.As(err, &parseError))
+	suite.Equal(1, parseError.Line)
+	suite.Equal("INVALID", parseError.Value)
+	suite.Equal("Error parsing \"INVALID\" (line 1, group code 0): "+
+		"Invalid group code: strconv.Atoi: parsing \"INVALID\": invalid syntax", err.Error())
+}
+
+func (suite *TaggerTestSuite) TestNextTagFunctionInvalidValueError() {
+	next := Tagger(strings.NewReader("  0\nLINE\n 10\n1.0\n 20\nNOT_A_NUMBER\n"))
+	tags, err := ReadAllTags(next)
+
+	suite.Len(tags, 2)
+	var parseError *ParseError
+	suite.True(errors.As(err, &parseError))
+	suite.Equal(5, parseError.Line)
+	suite.Equal(20, parseError.Code)
+	suite.Equal("NOT_A_NUMBER", parseError.Value)
+	suite.True(errors.Is(err, strconv.ErrSyntax))
+}
+
+func (suite *TaggerTestSuite) TestLineEndings() {
+	expected := TagSlice{
+		{Code: 0, Value: NewStringValue("LINE"), Line: 1},
+		{Code: 10, Value: NewFloatValue(1.0), Line: 3},
+		{Code: 0, Value: NewStringValue("EOF"), Line: 5},
+	}
+
+	for _, dxf := range []string{
+		"  0\nLINE\n 10\n1.0\n  0\nEOF\n",
+		"  0\r\nLINE\r\n 10\r\n1.0\r\n  0\r\nEOF\r\n",
+		"  0\rLINE\r 10\r1.0\r  0\rEOF\r",
+		"  0\r\nLINE\n 10\r1.0\r\n  0\nEOF",
+		"\ufeff  0\nLINE\n\t10\t\n1.0\n  0\nEOF\n",
+	} {
+		// the line endings are split between the reads of one byte.
+		for _, reader := range []io.Reader{strings.NewReader(dxf), iotest.OneByteReader(strings.NewReader(dxf))} {
+			tags, err := ReadAllTags(Tagger(reader))
+
+			suite.Nil(err, "%q", dxf)
+			suite.True(expected.Equals(tags), "%q", dxf)
+			for i, tag := range tags {
+				suite.Equal(expected[i].Line, tag.Line, "%q", dxf)
+			}
+		}
+	}
+}
+
+func (suite *TaggerTestSuite) TestStopsAtEof() {
+	next := Tagger(strings.NewReader("  0\nEOF\nTRAILING GARBAGE\n"))
+
+	tag, err := next()
+	suite.Nil(err)
+	suite.Equal("EOF", tag.Value.ToString())
+
+	tag, err = next()
+	suite.Nil(err)
+	suite.Equal(&NoneTag, tag)
+}
+
+func (suite *TaggerTestSuite) TestBlankLineAtTheEnd() {
+	tags, err := ReadAllTags(Tagger(strings.NewReader("  0\nSECTION\n\n")))
+
+	suite.Nil(err)
+	suite.Len(tags, 1)
+}
+
+func (suite *TaggerTestSuite) TestTruncatedFile() {
+	next := Tagger(strings.NewReader("  0\nSECTION\n  2\n"))
+	tags, err := ReadAllTags(next)
+
+	suite.Len(tags, 1)
+	var parseError *ParseError
+	suite.True(errors.As(err, &parseError))
+	suite.Equal(3, parseError.Line)
+	suite.True(errors.Is(err, ErrTruncatedFile))
+
+	// the Tagger finishes after the error.
+	tag, err := next()
+	suite.Nil(err)
+	suite.Equal(&NoneTag, tag)
+}
+
+func (suite *TaggerTestSuite) TestLongLine() {
+	value := strings.Repeat("X", 100*1024)
+	tags, err := ReadAllTags(Tagger(strings.NewReader("  1\n" + value + "\n")))
+
+	suite.Nil(err)
+	suite.Len(tags, 1)
+	suite.Equal(value, tags[0].Value.ToString())
+
+	tags, err = ReadAllTags(Tagger(iotest.OneByteReader(strings.NewReader("  1\n" + value + "\n  0\nEOF\n"))))
+	suite.Nil(err)
+	suite.Len(tags, 2)
+	suite.Equal(value, tags[0].Value.ToString())
+}
+
+func (suite *TaggerTestSuite) TestLineTooLong() {
+	reader := io.MultiReader(strings.NewReader("  1\n"), &repeatReader{'X'})
+	tags, err := ReadAllTags(Tagger(reader))
+
+	suite.Len(tags, 0)
+	suite.Equal(bufio.ErrTooLong, err)
+}
+
+func (suite *TaggerTestSuite) TestNoProgress() {
+	tags, err := ReadAllTags(Tagger(&MockReader{
+		Data: make([]string, maxEmptyReads),
+		Done: make([]bool, maxEmptyReads),
+		Err:  make([]error, maxEmptyReads),
+	}))
+
+	suite.Len(tags, 0)
+	suite.Equal(io.ErrNoProgress, err)
+}
+
+// repeatReader is an endless stream of its byte.
+type repeatReader struct {
+	b byte
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.b
+	}
+	return len(p), nil
+}
+
+func TestSplitLine(t *testing.T) {
+	tests := []struct {
+		data    string
+		atEOF   bool
+		advance int
+		line    string
+		ok      bool
+	}{
+		{"", true, 0, "", false},
+		{"abc", false, 0, "", false},
+		{"abc", true, 3, "abc", true},
+		{"abc\ndef", false, 4, "abc", true},
+		{"abc\r\ndef", false, 5, "abc", true},
+		{"abc\rdef", false, 4, "abc", true},
+		{"\ndef", false, 1, "", true},
+		{"abc\rdef\n", false, 4, "abc", true},
+		{strings.Repeat("x", 300) + "\ndef", false, 301, strings.Repeat("x", 300), true},
+		{strings.Repeat("x", 300) + "\rdef\n", false, 301, strings.Repeat("x", 300), true},
+		// the CR may be followed by a LF not read yet.
+		{"abc\r", false, 0, "", false},
+		{"abc\r", true, 4, "abc", true},
+	}
+
+	for _, test := range tests {
+		advance, line, ok := splitLine(test.data, test.atEOF)
+		assert.Equal(t, test.ok, ok, "%q", test.data)
+		assert.Equal(t, test.advance, advance, "%q", test.data)
+		assert.Equal(t, test.line, line, "%q", test.data)
+	}
 }
 
 func TestTaggerTestSuite(t *testing.T) {
@@ -355,7 +521,7 @@ func (suite *TagSliceTestSuite) TestAllWithCode() {
 		NewTag(0, NewStringValue("ENDSEC")),
 		NewTag(0, NewStringValue("EOF")),
 	}
-	suite.Equal(expected, suite.tags.AllWithCode(0))
+	suite.True(TagSlice(expected).Equals(TagSlice(suite.tags.AllWithCode(0))))
 	suite.Equal([]*Tag{}, suite.tags.AllWithCode(50))
 }
 
@@ -363,7 +529,33 @@ func (suite *TagSliceTestSuite) TestRegularTags() {
 	next := Tagger(strings.NewReader(regularDXFAppDataAndXData))
 	tags := TagSlice(AllTags(next))
 
-	suite.Equal(expectedRegularDxfTags, tags.RegularTags())
+	suite.True(TagSlice(expectedRegularDxfTags).Equals(TagSlice(tags.RegularTags())))
+}
+
+func (suite *TagSliceTestSuite) TestTagSeqs() {
+	tags := TagSlice(AllTags(Tagger(strings.NewReader(regularDXFAppDataAndXData))))
+
+	var regular TagSlice
+	tags.Regular()(func(tag *Tag) bool {
+		regular = append(regular, tag)
+		return true
+	})
+	suite.True(TagSlice(expectedRegularDxfTags).Equals(regular))
+
+	// the iteration stops when yield returns false.
+	var first TagSlice
+	tags.WithCode(0)(func(tag *Tag) bool {
+		first = append(first, tag)
+		return false
+	})
+	suite.True(TagSlice{NewTag(0, NewStringValue("SECTION"))}.Equals(first))
+
+	count := 0
+	tags.XData()(func(tag *Tag) bool {
+		count++
+		return tag.Code != 1001
+	})
+	suite.Equal(1, count)
 }
 
 func (suite *TagSliceTestSuite) TestXDataTags() {
@@ -375,7 +567,7 @@ func (suite *TagSliceTestSuite) TestXDataTags() {
 		NewTag(1000, NewStringValue("XDATA_STRING")),
 	}
 
-	suite.Equal(expected, tags.XDataTags())
+	suite.True(TagSlice(expected).Equals(TagSlice(tags.XDataTags())))
 }
 
 func (suite *TagSliceTestSuite) TestAppDataTags() {
@@ -384,12 +576,12 @@ func (suite *TagSliceTestSuite) TestAppDataTags() {
 
 	expected := []*Tag{
 		NewTag(102, NewStringValue("{DXFGrabber")),
-		NewTag(330, NewStringValue("999")),
+		NewTag(330, NewHandleValue("999")),
 		NewTag(102, NewStringValue("}")),
 	}
 	appData := tags.AppDataTags()
 
-	suite.Equal(expected, appData["{DXFGrabber"])
+	suite.True(TagSlice(expected).Equals(TagSlice(appData["{DXFGrabber"])))
 }
 
 func (suite *TagSliceTestSuite) TestSubclassesTags() {
@@ -447,4 +639,217 @@ func TestTagGroups(t *testing.T) {
 
 		assert.True(t, slice.Equals(otherSlice))
 	}
+
+	// the groups share the tags, appending to them does not overwrite the
+	// next group.
+	_ = append(groups[0], NewTag(999, NewStringValue("APPENDED")))
+	assert.True(t, expected[1].Equals(groups[1]))
+}
+
+func TestGroupCodeType(t *testing.T) {
+	tests := []struct {
+		code  int
+		value string
+		kind  Kind
+		ok    bool
+	}{
+		{0, "LINE", StringKind, true},
+		{10, "1.5", FloatKind, true},
+		{62, "7", IntegerKind, true},
+		{105, "1F", HandleKind, true},
+		{160, "9223372036854775807", Integer64Kind, true},
+		{310, "00FF", BinaryKind, true},
+		{330, "1F", HandleKind, true},
+		{1004, "00FF", BinaryKind, true},
+		{1005, "1F", HandleKind, true},
+		{210, "1.0", FloatKind, true},
+		{290, "1", IntegerKind, true},
+		{479, "NAME", StringKind, true},
+		{481, "1F", HandleKind, true},
+		{1071, "1", IntegerKind, true},
+		{-1, "", StringKind, false},
+		{-5, "", StringKind, false},
+		{106, "GAP", StringKind, true},
+		{150, "GAP", StringKind, true},
+		{180, "GAP", StringKind, true},
+		{240, "GAP", StringKind, true},
+		{482, "GAP", StringKind, true},
+		{1072, "GAP", StringKind, true},
+		{1 << 20, "GAP", StringKind, true},
+	}
+
+	for _, test := range tests {
+		factory, ok := groupCodeType(test.code)
+		assert.Equal(t, test.ok, ok, "%v", test.code)
+		if ok {
+			value, err := factory(test.value)
+			assert.Nil(t, err)
+			assert.Equal(t, test.kind, value.Kind(), "%v", test.code)
+		}
+	}
+}
+
+func TestParseGroupCode(t *testing.T) {
+	for _, code := range []string{"0", "10", "007", "1071", "999999999", "1234567890", "-1", "+5", "", "1 0", "X"} {
+		expected, expectedErr := strconv.Atoi(code)
+		value, err := parseGroupCode(code)
+		assert.Equal(t, expected, value, "%q", code)
+		assert.Equal(t, expectedErr, err, "%q", code)
+	}
+}
+
+func TestTrimSpace(t *testing.T) {
+	assert.Equal(t, "10", trimSpace("  10"))
+	assert.Equal(t, "A B", trimSpace("\tA B \r\n"))
+	assert.Equal(t, "", trimSpace(" \t "))
+	assert.Equal(t, "", trimSpace(""))
+	assert.Equal(t, 0.0, testing.AllocsPerRun(100, func() { trimSpace("  10 ") }))
+}
+
+// benchmarkCorpus is a directory of DXF files to benchmark the Tagger with:
+// "go test -bench TaggerCorpus -args -benchmark.corpus=path/to/dxf/files".
+var benchmarkCorpus = flag.String("benchmark.corpus", "", "directory of the DXF files of the Tagger benchmarks")
+
+func BenchmarkTaggerCorpus(b *testing.B) {
+	if *benchmarkCorpus == "" {
+		b.Skip("no -benchmark.corpus directory")
+	}
+	paths, err := filepath.Glob(filepath.Join(*benchmarkCorpus, "*.[dD][xX][fF]"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, path := range paths {
+		dxf, err := os.ReadFile(path)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(filepath.Base(path), func(b *testing.B) {
+			b.SetBytes(int64(len(dxf)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				// the binary DXF files and the files with negative group
+				// codes are read until their first error.
+				ReadAllTags(Tagger(bytes.NewReader(dxf)))
+			}
+		})
+	}
+}
+
+func BenchmarkGroupCodeType(b *testing.B) {
+	codes := []int{0, 5, 8, 10, 20, 30, 62, 100, 330, 370, 1001, 1040}
+	byCode := make(map[int]dataTypeFactory)
+	for code, factory := range groupCodeTypes {
+		if factory != nil {
+			byCode[code] = factory
+		}
+	}
+
+	b.Run("table", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, ok := groupCodeType(codes[i%len(codes)]); !ok {
+				b.Fatal("unknown group code")
+			}
+		}
+	})
+	b.Run("map", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, ok := byCode[codes[i%len(codes)]]; !ok {
+				b.Fatal("unknown group code")
+			}
+		}
+	})
+}
+
+func BenchmarkTagger(b *testing.B) {
+	var buffer bytes.Buffer
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&buffer, "  0\nLINE\n  5\n%X\n  8\n0\n 10\n%v.0\n 20\n1.0\n 62\n%v\n", i, i, i%256)
+	}
+	dxf := buffer.Bytes()
+
+	b.Run("Tagger", func(b *testing.B) {
+		b.SetBytes(int64(len(dxf)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := ReadAllTags(Tagger(bytes.NewReader(dxf))); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("baseline", func(b *testing.B) {
+		b.SetBytes(int64(len(dxf)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := baselineReadAllTags(bytes.NewReader(dxf)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// baselineFactories are the dataTypeFactories of the group codes by code,
+// parsing the values with strconv.
+var baselineFactories = func() map[int]dataTypeFactory {
+	factories := make(map[int]dataTypeFactory)
+	for _, codes := range groupCodeRanges {
+		factory := codes.factory
+		if value, _ := factory("0"); value.Kind() == FloatKind {
+			factory = func(s string) (DataType, error) {
+				v, err := strconv.ParseFloat(s, 64)
+				return NewFloatValue(v), err
+			}
+		}
+		for code := codes.first; code <= codes.last; code++ {
+			factories[code] = factory
+		}
+	}
+	return factories
+}()
+
+// baselineReadAllTags reads the tags of the stream the way the Tagger did
+// before its line reader, group code table and decimal parsing: with a
+// bufio.Scanner, strings.Trim, strconv and a map of the group codes.
+func baselineReadAllTags(stream io.Reader) (TagSlice, error) {
+	tags := make(TagSlice, 0)
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		code, err := strconv.Atoi(strings.Trim(scanner.Text(), " \t"))
+		if err != nil {
+			return tags, err
+		}
+		if !scanner.Scan() {
+			return tags, ErrTruncatedFile
+		}
+		factory, ok := baselineFactories[code]
+		if !ok {
+			return tags, ErrUnknownGroupCode
+		}
+		value, err := factory(strings.Trim(scanner.Text(), " \t"))
+		if err != nil {
+			return tags, err
+		}
+		tags = append(tags, &Tag{Code: code, Value: value})
+	}
+	return tags, scanner.Err()
+}
+
+func FuzzTagger(f *testing.F) {
+	f.Add([]byte(regularDXF))
+	f.Add([]byte(regularDXFComments))
+	f.Add([]byte("  0\r\nSECTION\r\n  2\r\n"))
+	f.Add([]byte("\ufeff  0\rEOF\r"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		next := Tagger(bytes.NewReader(data))
+		for i := 0; i <= len(data); i++ {
+			tag, err := next()
+			if tag == nil {
+				t.Fatalf("nil tag, error %v", err)
+			}
+			if *tag == NoneTag {
+				return
+			}
+		}
+		t.Fatalf("the Tagger did not finish")
+	})
 }
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// NewStringTag creates a new Tag with code and a String value.
+func NewStringTag(code int, value string) *Tag {
+	return NewTag(code, NewStringValue(value))
+}
+
+// NewIntegerTag creates a new Tag with code and an Integer value.
+func NewIntegerTag(code int, value int) *Tag {
+	return NewTag(code, NewIntegerValue(value))
+}
+
+// NewFloatTag creates a new Tag with code and a Float value.
+func NewFloatTag(code int, value float64) *Tag {
+	return NewTag(code, NewFloatValue(value))
+}
+
+// NewInteger64Tag creates a new Tag with code and an Integer64 value.
+func NewInteger64Tag(code int, value int64) *Tag {
+	return NewTag(code, NewInteger64Value(value))
+}
+
+// NewBinaryTag creates a new Tag with code and a Binary value.
+func NewBinaryTag(code int, value []byte) *Tag {
+	return NewTag(code, NewBinaryValue(value))
+}
+
+// NewHandleTag creates a new Tag with code and a Handle value.
+func NewHandleTag(code int, value string) *Tag {
+	return NewTag(code, NewHandleValue(value))
+}
+
+// NewPointTags creates the tags of a point: X with code, Y with code + 10
+// and Z with code + 20.
+func NewPointTags(code int, point Point) TagSlice {
+	return TagSlice{
+		NewFloatTag(code, point.X),
+		NewFloatTag(code+10, point.Y),
+		NewFloatTag(code+20, point.Z),
+	}
+}
+
+// WriteTags writes the tags to the stream in the DXF text format, the code
+// right aligned on its line and the value on the next one. As Tagger reads
+// them, the strings are written in UTF-8 from R2007 on and, in the older
+// versions, in the encoding of the $DWGCODEPAGE of the tags (see
+// EncodeString).
+func WriteTags(stream io.Writer, tags TagSlice) error {
+	writer := bufio.NewWriter(stream)
+	var codePage codePageTracker
+	for _, tag := range tags {
+		value := formatValue(tag.Value)
+		if _, ok := AsString(tag.Value); ok {
+			value = EncodeString(value, codePage.encoding())
+		}
+		if _, err := fmt.Fprintf(writer, "%3d\n%s\n", tag.Code, value); err != nil {
+			return err
+		}
+		codePage.track(tag)
+	}
+	return writer.Flush()
+}
+
+// formatValue returns the text of the value. Floats always have a decimal
+// point, so that they are not mistaken for integers.
+func formatValue(value DataType) string {
+	if number, ok := AsFloat(value); ok {
+		formatted := strconv.FormatFloat(number, 'f', -1, 64)
+		if !strings.ContainsAny(formatted, ".NI") {
+			formatted += ".0"
+		}
+		return formatted
+	}
+	return value.ToString()
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWriteTags(t *testing.T) {
+	tags := TagSlice{
+		NewStringTag(0, "LINE"),
+		NewIntegerTag(62, 1),
+		NewFloatTag(40, 2.0),
+		NewFloatTag(41, -0.25),
+		NewStringTag(1000, "DATA"),
+	}
+	tags = append(tags, NewPointTags(10, Point{X: 1.5, Y: 2.0, Z: 3.0})...)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, WriteTags(&buffer, tags))
+	assert.Equal(t, "  0\nLINE\n 62\n1\n 40\n2.0\n 41\n-0.25\n1000\nDATA\n"+
+		" 10\n1.5\n 20\n2.0\n 30\n3.0\n", buffer.String())
+
+	read := TagSlice(AllTags(Tagger(strings.NewReader(buffer.String()))))
+	assert.True(t, tags.Equals(read))
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "100000000000000000000.0", formatValue(NewFloatValue(1e20)))
+	assert.Equal(t, "0.001", formatValue(NewFloatValue(0.001)))
+	assert.Equal(t, "NaN", formatValue(NewFloatValue(math.NaN())))
+	assert.Equal(t, "42", formatValue(NewIntegerValue(42)))
+}
@@ -1,4 +1,4 @@
-// Package dxf_go is a library to Read and Write (not yet) DXF files in Go.
+// Package dxf_go is a library to Read and Write DXF files in Go.
 //
 // dxf_go contains the following packages:
 //
@@ -9,6 +9,15 @@
 // The entities package provides all the abstraction and code for DXF entities.
 //
 // The sections package provides all the abstraction and code for DXF section.
+//
+// The nurbs package provides the NURBS curves evaluation used by the SPLINE entities.
+//
+// The export/plot package converts documents into drawing primitives, rendered
+// as SVG by the export/svg package, as images by the export/raster package and
+// as PDF, one page per paper space layout, by the export/pdf package.
+//
+// The geo package converts entities to GeoJSON features and WKT geometries and
+// builds documents from them.
 package dxf_go
 
 // blank imports help docs.
@@ -21,4 +30,13 @@ import (
 	_ "github.com/rpaloschi/dxf-go/entities"
 	// sections package
 	_ "github.com/rpaloschi/dxf-go/sections"
+	// nurbs package
+	_ "github.com/rpaloschi/dxf-go/nurbs"
+	// export packages
+	_ "github.com/rpaloschi/dxf-go/export/pdf"
+	_ "github.com/rpaloschi/dxf-go/export/plot"
+	_ "github.com/rpaloschi/dxf-go/export/raster"
+	_ "github.com/rpaloschi/dxf-go/export/svg"
+	// geo package
+	_ "github.com/rpaloschi/dxf-go/geo"
 )
@@ -0,0 +1,494 @@
+package document
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/sections"
+)
+
+// DefaultVersion is the $ACADVER of the documents created by New when no
+// version is given: AutoCAD R2000, the first one with LWPOLYLINEs read by
+// most applications.
+const DefaultVersion = "AC1015"
+
+// ModelSpaceBlockName is the name of the Block owning the model space
+// entities.
+const ModelSpaceBlockName = "*Model_Space"
+
+// New creates an empty DxfDocument for the $ACADVER version, DefaultVersion
+// if empty. It has the layer "0", the CONTINUOUS line type, the STANDARD
+// text style and the model and paper space Blocks with their BLOCK_RECORDs.
+// The tables, their records, the Blocks and the entities added get a handle
+// and their owner, as the versions from R13 on require.
+func New(version string) *DxfDocument {
+	if version == "" {
+		version = DefaultVersion
+	}
+
+	doc := &DxfDocument{
+		Header: &sections.HeaderSection{Values: map[string]core.TagSlice{
+			"$ACADVER":     {core.NewStringTag(1, version)},
+			"$DWGCODEPAGE": {core.NewStringTag(3, "ANSI_1252")},
+			"$HANDSEED":    {core.NewStringTag(5, "1")},
+		}},
+		Tables: &sections.TablesSection{
+			Layers:    sections.Table{},
+			Styles:    sections.Table{},
+			LineTypes: sections.Table{},
+		},
+		Entities: &sections.EntitiesSection{},
+		Blocks:   sections.BlocksSection{},
+	}
+
+	for _, tableType := range []string{"LTYPE", "LAYER", "STYLE"} {
+		doc.tableHandle(tableType)
+	}
+	doc.AddLineType("CONTINUOUS", "Solid line")
+	doc.AddLayer("0", 7)
+	doc.AddStyle("STANDARD", "txt")
+	doc.NewBlock(ModelSpaceBlockName, core.Point{})
+	doc.NewBlock(PaperSpaceBlockName, core.Point{})
+	return doc
+}
+
+// NextHandle returns a new handle for an object of the document, updating
+// $HANDSEED. Documents without $HANDSEED continue after their highest
+// handle.
+func (doc *DxfDocument) NextHandle() string {
+	if doc.Header == nil {
+		doc.Header = &sections.HeaderSection{}
+	}
+	if doc.Header.Values == nil {
+		doc.Header.Values = map[string]core.TagSlice{}
+	}
+
+	var seed uint64
+	if values := doc.Header.Get("$HANDSEED"); len(values) > 0 {
+		seed, _ = strconv.ParseUint(values[0].Value.ToString(), 16, 64)
+	}
+	if seed == 0 {
+		seed = doc.maxHandle() + 1
+	}
+
+	doc.Header.Values["$HANDSEED"] = core.TagSlice{
+		core.NewStringTag(5, fmt.Sprintf("%X", seed+1))}
+	return fmt.Sprintf("%X", seed)
+}
+
+// maxHandle returns the highest handle of the tables, their records, the
+// Blocks and the entities.
+func (doc *DxfDocument) maxHandle() uint64 {
+	var max uint64
+	check := func(handle string) {
+		if value, err := strconv.ParseUint(handle, 16, 64); err == nil && value > max {
+			max = value
+		}
+	}
+
+	var visit func(list entities.EntitySlice)
+	visit = func(list entities.EntitySlice) {
+		for _, entity := range list {
+			if base := entities.EntityBase(entity); base != nil {
+				check(base.Handle)
+				check(base.SeqEndHandle)
+			}
+			visit(nestedEntities(entity))
+		}
+	}
+
+	if doc.Tables != nil {
+		for _, handle := range doc.Tables.Handles {
+			check(handle)
+		}
+		for _, table := range []sections.Table{doc.Tables.Layers, doc.Tables.LineTypes, doc.Tables.Styles} {
+			for _, record := range table {
+				check(recordHandle(record))
+			}
+		}
+		for _, tags := range doc.Tables.Others {
+			for tag := range tags.WithCode(5) {
+				check(tag.Value.ToString())
+			}
+		}
+	}
+	for _, block := range doc.Blocks {
+		check(block.Handle)
+		check(block.EndHandle)
+		visit(block.Entities)
+	}
+	if doc.Entities != nil {
+		visit(doc.Entities.Entities)
+	}
+	return max
+}
+
+// nestedEntities returns the vertices of a Polyline or the attributes of an
+// Insert.
+func nestedEntities(entity entities.Entity) entities.EntitySlice {
+	switch e := entity.(type) {
+	case *entities.Polyline:
+		nested := make(entities.EntitySlice, 0, len(e.Vertices))
+		for _, vertex := range e.Vertices {
+			nested = append(nested, vertex)
+		}
+		return nested
+	case *entities.Insert:
+		return e.Entities
+	}
+	return nil
+}
+
+// recordHandle returns the handle of a record of the LAYER, LTYPE or STYLE
+// tables.
+func recordHandle(record core.DxfElement) string {
+	switch r := record.(type) {
+	case *sections.Layer:
+		return r.Handle
+	case *sections.LineType:
+		return r.Handle
+	case *sections.Style:
+		return r.Handle
+	}
+	return ""
+}
+
+func (doc *DxfDocument) tables() *sections.TablesSection {
+	if doc.Tables == nil {
+		doc.Tables = &sections.TablesSection{}
+	}
+	return doc.Tables
+}
+
+// tableHandle returns the handle of the table of tableType, giving it one
+// if it has none.
+func (doc *DxfDocument) tableHandle(tableType string) string {
+	tables := doc.tables()
+	if tables.Handles == nil {
+		tables.Handles = map[string]string{}
+	}
+	handle, ok := tables.Handles[tableType]
+	if !ok {
+		handle = doc.NextHandle()
+		tables.Handles[tableType] = handle
+	}
+	return handle
+}
+
+// hasRecord tells if the table of tableType, kept with its tags in the
+// Others tables, has a record with the name.
+func (doc *DxfDocument) hasRecord(tableType string, name string) bool {
+	if doc.Tables == nil {
+		return false
+	}
+	table := doc.Tables.Others[tableType]
+	if len(table) == 0 {
+		return false
+	}
+	for _, record := range core.TagGroups(table, 0)[1:] {
+		if index := record.TagIndex(2, 0, len(record)); index >= 0 && record[index].Value.ToString() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addRecord adds a record with the name to the table of tableType, kept
+// with its tags in the Others tables, creating it if needed. The record
+// has its handle, the table as owner, the subclass marker, the name and
+// the other tags. It returns the handle of the record.
+func (doc *DxfDocument) addRecord(tableType string, subclass string, name string, tags ...*core.Tag) string {
+	tables := doc.tables()
+	if tables.Others == nil {
+		tables.Others = map[string]core.TagSlice{}
+	}
+	table, ok := tables.Others[tableType]
+	if !ok {
+		table = core.TagSlice{
+			core.NewStringTag(0, "TABLE"),
+			core.NewStringTag(2, tableType),
+			core.NewStringTag(5, doc.NextHandle()),
+			core.NewHandleTag(330, "0"),
+			core.NewStringTag(100, "AcDbSymbolTable"),
+			core.NewIntegerTag(70, 0),
+			core.NewStringTag(0, "ENDTAB"),
+		}
+	}
+
+	// the TABLE tags end at the first record, or the ENDTAB.
+	header := table.TagIndex(0, 1, len(table))
+	tableHandle := ""
+	if index := table.TagIndex(5, 0, header); index >= 0 {
+		tableHandle = table[index].Value.ToString()
+	}
+	handle := doc.NextHandle()
+	record := core.TagSlice{
+		core.NewStringTag(0, tableType),
+		core.NewStringTag(5, handle),
+		core.NewHandleTag(330, tableHandle),
+		core.NewStringTag(100, "AcDbSymbolTableRecord"),
+		core.NewStringTag(100, subclass),
+		core.NewStringTag(2, name),
+	}
+	record = append(record, tags...)
+
+	end := len(table) - 1
+	result := make(core.TagSlice, 0, len(table)+len(record))
+	result = append(result, table[:end]...)
+	result = append(result, record...)
+	result = append(result, table[end])
+	if index := result.TagIndex(70, 0, header); index >= 0 {
+		result[index] = core.NewIntegerTag(70, len(result.AllWithCode(0))-2)
+	}
+	tables.Others[tableType] = result
+	return handle
+}
+
+// AddLayer adds a Layer with the color and the CONTINUOUS line type to the
+// LAYER table. It fails if the layer already exists.
+func (doc *DxfDocument) AddLayer(name string, color int) (*sections.Layer, error) {
+	tables := doc.tables()
+	if tables.Layers == nil {
+		tables.Layers = sections.Table{}
+	}
+	if _, ok := tables.Layers[name]; ok {
+		return nil, fmt.Errorf("Layer already exists: %v", name)
+	}
+
+	layer := &sections.Layer{Owner: doc.tableHandle("LAYER"), Handle: doc.NextHandle(),
+		Name: name, Color: color, LineType: "CONTINUOUS", On: true}
+	tables.Layers[name] = layer
+	return layer, nil
+}
+
+// AddAppID registers the application name of XDATA in the APPID table. It
+// fails if the application is already registered.
+func (doc *DxfDocument) AddAppID(name string) error {
+	if doc.hasRecord("APPID", name) {
+		return fmt.Errorf("AppID already exists: %v", name)
+	}
+	doc.addRecord("APPID", "AcDbRegAppTableRecord", name, core.NewIntegerTag(70, 0))
+	return nil
+}
+
+// AddLineType adds a LineType to the LTYPE table. The pattern has the
+// lengths of its dashes, positive, and spaces, negative. It fails if the
+// line type already exists.
+func (doc *DxfDocument) AddLineType(name string, description string, pattern ...float64) (*sections.LineType, error) {
+	tables := doc.tables()
+	if tables.LineTypes == nil {
+		tables.LineTypes = sections.Table{}
+	}
+	if _, ok := tables.LineTypes[name]; ok {
+		return nil, fmt.Errorf("LineType already exists: %v", name)
+	}
+
+	ltype := &sections.LineType{Owner: doc.tableHandle("LTYPE"), Handle: doc.NextHandle(),
+		Name: name, Description: description,
+		Pattern: make([]*sections.LineElement, 0, len(pattern))}
+	for _, length := range pattern {
+		ltype.Length += math.Abs(length)
+		ltype.Pattern = append(ltype.Pattern, &sections.LineElement{Length: length, Scale: 1.0})
+	}
+	tables.LineTypes[name] = ltype
+	return ltype, nil
+}
+
+// AddStyle adds a text Style with the font file and a variable height to
+// the STYLE table. It fails if the style already exists.
+func (doc *DxfDocument) AddStyle(name string, font string) (*sections.Style, error) {
+	tables := doc.tables()
+	if tables.Styles == nil {
+		tables.Styles = sections.Table{}
+	}
+	if _, ok := tables.Styles[name]; ok {
+		return nil, fmt.Errorf("Style already exists: %v", name)
+	}
+
+	style := &sections.Style{Owner: doc.tableHandle("STYLE"), Handle: doc.NextHandle(),
+		Name: name, Width: 1.0, Font: font}
+	tables.Styles[name] = style
+	return style, nil
+}
+
+// NewBlock adds an empty Block on layer "0" to the document, with its
+// BLOCK_RECORD, returning the Space to add its entities. It fails if the
+// Block already exists.
+func (doc *DxfDocument) NewBlock(name string, basePoint core.Point) (*Space, error) {
+	if doc.Blocks == nil {
+		doc.Blocks = sections.BlocksSection{}
+	}
+	if _, ok := doc.Blocks[name]; ok {
+		return nil, fmt.Errorf("Block already exists: %v", name)
+	}
+
+	owner := doc.addRecord("BLOCK_RECORD", "AcDbBlockTableRecord", name)
+	block := &sections.Block{Name: name, Owner: owner, Handle: doc.NextHandle(),
+		EndHandle: doc.NextHandle(), LayerName: "0", BasePoint: basePoint, Entities: entities.EntitySlice{}}
+	doc.Blocks[name] = block
+	return &Space{doc: doc, block: block, owner: block.Owner}, nil
+}
+
+// ModelSpace returns the Space adding entities to the model space, the
+// ENTITIES section.
+func (doc *DxfDocument) ModelSpace() *Space {
+	return doc.entitiesSpace(ModelSpaceBlockName, entities.MODEL)
+}
+
+// PaperSpace returns the Space adding entities to the active paper space
+// layout, in the ENTITIES section.
+func (doc *DxfDocument) PaperSpace() *Space {
+	return doc.entitiesSpace(PaperSpaceBlockName, entities.PAPER)
+}
+
+func (doc *DxfDocument) entitiesSpace(blockName string, space entities.Space) *Space {
+	if doc.Entities == nil {
+		doc.Entities = &sections.EntitiesSection{}
+	}
+	owner := ""
+	if block, ok := doc.Blocks[blockName]; ok {
+		owner = block.Owner
+	}
+	return &Space{doc: doc, owner: owner, space: space}
+}
+
+// Space adds entities to the model space, the paper space or a Block of a
+// document, giving them a handle and their owner, the BLOCK_RECORD of the
+// Block.
+type Space struct {
+	doc   *DxfDocument
+	block *sections.Block
+	owner string
+	space entities.Space
+}
+
+// Block returns the Block of the entities, nil for the model and paper
+// spaces.
+func (s *Space) Block() *sections.Block {
+	return s.block
+}
+
+// Add adds the entity, setting the handle and owner of it and its nested
+// entities. Entities without a layer are put on layer "0".
+func (s *Space) Add(entity entities.Entity) entities.Entity {
+	s.prepare(entity, s.owner)
+	if s.block != nil {
+		s.block.Entities = append(s.block.Entities, entity)
+	} else {
+		s.doc.Entities.Entities = append(s.doc.Entities.Entities, entity)
+	}
+	return entity
+}
+
+func (s *Space) prepare(entity entities.Entity, owner string) {
+	base := entities.EntityBase(entity)
+	if base == nil {
+		return
+	}
+	base.Handle = s.doc.NextHandle()
+	base.Owner = owner
+	if entity.HasNestedEntities() {
+		base.SeqEndHandle = s.doc.NextHandle()
+	}
+	if s.block == nil {
+		base.Space = s.space
+	}
+	if base.LayerName == "" {
+		base.LayerName = "0"
+	}
+	for _, nested := range nestedEntities(entity) {
+		s.prepare(nested, base.Handle)
+	}
+}
+
+// newBaseEntity returns the BaseEntity of the added entities: visible, on
+// layer "0" and with the color and line type of the layer.
+func newBaseEntity() entities.BaseEntity {
+	return entities.BaseEntity{LayerName: "0", On: true, Visible: true}
+}
+
+// AddPoint adds a POINT at location.
+func (s *Space) AddPoint(location core.Point) *entities.Point {
+	point := &entities.Point{BaseEntity: newBaseEntity(), Location: location,
+		ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0}}
+	s.Add(point)
+	return point
+}
+
+// AddLine adds a LINE from start to end.
+func (s *Space) AddLine(start core.Point, end core.Point) *entities.Line {
+	line := &entities.Line{BaseEntity: newBaseEntity(), Start: start, End: end,
+		ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0}}
+	s.Add(line)
+	return line
+}
+
+// AddCircle adds a CIRCLE.
+func (s *Space) AddCircle(center core.Point, radius float64) *entities.Circle {
+	circle := &entities.Circle{BaseEntity: newBaseEntity(), Center: center, Radius: radius,
+		ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0}}
+	s.Add(circle)
+	return circle
+}
+
+// AddArc adds an ARC, counterclockwise from the start to the end angle, in
+// degrees.
+func (s *Space) AddArc(center core.Point, radius float64, startAngle float64, endAngle float64) *entities.Arc {
+	arc := &entities.Arc{BaseEntity: newBaseEntity(), Center: center, Radius: radius,
+		StartAngle: startAngle, EndAngle: endAngle,
+		ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0}}
+	s.Add(arc)
+	return arc
+}
+
+// AddLWPolyline adds a LWPOLYLINE through the points, at the elevation of
+// the first one.
+func (s *Space) AddLWPolyline(points core.PointSlice, closed bool) *entities.LWPolyline {
+	polyline := &entities.LWPolyline{BaseEntity: newBaseEntity(), Closed: closed,
+		Points:             make(entities.LWPolyLinePointSlice, 0, len(points)),
+		ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0}}
+	for i, point := range points {
+		if i == 0 {
+			polyline.Elevation = point.Z
+		}
+		polyline.Points = append(polyline.Points, entities.LWPolyLinePoint{
+			Point: core.Point{X: point.X, Y: point.Y}})
+	}
+	s.Add(polyline)
+	return polyline
+}
+
+// AddPolyline3D adds a 3D POLYLINE through the points, with its vertices.
+func (s *Space) AddPolyline3D(points core.PointSlice, closed bool) *entities.Polyline {
+	polyline := &entities.Polyline{BaseEntity: newBaseEntity(), Closed: closed, Is3dPolyline: true,
+		Vertices:           make(entities.VertexSlice, 0, len(points)),
+		ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0}}
+	for _, point := range points {
+		polyline.Vertices = append(polyline.Vertices, &entities.Vertex{
+			BaseEntity: newBaseEntity(), Location: point, Is3dPolylineVertex: true})
+	}
+	s.Add(polyline)
+	return polyline
+}
+
+// AddText adds a single line TEXT in the STANDARD style.
+func (s *Space) AddText(value string, insertion core.Point, height float64) *entities.Text {
+	text := &entities.Text{BaseEntity: newBaseEntity(), Value: value, FirstAlignmentPoint: insertion,
+		Height: height, RelativeXScale: 1.0, StyleName: "STANDARD",
+		ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0}}
+	s.Add(text)
+	return text
+}
+
+// AddInsert adds an INSERT of the named Block at the insertion point.
+func (s *Space) AddInsert(blockName string, insertion core.Point) *entities.Insert {
+	insert := &entities.Insert{BaseEntity: newBaseEntity(), BlockName: blockName,
+		InsertionPoint: insertion, ScaleFactorX: 1.0, ScaleFactorY: 1.0, ScaleFactorZ: 1.0,
+		ColumnCount: 1, RowCount: 1, Entities: entities.EntitySlice{},
+		ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0}}
+	s.Add(insert)
+	return insert
+}
@@ -0,0 +1,141 @@
+package document
+
+import (
+	"bytes"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/sections"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	doc := New("")
+	assert.Equal(t, DefaultVersion, doc.Header.Get("$ACADVER")[0].Value.ToString())
+	assert.Equal(t, "E", doc.Header.Get("$HANDSEED")[0].Value.ToString())
+
+	// the tables and their records have a handle and are owned by the table.
+	assert.Equal(t, map[string]string{"LTYPE": "1", "LAYER": "2", "STYLE": "3"}, doc.Tables.Handles)
+	assert.Equal(t, &sections.LineType{Handle: "4", Owner: "1", Name: "CONTINUOUS",
+		Description: "Solid line", Pattern: []*sections.LineElement{}}, doc.Tables.LineTypes["CONTINUOUS"])
+	assert.Equal(t, "5", doc.Tables.Layers["0"].(*sections.Layer).Handle)
+	assert.Equal(t, "2", doc.Tables.Layers["0"].(*sections.Layer).Owner)
+	assert.Equal(t, "6", doc.Tables.Styles["STANDARD"].(*sections.Style).Handle)
+	assert.Equal(t, "3", doc.Tables.Styles["STANDARD"].(*sections.Style).Owner)
+
+	// the Blocks, their ENDBLK and their BLOCK_RECORD too.
+	model := doc.Blocks[ModelSpaceBlockName]
+	assert.Equal(t, "8", model.Owner)
+	assert.Equal(t, "9", model.Handle)
+	assert.Equal(t, "A", model.EndHandle)
+	paper := doc.Blocks[PaperSpaceBlockName]
+	assert.Equal(t, "B", paper.Owner)
+	assert.Equal(t, "C", paper.Handle)
+	assert.Equal(t, "D", paper.EndHandle)
+	assert.Equal(t, core.TagSlice{
+		core.NewStringTag(0, "TABLE"),
+		core.NewStringTag(2, "BLOCK_RECORD"),
+		core.NewStringTag(5, "7"),
+		core.NewHandleTag(330, "0"),
+		core.NewStringTag(100, "AcDbSymbolTable"),
+		core.NewIntegerTag(70, 2),
+		core.NewStringTag(0, "BLOCK_RECORD"),
+		core.NewStringTag(5, "8"),
+		core.NewHandleTag(330, "7"),
+		core.NewStringTag(100, "AcDbSymbolTableRecord"),
+		core.NewStringTag(100, "AcDbBlockTableRecord"),
+		core.NewStringTag(2, ModelSpaceBlockName),
+		core.NewStringTag(0, "BLOCK_RECORD"),
+		core.NewStringTag(5, "B"),
+		core.NewHandleTag(330, "7"),
+		core.NewStringTag(100, "AcDbSymbolTableRecord"),
+		core.NewStringTag(100, "AcDbBlockTableRecord"),
+		core.NewStringTag(2, PaperSpaceBlockName),
+		core.NewStringTag(0, "ENDTAB"),
+	}, doc.Tables.Others["BLOCK_RECORD"])
+
+	assert.Equal(t, "AC1009", New("AC1009").Header.Get("$ACADVER")[0].Value.ToString())
+}
+
+func TestDocumentBuilder(t *testing.T) {
+	doc := New("AC1015")
+
+	layer, err := doc.AddLayer("WALLS", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, "CONTINUOUS", layer.LineType)
+	_, err = doc.AddLayer("WALLS", 2)
+	assert.NotNil(t, err)
+
+	dashed, err := doc.AddLineType("DASHED", "__ __", 0.5, -0.25)
+	assert.Nil(t, err)
+	assert.Equal(t, 0.75, dashed.Length)
+	_, err = doc.AddStyle("STANDARD", "arial.ttf")
+	assert.NotNil(t, err)
+
+	block, err := doc.NewBlock("DOOR", core.Point{X: 1.0})
+	assert.Nil(t, err)
+	arc := block.AddArc(core.Point{}, 1.0, 0.0, 90.0)
+	assert.Equal(t, "DOOR", block.Block().Name)
+	assert.Equal(t, entities.EntitySlice{arc}, doc.Blocks["DOOR"].Entities)
+	assert.Equal(t, doc.Blocks["DOOR"].Owner, arc.Owner)
+	_, err = doc.NewBlock("DOOR", core.Point{})
+	assert.NotNil(t, err)
+
+	model := doc.ModelSpace()
+	line := model.AddLine(core.Point{}, core.Point{X: 10.0})
+	line.LayerName = "WALLS"
+	insert := model.AddInsert("DOOR", core.Point{X: 5.0})
+	polyline := model.AddPolyline3D(core.PointSlice{{}, {X: 1.0, Z: 1.0}}, false)
+	text := doc.PaperSpace().AddText("Title", core.Point{}, 2.5)
+
+	assert.Equal(t, entities.EntitySlice{line, insert, polyline, text}, doc.Entities.Entities)
+	assert.Equal(t, doc.Blocks[ModelSpaceBlockName].Owner, line.Owner)
+	assert.Equal(t, doc.Blocks[PaperSpaceBlockName].Owner, text.Owner)
+	assert.Equal(t, entities.PAPER, text.Space)
+	assert.Equal(t, polyline.Handle, polyline.Vertices[1].Owner)
+
+	// every object has its own handle.
+	handles := map[string]bool{}
+	for _, handle := range []string{layer.Handle, dashed.Handle, doc.Blocks["DOOR"].Owner,
+		doc.Blocks["DOOR"].Handle, doc.Blocks["DOOR"].EndHandle, arc.Handle, line.Handle,
+		insert.Handle, polyline.Handle, polyline.Vertices[0].Handle,
+		polyline.Vertices[1].Handle, polyline.SeqEndHandle, text.Handle} {
+		assert.NotEqual(t, "", handle)
+		handles[handle] = true
+	}
+	assert.Len(t, handles, 13)
+	assert.Equal(t, "1B", doc.Header.Get("$HANDSEED")[0].Value.ToString())
+	assert.Equal(t, "", insert.SeqEndHandle)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, DxfDocumentToStream(&buffer, doc))
+	written, err := DxfDocumentFromStream(&buffer)
+	assert.Nil(t, err)
+	assert.True(t, doc.Equals(written))
+}
+
+func TestNextHandle(t *testing.T) {
+	doc := DxfDocument{
+		Entities: &sections.EntitiesSection{Entities: entities.EntitySlice{
+			&entities.Line{BaseEntity: entities.BaseEntity{Handle: "2A"}},
+		}},
+		Blocks: sections.BlocksSection{"A": &sections.Block{Name: "A", Handle: "1F"}},
+	}
+
+	// without $HANDSEED the handles follow the highest one.
+	assert.Equal(t, "2B", doc.NextHandle())
+	assert.Equal(t, "2C", doc.NextHandle())
+	assert.Equal(t, "2D", doc.Header.Get("$HANDSEED")[0].Value.ToString())
+
+	// the handles of the tables, their records and the ENDBLKs count too.
+	doc = DxfDocument{
+		Tables: &sections.TablesSection{
+			Layers:  sections.Table{"0": &sections.Layer{Name: "0", Handle: "3A"}},
+			Handles: map[string]string{"LAYER": "2"},
+			Others: map[string]core.TagSlice{"BLOCK_RECORD": {
+				core.NewStringTag(0, "TABLE"), core.NewStringTag(5, "3B"), core.NewStringTag(0, "ENDTAB")}},
+		},
+		Blocks: sections.BlocksSection{"A": &sections.Block{Name: "A", Handle: "1F", EndHandle: "3C"}},
+	}
+	assert.Equal(t, "3D", doc.NextHandle())
+}
@@ -1,6 +1,7 @@
 package document
 
 import (
+	"context"
 	"io"
 
 	"github.com/rpaloschi/dxf-go/core"
@@ -9,22 +10,81 @@ import (
 
 // DxfDocument the representation of a full dxf document.
 type DxfDocument struct {
-	Header   *sections.HeaderSection
+	Header *sections.HeaderSection
+	// Classes is nil for the documents without a CLASSES section, like the
+	// DXF R12 ones.
+	Classes  *sections.ClassesSection
 	Tables   *sections.TablesSection
 	Entities *sections.EntitiesSection
 	Blocks   sections.BlocksSection
+	// Thumbnail is the preview image of the drawing, nil for the documents
+	// without a THUMBNAILIMAGE section.
+	Thumbnail *sections.ThumbnailImageSection
+	// UnknownSections has the sections that are not parsed, like OBJECTS or
+	// ACDSDATA, in the file order. Each one keeps all its tags, from SECTION
+	// to ENDSEC.
+	UnknownSections []core.TagSlice
 }
 
 // Equals compares against the other DxfDocument for equality.
 func (doc DxfDocument) Equals(other *DxfDocument) bool {
+	if len(doc.UnknownSections) != len(other.UnknownSections) {
+		return false
+	}
+	for i, section := range doc.UnknownSections {
+		if !section.Equals(other.UnknownSections[i]) {
+			return false
+		}
+	}
+
+	if (doc.Classes == nil) != (other.Classes == nil) ||
+		(doc.Classes != nil && !doc.Classes.Equals(other.Classes)) {
+		return false
+	}
+
+	if (doc.Thumbnail == nil) != (other.Thumbnail == nil) ||
+		(doc.Thumbnail != nil && !doc.Thumbnail.Equals(other.Thumbnail)) {
+		return false
+	}
+
 	return doc.Header.Equals(other.Header) &&
 		doc.Tables.Equals(other.Tables) &&
 		doc.Entities.Equals(other.Entities) &&
 		doc.Blocks.Equals(other.Blocks)
 }
 
-// DxfDocumentFromStream reads a DxfDocument from the stream.
+// DxfDocumentFromStream reads a DxfDocument from the stream. It fails on
+// the first error, see DxfDocumentFromStreamOptions.
 func DxfDocumentFromStream(stream io.Reader) (*DxfDocument, error) {
+	return dxfDocumentFromStream(stream, sections.Parser{})
+}
+
+// DxfDocumentFromStreamOptions reads a DxfDocument from the stream with the
+// options, returning the Diagnostics of the parsing. In core.Lenient mode the
+// sections, entities and tags that cannot be parsed are skipped, and it only
+// fails when the stream cannot be read or the file exceeds the Limits of the
+// options.
+func DxfDocumentFromStreamOptions(stream io.Reader, options core.ParseOptions) (*DxfDocument, []core.Diagnostic, error) {
+	return DxfDocumentFromStreamContext(context.Background(), stream, options)
+}
+
+// DxfDocumentFromStreamContext reads a DxfDocument from the stream with the
+// options, as DxfDocumentFromStreamOptions, failing with a
+// core.ErrLimitExceeded when the file exceeds their Limits. It stops with the
+// error of the ctx when it is done, checked while reading the tags and
+// parsing the sections.
+func DxfDocumentFromStreamContext(ctx context.Context, stream io.Reader, options core.ParseOptions) (*DxfDocument, []core.Diagnostic, error) {
+	diagnostics := core.NewDiagnostics(options)
+	parser := sections.Parser{
+		Diagnostics: diagnostics,
+		Limiter:     core.NewLimiter(ctx, options.Limits),
+		Workers:     options.Workers,
+	}
+	doc, err := dxfDocumentFromStream(stream, parser)
+	return doc, diagnostics.List, err
+}
+
+func dxfDocumentFromStream(stream io.Reader, parser sections.Parser) (*DxfDocument, error) {
 	doc := new(DxfDocument)
 
 	doc.Header = new(sections.HeaderSection)
@@ -38,40 +98,168 @@ func DxfDocumentFromStream(stream io.Reader) (*DxfDocument, error) {
 			doc.Header = header
 			return nil
 		},
+		"CLASSES": func(slice core.TagSlice) error {
+			section, err := parser.ClassesSection(slice)
+			if err == nil {
+				doc.Classes = section
+			}
+			return err
+		},
 		"TABLES": func(slice core.TagSlice) error {
-			section, err := sections.NewTablesSection(slice)
-			doc.Tables = section
+			section, err := parser.TablesSection(slice)
+			if err == nil {
+				doc.Tables = section
+			}
 			return err
 		},
 		"ENTITIES": func(slice core.TagSlice) error {
-			section, err := sections.NewEntitiesSection(slice)
-			doc.Entities = section
+			section, err := parser.EntitiesSection(slice)
+			if err == nil {
+				doc.Entities = section
+			}
 			return err
 		},
 		"BLOCKS": func(slice core.TagSlice) error {
-			section, err := sections.NewBlocksSection(slice)
-			doc.Blocks = section
+			section, err := parser.BlocksSection(slice)
+			if err == nil {
+				doc.Blocks = section
+			}
+			return err
+		},
+		"THUMBNAILIMAGE": func(slice core.TagSlice) error {
+			section, err := parser.ThumbnailImageSection(slice)
+			if err == nil {
+				doc.Thumbnail = section
+			}
 			return err
 		},
 	}
 
-	next := core.Tagger(stream)
-	tags := core.TagSlice(core.AllTags(next))
+	next := parser.Limiter.Tagger(stream)
+	tags, err := parser.Diagnostics.ReadAllTags(next)
+	if err != nil {
+		return nil, err
+	}
 
 	stopTag := core.NewTag(0, core.NewStringValue("EOF"))
 	endOfChunk := core.NewTag(0, core.NewStringValue("ENDSEC"))
+	if len(tags) == 0 || !tags[len(tags)-1].Equals(stopTag) {
+		parser.Diagnostics.Report(core.SeverityWarning, nil, "Missing EOF, the file may be truncated.")
+	}
 	for _, sectionTags := range sections.SplitTagChunks(tags, stopTag, endOfChunk) {
+		if err := parser.Limiter.Err(); err != nil {
+			return nil, err
+		}
+		if len(sectionTags) < 2 || sectionTags[1].Code != 2 ||
+			!sectionTags[0].Equals(core.NewStringTag(0, "SECTION")) {
+			if err := parser.Recover(sectionTags, core.ErrInvalidSection); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !sectionTags[len(sectionTags)-1].Equals(endOfChunk) {
+			// a truncated section is parsed up to the end of the file in
+			// lenient mode.
+			if err := parser.Recover(sectionTags, core.ErrMissingEndSection); err != nil {
+				return nil, err
+			}
+			sectionTags = append(sectionTags, endOfChunk)
+		}
 		sectionType := sectionTags[1].Value.ToString()
 
 		if parserFunc, ok := sectionParsers[sectionType]; ok {
-			err := parserFunc(sectionTags)
-			if err != nil {
-				return nil, err
+			if err := parserFunc(sectionTags); err != nil {
+				// a section that cannot be parsed is skipped in lenient mode.
+				if err = parser.Recover(sectionTags, err); err != nil {
+					return nil, err
+				}
 			}
 		} else {
-			core.Log.Printf("Ignoring unsupported Section type: %+v\n", sectionType)
+			parser.Diagnostics.Report(core.SeverityInfo, sectionTags, "Keeping unsupported Section type: %+v", sectionType)
+			doc.UnknownSections = append(doc.UnknownSections, sectionTags)
+		}
+	}
+
+	if max := parser.Limiter.MaxInsertDepth(); max > 0 {
+		if err := parser.Limiter.CheckInsertDepth(doc.insertDepth(max)); err != nil {
+			return nil, err
 		}
 	}
 
 	return doc, nil
 }
+
+// sectionOrder is the order of the sections in the DXF reference.
+var sectionOrder = []string{
+	"HEADER", "CLASSES", "TABLES", "BLOCKS", "ENTITIES", "OBJECTS", "THUMBNAILIMAGE", "ACDSDATA",
+}
+
+// Tags returns the tags of the DxfDocument: its HEADER, CLASSES, TABLES,
+// BLOCKS, ENTITIES and THUMBNAILIMAGE sections, the missing ones are
+// skipped, and the unknown ones in the order of the DXF reference, followed
+// by the closing EOF.
+func (doc DxfDocument) Tags() core.TagSlice {
+	return doc.TagsOptions(core.WriteOptions{})
+}
+
+// TagsOptions returns the tags of the DxfDocument as Tags, logging the
+// entities and table entries skipped to the Logger of the options.
+func (doc DxfDocument) TagsOptions(options core.WriteOptions) core.TagSlice {
+	parsed := make(map[string]core.TagSlice)
+	if doc.Header != nil {
+		parsed["HEADER"] = doc.Header.Tags()
+	}
+	if doc.Classes != nil {
+		parsed["CLASSES"] = doc.Classes.Tags()
+	}
+	if doc.Tables != nil {
+		parsed["TABLES"] = doc.Tables.TagsOptions(options)
+	}
+	if doc.Blocks != nil {
+		parsed["BLOCKS"] = doc.Blocks.TagsOptions(options)
+	}
+	if doc.Entities != nil {
+		parsed["ENTITIES"] = doc.Entities.TagsOptions(options)
+	}
+	if doc.Thumbnail != nil {
+		parsed["THUMBNAILIMAGE"] = doc.Thumbnail.Tags()
+	}
+
+	unknown := make(map[string][]core.TagSlice)
+	var names []string
+	for _, section := range doc.UnknownSections {
+		name := section[1].Value.ToString()
+		if _, ok := unknown[name]; !ok {
+			names = append(names, name)
+		}
+		unknown[name] = append(unknown[name], section)
+	}
+
+	var tags core.TagSlice
+	written := make(map[string]bool)
+	for _, name := range append(sectionOrder, names...) {
+		if written[name] {
+			continue
+		}
+		written[name] = true
+
+		tags = append(tags, parsed[name]...)
+		for _, section := range unknown[name] {
+			tags = append(tags, section...)
+		}
+	}
+	return append(tags, core.NewStringTag(0, "EOF"))
+}
+
+// DxfDocumentToStream writes the DxfDocument to the stream as a DXF text
+// file.
+func DxfDocumentToStream(stream io.Writer, doc *DxfDocument) error {
+	return DxfDocumentToStreamOptions(stream, doc, core.WriteOptions{})
+}
+
+// DxfDocumentToStreamOptions writes the DxfDocument to the stream as a DXF
+// text file with the options, logging the entities and table entries that
+// cannot be written to their Logger.
+func DxfDocumentToStreamOptions(stream io.Writer, doc *DxfDocument, options core.WriteOptions) error {
+	return core.WriteTags(stream, doc.TagsOptions(options))
+}
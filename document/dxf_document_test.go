@@ -1,11 +1,19 @@
 package document
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/rpaloschi/dxf-go/core"
 	"github.com/rpaloschi/dxf-go/entities"
 	"github.com/rpaloschi/dxf-go/sections"
 	"github.com/stretchr/testify/assert"
+	"image"
+	"image/png"
+	"runtime"
 	"strings"
 	"testing"
 )
@@ -28,14 +36,147 @@ func TestDxfDocumentErrorParsingSection(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestDxfDocumentFromStreamInvalidValue(t *testing.T) {
+	dxf := testSimpleDxf + "  0\nSECTION\n  2\nENTITIES\n  0\nLINE\n 10\n1,5\n  0\nENDSEC\n"
+	doc, err := DxfDocumentFromStream(strings.NewReader(dxf))
+
+	assert.Nil(t, doc)
+	var parseError *core.ParseError
+	assert.True(t, errors.As(err, &parseError))
+	assert.Equal(t, strings.Count(testSimpleDxf, "\n")+7, parseError.Line)
+	assert.Equal(t, 10, parseError.Code)
+	assert.Equal(t, "1,5", parseError.Value)
+}
+
+func TestDxfDocumentFromStreamOptions(t *testing.T) {
+	dxf := testSimpleDxf + "  0\nSECTION\n  2\nENTITIES\n  0\nLINE\n 10\n1,5\n  0\nPOINT\n 10\n2.0\n  0\nENDSEC\n  0\nEOF\n"
+
+	_, diagnostics, err := DxfDocumentFromStreamOptions(strings.NewReader(dxf), core.ParseOptions{})
+	assert.NotNil(t, err)
+	assert.Len(t, diagnostics, 0)
+
+	doc, diagnostics, err := DxfDocumentFromStreamOptions(
+		strings.NewReader(dxf), core.ParseOptions{Mode: core.Lenient})
+	assert.Nil(t, err)
+	assert.Len(t, doc.Entities.Entities, 2)
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, core.SeverityError, diagnostics[0].Severity)
+	assert.Equal(t, strings.Count(testSimpleDxf, "\n")+7, diagnostics[0].Line)
+}
+
+func TestDxfDocumentFromStreamTruncated(t *testing.T) {
+	dxf := testSimpleDxf + "  0\nSECTION\n  2\nENTITIES\n  0\nLINE\n 10\n1.0\n  0\nPOINT\n 10\n"
+
+	doc, err := DxfDocumentFromStream(strings.NewReader(dxf))
+	assert.Nil(t, doc)
+	assert.True(t, errors.Is(err, core.ErrTruncatedFile))
+
+	// the section is parsed up to the truncated tag in lenient mode.
+	doc, diagnostics, err := DxfDocumentFromStreamOptions(
+		strings.NewReader(dxf), core.ParseOptions{Mode: core.Lenient})
+	assert.Nil(t, err)
+	assert.Len(t, doc.Entities.Entities, 2)
+	assert.Len(t, diagnostics, 3)
+	assert.Equal(t, core.SeverityError, diagnostics[0].Severity)
+	assert.Equal(t, core.SeverityWarning, diagnostics[1].Severity)
+	assert.Equal(t, "Missing ENDSEC, the section is truncated.", diagnostics[2].Message)
+}
+
+func TestDxfDocumentFromStreamMissingEndSection(t *testing.T) {
+	dxf := testSimpleDxf + "  0\nSECTION\n  2\nENTITIES\n  0\nLINE\n 10\n1.0\n"
+
+	doc, err := DxfDocumentFromStream(strings.NewReader(dxf))
+	assert.Nil(t, doc)
+	assert.True(t, errors.Is(err, core.ErrMissingEndSection))
+
+	doc, diagnostics, err := DxfDocumentFromStreamOptions(
+		strings.NewReader(dxf), core.ParseOptions{Mode: core.Lenient})
+	assert.Nil(t, err)
+	assert.Len(t, doc.Entities.Entities, 1)
+	assert.Len(t, diagnostics, 2)
+}
+
+func TestDxfDocumentFromStreamNotASection(t *testing.T) {
+	dxf := testSimpleDxf + "  0\nLINE\n 10\n1.0\n  0\nENDSEC\n  0\nEOF\n"
+
+	doc, err := DxfDocumentFromStream(strings.NewReader(dxf))
+	assert.Nil(t, doc)
+	assert.True(t, errors.Is(err, core.ErrInvalidSection))
+
+	doc, diagnostics, err := DxfDocumentFromStreamOptions(
+		strings.NewReader(dxf), core.ParseOptions{Mode: core.Lenient})
+	assert.Nil(t, err)
+	assert.NotNil(t, doc)
+	assert.Len(t, diagnostics, 1)
+	assert.Equal(t, "LINE", diagnostics[0].EntityType)
+}
+
 func TestDxfDocumentFromStreamWithInvalidSection(t *testing.T) {
 	doc, err := DxfDocumentFromStream(
 		strings.NewReader(testSimpleDxfWithInvalidSection))
 
+	// the unknown section is kept, to be written back.
+	expected := expectedDocument
+	expected.UnknownSections = []core.TagSlice{{
+		core.NewStringTag(0, "SECTION"),
+		core.NewStringTag(2, "IDONTEXIST"),
+		core.NewStringTag(0, "ENDSEC"),
+	}}
+
 	assert.Nil(t, err)
-	assert.True(t, expectedDocument.Equals(doc),
+	assert.True(t, expected.Equals(doc),
 		"Expected %+v and %+v to be equals",
-		spew.Sdump(expectedDocument), spew.Sdump(doc))
+		spew.Sdump(expected), spew.Sdump(doc))
+}
+
+func TestDxfDocumentToStream(t *testing.T) {
+	doc, err := DxfDocumentFromStream(strings.NewReader(testSimpleDxf))
+	assert.Nil(t, err)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, DxfDocumentToStream(&buffer, doc))
+	output := buffer.String()
+	assert.True(t, strings.HasPrefix(output, "  0\nSECTION\n  2\nHEADER\n"), output)
+	assert.True(t, strings.HasSuffix(output, "  0\nENDSEC\n  0\nEOF\n"), output)
+
+	written, err := DxfDocumentFromStream(&buffer)
+	assert.Nil(t, err)
+	assert.True(t, doc.Equals(written),
+		"Expected %+v and %+v to be equals", spew.Sdump(doc), spew.Sdump(written))
+}
+
+func TestDxfDocumentToStreamKeepsUnknownTags(t *testing.T) {
+	doc, err := DxfDocumentFromStream(strings.NewReader(testDxfWithUnknownTags))
+	assert.Nil(t, err)
+	assert.Len(t, doc.UnknownSections, 1)
+	assert.Len(t, doc.Classes.Classes, 0)
+	assert.Contains(t, doc.Tables.Others, "VPORT")
+
+	// edit a single attribute.
+	line := doc.Entities.Entities[1].(*entities.Line)
+	line.LayerName = "EDITED"
+
+	var buffer bytes.Buffer
+	assert.Nil(t, DxfDocumentToStream(&buffer, doc))
+	output := buffer.String()
+
+	written, err := DxfDocumentFromStream(strings.NewReader(output))
+	assert.Nil(t, err)
+	assert.True(t, doc.Equals(written),
+		"Expected %+v and %+v to be equals", spew.Sdump(doc), spew.Sdump(written))
+	assert.Equal(t, "EDITED", written.Entities.Entities[1].(*entities.Line).LayerName)
+
+	// the sections are in the order of the DXF reference.
+	sectionNames := []string{}
+	tags := core.TagSlice(core.AllTags(core.Tagger(strings.NewReader(output))))
+	for i, tag := range tags[:len(tags)-1] {
+		if tag.Code == 0 && tag.Value.ToString() == "SECTION" {
+			sectionNames = append(sectionNames, tags[i+1].Value.ToString())
+		}
+	}
+	assert.Equal(t, []string{"HEADER", "CLASSES", "TABLES", "BLOCKS", "ENTITIES", "OBJECTS"}, sectionNames)
+	assert.Contains(t, output, "102\n{ACAD_REACTORS\n330\n1F\n102\n}\n")
+	assert.Contains(t, output, "  0\nMTEXT\n  5\n2A\n")
 }
 
 const testSimpleDxf = `  0
@@ -279,3 +420,295 @@ func init() {
 		},
 	}
 }
+
+func TestDxfDocumentThumbnail(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 4, 3))
+	var thumbnail bytes.Buffer
+	assert.Nil(t, png.Encode(&thumbnail, img))
+
+	var dxf bytes.Buffer
+	dxf.WriteString(testSimpleDxf)
+	assert.Nil(t, core.WriteTags(&dxf, sections.ThumbnailImageSection{Data: thumbnail.Bytes()}.Tags()))
+	dxf.WriteString("  0\nEOF\n")
+
+	doc, err := DxfDocumentFromStream(&dxf)
+	assert.Nil(t, err)
+	assert.Len(t, doc.UnknownSections, 0)
+	assert.Equal(t, thumbnail.Bytes(), doc.Thumbnail.Data)
+	assert.Equal(t, img.Bounds(), doc.Thumbnail.Image.Bounds())
+	assert.False(t, expectedDocument.Equals(doc))
+
+	var buffer bytes.Buffer
+	assert.Nil(t, DxfDocumentToStream(&buffer, doc))
+	written, err := DxfDocumentFromStream(&buffer)
+	assert.Nil(t, err)
+	assert.True(t, doc.Equals(written))
+
+	// the thumbnails are newer than R14.
+	tags, _, err := doc.VersionTags("AC1014")
+	assert.Nil(t, err)
+	assert.Equal(t, -1, tags.TagIndex(310, 0, len(tags)))
+	tags, _, err = doc.VersionTags("AC1015")
+	assert.Nil(t, err)
+	assert.NotEqual(t, -1, tags.TagIndex(310, 0, len(tags)))
+}
+
+const testDxfWithUnknownTags = `  0
+SECTION
+  2
+HEADER
+  9
+$ACADVER
+  1
+AC1015
+  0
+ENDSEC
+  0
+SECTION
+  2
+CLASSES
+  0
+ENDSEC
+  0
+SECTION
+  2
+TABLES
+  0
+TABLE
+  2
+VPORT
+ 70
+1
+  0
+VPORT
+  2
+*ACTIVE
+ 70
+0
+  0
+ENDTAB
+  0
+ENDSEC
+  0
+SECTION
+  2
+ENTITIES
+  0
+MTEXT
+  5
+2A
+100
+AcDbEntity
+  8
+0
+100
+AcDbMText
+  1
+Notes
+  0
+LINE
+  5
+2B
+102
+{ACAD_REACTORS
+330
+1F
+102
+}
+100
+AcDbEntity
+  8
+0
+100
+AcDbLine
+ 10
+0.0
+ 20
+0.0
+ 30
+0.0
+ 11
+1.0
+ 21
+1.0
+ 31
+0.0
+  0
+ENDSEC
+  0
+SECTION
+  2
+OBJECTS
+  0
+DICTIONARY
+  5
+C
+100
+AcDbDictionary
+  0
+ENDSEC
+  0
+EOF
+`
+
+func FuzzDxfDocumentFromStream(f *testing.F) {
+	f.Add([]byte(testSimpleDxf))
+	f.Add([]byte(testSimpleDxfWithInvalidSection))
+	f.Add([]byte(testSimpleDxf + "  0\nSECTION\n  2\nENTITIES\n  0\nPOLYLINE\n  0\nVERTEX\n 10\n1.0\n"))
+	f.Add([]byte("  0\rSECTION\r  2\rBLOCKS\r  0\rENDBLK\r  0\rENDSEC\r  0\rEOF\r"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// the malformed files must return an error, never panic.
+		doc, err := DxfDocumentFromStream(bytes.NewReader(data))
+		if err == nil && doc == nil {
+			t.Fatal("nil document without an error")
+		}
+		doc, _, err = DxfDocumentFromStreamOptions(bytes.NewReader(data), core.ParseOptions{Mode: core.Lenient})
+		if err == nil && doc == nil {
+			t.Fatal("nil document without an error")
+		}
+	})
+}
+
+func TestDxfDocumentFromStreamContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	doc, _, err := DxfDocumentFromStreamContext(ctx, strings.NewReader(testSimpleDxf), core.ParseOptions{})
+	assert.Nil(t, doc)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestDxfDocumentFromStreamContextMaxEntities(t *testing.T) {
+	dxf := "  0\nSECTION\n  2\nENTITIES\n" +
+		strings.Repeat("  0\nPOINT\n 10\n1.0\n", 3) + "  0\nENDSEC\n  0\nEOF\n"
+
+	for _, mode := range []core.ParseMode{core.Strict, core.Lenient} {
+		doc, _, err := DxfDocumentFromStreamContext(context.Background(), strings.NewReader(dxf),
+			core.ParseOptions{Mode: mode, Limits: core.Limits{MaxEntities: 3}})
+		assert.Nil(t, err)
+		assert.Len(t, doc.Entities.Entities, 3)
+
+		doc, _, err = DxfDocumentFromStreamContext(context.Background(), strings.NewReader(dxf),
+			core.ParseOptions{Mode: mode, Limits: core.Limits{MaxEntities: 2}})
+		assert.Nil(t, doc)
+		assert.True(t, errors.Is(err, core.ErrLimitExceeded))
+	}
+}
+
+// testInsertsDxf returns a DXF file inserting the block A, and the blocks
+// inserting the next ones.
+func testInsertsDxf(blocks map[string]string) string {
+	insert := func(name string) string {
+		return "  0\nINSERT\n  2\n" + name + "\n 10\n0.0\n 20\n0.0\n"
+	}
+	dxf := "  0\nSECTION\n  2\nBLOCKS\n"
+	for name, inserted := range blocks {
+		dxf += "  0\nBLOCK\n  2\n" + name + "\n 10\n0.0\n 20\n0.0\n"
+		if inserted != "" {
+			dxf += insert(inserted)
+		}
+		dxf += "  0\nENDBLK\n"
+	}
+	return dxf + "  0\nENDSEC\n  0\nSECTION\n  2\nENTITIES\n" + insert("A") + "  0\nENDSEC\n  0\nEOF\n"
+}
+
+func TestDxfDocumentFromStreamContextMaxInsertDepth(t *testing.T) {
+	testCases := []struct {
+		blocks   map[string]string
+		maxDepth int
+		exceeded bool
+	}{
+		{map[string]string{"A": "B", "B": "C", "C": ""}, 3, false},
+		{map[string]string{"A": "B", "B": "C", "C": ""}, 2, true},
+		// the INSERTs of a missing block are not nested.
+		{map[string]string{"A": "B", "B": "MISSING"}, 3, false},
+		{map[string]string{"A": "B", "B": "A"}, 100, true},
+		{map[string]string{"A": "A"}, 100, true},
+	}
+
+	for _, test := range testCases {
+		dxf := testInsertsDxf(test.blocks)
+		doc, _, err := DxfDocumentFromStreamContext(context.Background(), strings.NewReader(dxf),
+			core.ParseOptions{Limits: core.Limits{MaxInsertDepth: test.maxDepth}})
+
+		if test.exceeded {
+			assert.Nil(t, doc, "%v", test.blocks)
+			assert.True(t, errors.Is(err, core.ErrLimitExceeded), "%v", test.blocks)
+		} else {
+			assert.Nil(t, err, "%v", test.blocks)
+			assert.Len(t, doc.Blocks, len(test.blocks))
+		}
+
+		// without a limit the blocks inserting themselves are kept.
+		_, _, err = DxfDocumentFromStreamContext(context.Background(), strings.NewReader(dxf), core.ParseOptions{})
+		assert.Nil(t, err)
+	}
+}
+
+func TestDxfDocumentInsertDepth(t *testing.T) {
+	doc, err := DxfDocumentFromStream(strings.NewReader(
+		testInsertsDxf(map[string]string{"A": "B", "B": "C", "C": "", "D": "A"})))
+	assert.Nil(t, err)
+
+	// the block D, not inserted, nests A, B and C.
+	assert.Equal(t, 3, doc.insertDepth(10))
+	assert.Equal(t, 3, doc.insertDepth(3))
+	assert.Equal(t, 2, doc.insertDepth(1))
+}
+
+// benchmarkEntities is the number of entities of the benchmark file, of about
+// 150 bytes each: "go test -bench . -args -benchmark.entities=2000000"
+// parses a 300 MB file.
+var benchmarkEntities = flag.Int("benchmark.entities", 100000, "number of entities of the benchmark DXF file")
+
+// benchmarkDxf returns a DXF file of count entities, half of them in blocks.
+func benchmarkDxf(count int) []byte {
+	var buffer bytes.Buffer
+	entity := func(i int) {
+		if i%2 == 0 {
+			fmt.Fprintf(&buffer, "  0\nLINE\n  5\n%X\n  8\n0\n 10\n%v.0\n 20\n1.0\n 30\n0.0\n 11\n2.0\n 21\n3.0\n 31\n0.0\n", i, i)
+		} else {
+			fmt.Fprintf(&buffer, "  0\nPOLYLINE\n  5\n%X\n  8\n0\n 66\n1\n  0\nVERTEX\n 10\n%v.0\n 20\n0.0\n"+
+				"  0\nVERTEX\n 10\n1.0\n 20\n1.0\n  0\nSEQEND\n", i, i)
+		}
+	}
+
+	buffer.WriteString("  0\nSECTION\n  2\nBLOCKS\n")
+	for i := 0; i < count/2; i++ {
+		if i%1000 == 0 {
+			fmt.Fprintf(&buffer, "  0\nBLOCK\n  2\nBLOCK%v\n 10\n0.0\n 20\n0.0\n", i)
+		}
+		entity(i)
+		if i%1000 == 999 || i == count/2-1 {
+			buffer.WriteString("  0\nENDBLK\n")
+		}
+	}
+	buffer.WriteString("  0\nENDSEC\n  0\nSECTION\n  2\nENTITIES\n")
+	for i := count / 2; i < count; i++ {
+		entity(i)
+	}
+	buffer.WriteString("  0\nENDSEC\n  0\nEOF\n")
+	return buffer.Bytes()
+}
+
+func BenchmarkDxfDocumentFromStream(b *testing.B) {
+	dxf := benchmarkDxf(*benchmarkEntities)
+
+	workerCounts := []int{1, 2, 4}
+	if procs := runtime.GOMAXPROCS(0); procs > 4 {
+		workerCounts = append(workerCounts, procs)
+	}
+	for _, workers := range workerCounts {
+		b.Run(fmt.Sprintf("workers=%v", workers), func(b *testing.B) {
+			b.SetBytes(int64(len(dxf)))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _, err := DxfDocumentFromStreamOptions(bytes.NewReader(dxf), core.ParseOptions{Workers: workers})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
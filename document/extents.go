@@ -0,0 +1,136 @@
+package document
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/sections"
+)
+
+// ExtentsOptions controls which entities are considered when computing the
+// DxfDocument extents.
+type ExtentsOptions struct {
+	// IncludeInserts resolves Inserts to the geometry of the referenced Block.
+	// If false only the insertion point and attributes are considered.
+	IncludeInserts bool
+	// IncludeInvisible considers entities that are invisible, turned off or on
+	// a layer that is turned off.
+	IncludeInvisible bool
+	// IncludeFrozenLayers considers entities on frozen layers.
+	IncludeFrozenLayers bool
+}
+
+// Extents computes the extents of the drawing from its entities, ignoring the
+// $EXTMIN/$EXTMAX header values. If no entity is considered, the returned box
+// is empty (see core.Extents.IsEmpty).
+func (doc DxfDocument) Extents(options ExtentsOptions) (min, max core.Point) {
+	calculator := extentsCalculator{
+		doc:     doc,
+		options: options,
+		blocks:  make(map[string]core.Extents),
+		visited: make(map[string]bool),
+	}
+
+	extents := core.NewExtents()
+	if doc.Entities != nil {
+		extents = calculator.entitiesExtents(doc.Entities.Entities)
+	}
+	return extents.Min, extents.Max
+}
+
+type extentsCalculator struct {
+	doc     DxfDocument
+	options ExtentsOptions
+	blocks  map[string]core.Extents
+	visited map[string]bool
+}
+
+func (c *extentsCalculator) entitiesExtents(entityList entities.EntitySlice) core.Extents {
+	extents := core.NewExtents()
+	for _, entity := range entityList {
+		if !c.isIncluded(entities.EntityBase(entity)) {
+			continue
+		}
+
+		if insert, ok := entity.(*entities.Insert); ok && c.options.IncludeInserts {
+			extents.Merge(c.insertExtents(insert))
+		} else {
+			extents.Merge(entities.EntityExtents(entity))
+		}
+	}
+	return extents
+}
+
+func (c *extentsCalculator) isIncluded(entity *entities.BaseEntity) bool {
+	if entity == nil {
+		return true
+	}
+	if !c.options.IncludeInvisible && (!entity.Visible || !entity.On) {
+		return false
+	}
+
+	if c.doc.Tables == nil {
+		return true
+	}
+	if layer, ok := c.doc.Tables.Layers[entity.LayerName].(*sections.Layer); ok {
+		if !c.options.IncludeFrozenLayers && layer.Frozen {
+			return false
+		}
+		if !c.options.IncludeInvisible && !layer.On {
+			return false
+		}
+	}
+	return true
+}
+
+// blockExtents returns the extents of the block in block coordinates.
+// Self referencing blocks are resolved only once.
+func (c *extentsCalculator) blockExtents(block *sections.Block) core.Extents {
+	if extents, ok := c.blocks[block.Name]; ok {
+		return extents
+	}
+	if c.visited[block.Name] {
+		return core.NewExtents()
+	}
+
+	c.visited[block.Name] = true
+	extents := c.entitiesExtents(block.Entities)
+	c.blocks[block.Name] = extents
+	return extents
+}
+
+// insertExtents transforms the extents of the referenced block by the insert
+// placement. The corners of the block box are transformed, so the result
+// contains the real extents but may be larger than them for rotated inserts.
+func (c *extentsCalculator) insertExtents(insert *entities.Insert) core.Extents {
+	extents := entities.EntityExtents(insert)
+
+	block, ok := c.doc.Blocks[insert.BlockName]
+	if !ok {
+		return extents
+	}
+
+	blockExtents := c.blockExtents(block)
+	if blockExtents.IsEmpty() {
+		return extents
+	}
+
+	// the array is affine, so only its corner instances matter.
+	columns := []int{0, maxInt(insert.ColumnCount, 1) - 1}
+	rows := []int{0, maxInt(insert.RowCount, 1) - 1}
+	for _, column := range columns {
+		for _, row := range rows {
+			transform := insert.Transform(block.BasePoint, column, row)
+			for _, corner := range blockExtents.Corners() {
+				extents.Add(transform.Apply(corner))
+			}
+		}
+	}
+	return extents
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
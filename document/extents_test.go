@@ -0,0 +1,108 @@
+package document
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/sections"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func extentsTestDocument() DxfDocument {
+	visible := entities.BaseEntity{LayerName: "0", On: true, Visible: true}
+	frozen := entities.BaseEntity{LayerName: "FROZEN", On: true, Visible: true}
+	invisible := entities.BaseEntity{LayerName: "0", On: true, Visible: false}
+
+	return DxfDocument{
+		Tables: &sections.TablesSection{
+			Layers: sections.Table{
+				"0":      &sections.Layer{Name: "0", On: true},
+				"FROZEN": &sections.Layer{Name: "FROZEN", On: true, Frozen: true},
+			},
+		},
+		Entities: &sections.EntitiesSection{
+			Entities: entities.EntitySlice{
+				&entities.Line{BaseEntity: visible, End: core.Point{X: 1.0, Y: 1.0}},
+				&entities.Circle{BaseEntity: frozen, Center: core.Point{X: 10.0, Y: 10.0},
+					Radius: 1.0, ExtrusionDirection: core.Point{Z: 1.0}},
+				&entities.Point{BaseEntity: invisible, Location: core.Point{X: -5.0, Y: -5.0}},
+				&entities.Insert{BaseEntity: visible, BlockName: "B",
+					InsertionPoint: core.Point{X: 20.0, Y: 0.0},
+					ScaleFactorX:   2.0, ScaleFactorY: 2.0, ScaleFactorZ: 1.0,
+					RotationAngle: 90.0, ColumnCount: 1, RowCount: 1,
+					ExtrusionDirection: core.Point{Z: 1.0}},
+			},
+		},
+		Blocks: sections.BlocksSection{
+			"B": &sections.Block{
+				Name:      "B",
+				BasePoint: core.Point{X: 1.0},
+				Entities: entities.EntitySlice{
+					&entities.Line{BaseEntity: visible, Start: core.Point{X: 1.0},
+						End: core.Point{X: 2.0}},
+				},
+			},
+		},
+	}
+}
+
+func TestDxfDocumentExtents(t *testing.T) {
+	doc := extentsTestDocument()
+
+	testCases := []struct {
+		options  ExtentsOptions
+		min, max core.Point
+	}{
+		{ExtentsOptions{}, core.Point{X: 0.0, Y: 0.0}, core.Point{X: 20.0, Y: 1.0}},
+		{ExtentsOptions{IncludeInserts: true},
+			core.Point{X: 0.0, Y: 0.0}, core.Point{X: 20.0, Y: 2.0}},
+		{ExtentsOptions{IncludeInvisible: true},
+			core.Point{X: -5.0, Y: -5.0}, core.Point{X: 20.0, Y: 1.0}},
+		{ExtentsOptions{IncludeFrozenLayers: true},
+			core.Point{X: 0.0, Y: 0.0}, core.Point{X: 20.0, Y: 11.0}},
+	}
+
+	for _, test := range testCases {
+		min, max := doc.Extents(test.options)
+		assert.True(t, test.min.Equals(min), "Test case: %+v, min: %+v", test, min)
+		assert.True(t, test.max.Equals(max), "Test case: %+v, max: %+v", test, max)
+	}
+}
+
+func TestDxfDocumentExtentsInsertArray(t *testing.T) {
+	doc := extentsTestDocument()
+	insert := doc.Entities.Entities[3].(*entities.Insert)
+	insert.RotationAngle = 0.0
+	insert.ColumnCount = 3
+	insert.ColumnSpacing = 5.0
+	insert.RowCount = 2
+	insert.RowSpacing = 4.0
+
+	min, max := doc.Extents(ExtentsOptions{IncludeInserts: true})
+	assert.True(t, core.Point{X: 0.0, Y: 0.0}.Equals(min), "%+v", min)
+	assert.True(t, core.Point{X: 32.0, Y: 4.0}.Equals(max), "%+v", max)
+}
+
+func TestDxfDocumentExtentsEmpty(t *testing.T) {
+	doc, err := DxfDocumentFromStream(strings.NewReader(testWrongSection[:0]))
+	assert.Nil(t, err)
+
+	min, max := doc.Extents(ExtentsOptions{})
+	assert.True(t, core.Extents{Min: min, Max: max}.IsEmpty())
+}
+
+func TestDxfDocumentExtentsSelfReferencingBlock(t *testing.T) {
+	doc := extentsTestDocument()
+	block := doc.Blocks["B"]
+	block.Entities = append(block.Entities, &entities.Insert{
+		BaseEntity: entities.BaseEntity{On: true, Visible: true}, BlockName: "B",
+		ScaleFactorX: 1.0, ScaleFactorY: 1.0, ScaleFactorZ: 1.0,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	})
+
+	// the nested insert is resolved to its insertion point (block origin).
+	min, max := doc.Extents(ExtentsOptions{IncludeInserts: true})
+	assert.True(t, core.Point{X: 0.0, Y: -2.0}.Equals(min), "%+v", min)
+	assert.True(t, core.Point{X: 20.0, Y: 2.0}.Equals(max), "%+v", max)
+}
@@ -0,0 +1,83 @@
+package document
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rpaloschi/dxf-go/entities"
+)
+
+// PaperSpaceBlockName is the name of the Block of the active paper space
+// layout, the other layouts Blocks add a number to it.
+const PaperSpaceBlockName = "*Paper_Space"
+
+// Layout a paper space layout of the document.
+type Layout struct {
+	// Name is the layout tab name of its entities, or the BlockName for
+	// layouts without one (DXF R12).
+	Name      string
+	BlockName string
+	Entities  entities.EntitySlice
+}
+
+// Layouts returns the paper space layouts of the document, the active one
+// first. The entities of the active layout are the paper space entities of
+// the ENTITIES section, the others are stored in their own Blocks.
+func (doc DxfDocument) Layouts() []Layout {
+	var layouts []Layout
+
+	active := Layout{BlockName: PaperSpaceBlockName}
+	_, hasActiveBlock := doc.Blocks[PaperSpaceBlockName]
+	if doc.Entities != nil {
+		for _, entity := range doc.Entities.Entities {
+			if base := entities.EntityBase(entity); base != nil && base.Space == entities.PAPER {
+				active.Entities = append(active.Entities, entity)
+			}
+		}
+	}
+	if hasActiveBlock || len(active.Entities) > 0 {
+		layouts = append(layouts, withLayoutName(active))
+	}
+
+	var others []Layout
+	for name, block := range doc.Blocks {
+		if len(name) > len(PaperSpaceBlockName) &&
+			strings.EqualFold(name[:len(PaperSpaceBlockName)], PaperSpaceBlockName) {
+			others = append(others, withLayoutName(Layout{BlockName: name, Entities: block.Entities}))
+		}
+	}
+	// *Paper_Space2 goes before *Paper_Space10.
+	sort.Slice(others, func(i, j int) bool {
+		a, errA := strconv.Atoi(others[i].BlockName[len(PaperSpaceBlockName):])
+		b, errB := strconv.Atoi(others[j].BlockName[len(PaperSpaceBlockName):])
+		if errA == nil && errB == nil && a != b {
+			return a < b
+		}
+		return others[i].BlockName < others[j].BlockName
+	})
+
+	return append(layouts, others...)
+}
+
+// Layout returns the layout with the name, compared case-insensitively with
+// the layout and Block names.
+func (doc DxfDocument) Layout(name string) (Layout, bool) {
+	for _, layout := range doc.Layouts() {
+		if strings.EqualFold(layout.Name, name) || strings.EqualFold(layout.BlockName, name) {
+			return layout, true
+		}
+	}
+	return Layout{}, false
+}
+
+func withLayoutName(layout Layout) Layout {
+	layout.Name = layout.BlockName
+	for _, entity := range layout.Entities {
+		if base := entities.EntityBase(entity); base != nil && base.LayoutTabName != "" {
+			layout.Name = base.LayoutTabName
+			break
+		}
+	}
+	return layout
+}
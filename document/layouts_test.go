@@ -0,0 +1,49 @@
+package document
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/sections"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDxfDocumentLayouts(t *testing.T) {
+	paper := func(layout string) entities.BaseEntity {
+		return entities.BaseEntity{Space: entities.PAPER, LayoutTabName: layout, On: true, Visible: true}
+	}
+	model := &entities.Line{End: core.Point{X: 1.0}}
+	active := &entities.Line{BaseEntity: paper("Sheet A"), End: core.Point{Y: 1.0}}
+	second := &entities.Viewport{BaseEntity: paper("Sheet B"), ID: 1}
+	third := &entities.Viewport{BaseEntity: paper(""), ID: 1}
+
+	doc := DxfDocument{
+		Entities: &sections.EntitiesSection{Entities: entities.EntitySlice{model, active}},
+		Blocks: sections.BlocksSection{
+			"*Model_Space":   &sections.Block{Name: "*Model_Space"},
+			"*Paper_Space":   &sections.Block{Name: "*Paper_Space"},
+			"*Paper_Space10": &sections.Block{Name: "*Paper_Space10", Entities: entities.EntitySlice{third}},
+			"*Paper_Space2":  &sections.Block{Name: "*Paper_Space2", Entities: entities.EntitySlice{second}},
+		},
+	}
+
+	layouts := doc.Layouts()
+	assert.Equal(t, []Layout{
+		{Name: "Sheet A", BlockName: "*Paper_Space", Entities: entities.EntitySlice{active}},
+		{Name: "Sheet B", BlockName: "*Paper_Space2", Entities: entities.EntitySlice{second}},
+		{Name: "*Paper_Space10", BlockName: "*Paper_Space10", Entities: entities.EntitySlice{third}},
+	}, layouts)
+
+	layout, ok := doc.Layout("sheet b")
+	assert.True(t, ok)
+	assert.Equal(t, "*Paper_Space2", layout.BlockName)
+
+	layout, ok = doc.Layout("*PAPER_SPACE10")
+	assert.True(t, ok)
+	assert.Equal(t, "*Paper_Space10", layout.Name)
+
+	_, ok = doc.Layout("Missing")
+	assert.False(t, ok)
+
+	assert.Len(t, DxfDocument{}.Layouts(), 0)
+}
@@ -0,0 +1,80 @@
+package document
+
+import (
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/sections"
+)
+
+// insertDepth returns the deepest nesting of the blocks referenced by the
+// INSERTs of the DxfDocument, in its ENTITIES section and in its blocks, up
+// to max+1: the blocks inserting themselves are deeper than any max.
+func (doc DxfDocument) insertDepth(max int) int {
+	calculator := insertDepthCalculator{
+		blocks:    doc.Blocks,
+		max:       max,
+		depths:    make(map[string]int),
+		inserting: make(map[string]bool),
+	}
+
+	depth := 0
+	if doc.Entities != nil {
+		depth = calculator.entitiesDepth(doc.Entities.Entities, 0)
+	}
+	for _, block := range doc.Blocks {
+		if depth > max {
+			break
+		}
+		depth = maxInt(depth, calculator.blockDepth(block, 0))
+	}
+	return depth
+}
+
+type insertDepthCalculator struct {
+	blocks sections.BlocksSection
+	max    int
+	// depths has the depth of the blocks already visited.
+	depths map[string]int
+	// inserting has the blocks of the INSERTs being visited, to find the
+	// blocks inserting themselves.
+	inserting map[string]bool
+}
+
+// entitiesDepth returns the depth of the INSERTs of the entities, found at
+// the level of nesting, up to max+1.
+func (c *insertDepthCalculator) entitiesDepth(entityList entities.EntitySlice, level int) int {
+	depth := 0
+	for _, entity := range entityList {
+		insert, ok := entity.(*entities.Insert)
+		if !ok {
+			continue
+		}
+
+		depth = maxInt(depth, 1)
+		if block, ok := c.blocks[insert.BlockName]; ok {
+			depth = maxInt(depth, 1+c.blockDepth(block, level+1))
+		}
+		if depth > c.max {
+			return c.max + 1
+		}
+	}
+	return depth
+}
+
+// blockDepth returns the depth of the INSERTs of the block, found at the
+// level of nesting, up to max+1.
+func (c *insertDepthCalculator) blockDepth(block *sections.Block, level int) int {
+	if depth, ok := c.depths[block.Name]; ok {
+		return depth
+	}
+	if c.inserting[block.Name] || level > c.max {
+		return c.max + 1
+	}
+
+	c.inserting[block.Name] = true
+	depth := c.entitiesDepth(block.Entities, level)
+	delete(c.inserting, block.Name)
+	if depth <= c.max {
+		c.depths[block.Name] = depth
+	}
+	return depth
+}
@@ -0,0 +1,247 @@
+package document
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/sections"
+)
+
+// WriteReport describes how a document was downgraded to be written in an
+// older DXF version.
+type WriteReport struct {
+	Version string
+	// Converted has the entities written as another entity type, like the
+	// LWPOLYLINEs written as POLYLINEs before R14.
+	Converted entities.EntitySlice
+	// Omitted has the entities the version does not support.
+	Omitted entities.EntitySlice
+}
+
+// entityTagVersions has the first version knowing the group codes of the
+// entities not supported by R12.
+var entityTagVersions = map[int]string{
+	48:  "AC1012", // line type scale
+	60:  "AC1012", // visibility
+	370: "AC1015", // line weight
+	410: "AC1015", // layout tab name
+	420: "AC1018", // true color
+	430: "AC1018", // color name
+	440: "AC1018", // transparency
+	284: "AC1021", // shadow mode
+}
+
+// recordTagVersions has, by table type, the first version knowing the
+// group codes of the table records not supported by R12.
+var recordTagVersions = map[string]map[int]string{
+	"LAYER": {
+		290: "AC1015", // plotting flag
+		370: "AC1015", // line weight
+		420: "AC1018", // true color
+		430: "AC1018", // color name
+		440: "AC1018", // transparency
+	},
+}
+
+// r12EntityTypes has the entity types of R12.
+var r12EntityTypes = map[string]bool{
+	"3DFACE": true, "3DLINE": true, "ARC": true, "ATTDEF": true, "ATTRIB": true,
+	"CIRCLE": true, "DIMENSION": true, "INSERT": true, "LINE": true, "POINT": true,
+	"POLYLINE": true, "SEQEND": true, "SHAPE": true, "SOLID": true, "TEXT": true,
+	"TRACE": true, "VERTEX": true, "VIEWPORT": true,
+}
+
+// r12BlockNames has the R12 names of the model and paper space Blocks.
+var r12BlockNames = map[string]string{
+	ModelSpaceBlockName: "$MODEL_SPACE",
+	PaperSpaceBlockName: "$PAPER_SPACE",
+}
+
+// VersionTags returns the tags of the DxfDocument in the $ACADVER version,
+// from AC1009 (R12) to AC1032 (R2018). Older versions get the entities
+// converted to the types they know, LWPOLYLINEs become POLYLINEs before R14,
+// and the group codes they do not support dropped from each table record,
+// block and entity, like the handles, subclass markers and application
+// groups of R12. The entities without an equivalent, the CLASSES
+// and unknown sections before R13 and the THUMBNAILIMAGE before R2000 are
+// omitted. From R13 the VERTEX and SEQEND entities of the converted
+// LWPOLYLINEs get new handles, which the written $HANDSEED accounts for.
+// The document is not modified.
+func (doc DxfDocument) VersionTags(version string) (core.TagSlice, WriteReport, error) {
+	return doc.VersionTagsOptions(version, core.WriteOptions{})
+}
+
+// VersionTagsOptions returns the tags of the DxfDocument in the $ACADVER
+// version as VersionTags, logging the entities and table entries that
+// cannot be written to the Logger of the options.
+func (doc DxfDocument) VersionTagsOptions(version string, options core.WriteOptions) (core.TagSlice, WriteReport, error) {
+	report := WriteReport{Version: version}
+	if _, ok := core.AcadRelease(version); !ok {
+		return nil, report, fmt.Errorf("Unsupported DXF version: %v", version)
+	}
+
+	header := &sections.HeaderSection{Values: map[string]core.TagSlice{}}
+	if doc.Header != nil {
+		for key, value := range doc.Header.Values {
+			header.Values[key] = value
+		}
+	}
+	header.Values["$ACADVER"] = core.TagSlice{core.NewStringTag(1, version)}
+	if version < "AC1012" {
+		delete(header.Values, "$HANDSEED")
+	}
+
+	// the handles of the converted entities are allocated from a copy of
+	// the document with the copied header.
+	handles := doc
+	handles.Header = header
+	nextHandle := handles.NextHandle
+	if version < "AC1012" {
+		nextHandle = nil
+	}
+
+	downgraded := DxfDocument{Tables: doc.Tables}
+	if version < "AC1012" {
+		// the CLASSES, the unknown sections and the BLOCK_RECORD table are
+		// newer than R12.
+		if doc.Tables != nil && doc.Tables.Others["BLOCK_RECORD"] != nil {
+			tables := *doc.Tables
+			tables.Others = make(map[string]core.TagSlice)
+			for tableType, tags := range doc.Tables.Others {
+				if tableType != "BLOCK_RECORD" {
+					tables.Others[tableType] = tags
+				}
+			}
+			downgraded.Tables = &tables
+		}
+	} else {
+		downgraded.Classes = doc.Classes
+		downgraded.UnknownSections = doc.UnknownSections
+	}
+	if version >= "AC1015" {
+		downgraded.Thumbnail = doc.Thumbnail
+	}
+	if doc.Entities != nil {
+		downgraded.Entities = &sections.EntitiesSection{
+			Entities: downgradeEntities(doc.Entities.Entities, version, nextHandle, &report)}
+	}
+	if doc.Blocks != nil {
+		downgraded.Blocks = make(sections.BlocksSection, len(doc.Blocks))
+		for name, block := range doc.Blocks {
+			copied := *block
+			copied.Entities = downgradeEntities(block.Entities, version, nextHandle, &report)
+			if r12Name, ok := r12BlockNames[name]; ok && version < "AC1012" {
+				copied.Name = r12Name
+			}
+			downgraded.Blocks[copied.Name] = &copied
+		}
+	}
+
+	return append(header.Tags(), versionTags(downgraded.TagsOptions(options), version)...), report, nil
+}
+
+// versionTags returns the tags of the sections without the group codes the
+// version does not support, element by element: the ones of the entities
+// for the entities and blocks, the ones of its table for a table record.
+// In R12 the handles, the subclass markers and the application groups of
+// all the elements are dropped too, but not their XDATA.
+func versionTags(tags core.TagSlice, version string) core.TagSlice {
+	result := make(core.TagSlice, 0, len(tags))
+	section := ""
+	var versions map[int]string
+	inAppData := false
+	for i, tag := range tags {
+		if tag.Code == 0 {
+			name := tag.Value.ToString()
+			switch {
+			case name == "SECTION" && i+1 < len(tags):
+				section = tags[i+1].Value.ToString()
+				versions = nil
+			case section == "TABLES":
+				versions = recordTagVersions[name]
+			case section == "BLOCKS" || section == "ENTITIES":
+				versions = entityTagVersions
+			default:
+				versions = nil
+			}
+			inAppData = false
+			result = append(result, tag)
+			continue
+		}
+
+		if tag.Code < 1000 && version < "AC1012" {
+			if tag.Code == 102 {
+				inAppData = tag.Value.ToString() != "}"
+				continue
+			}
+			if inAppData || tag.Code == 5 || tag.Code == 100 || tag.Value.Kind() == core.HandleKind {
+				continue
+			}
+		}
+		if minimum, ok := versions[tag.Code]; ok && version < minimum {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
+
+// downgradeEntities returns the entities of the list the version supports,
+// converting or omitting the others: before R13 only the entity types of
+// R12 are kept. The entities converted for the versions with handles get
+// the new ones from nextHandle, nil for the versions without them.
+func downgradeEntities(list entities.EntitySlice, version string, nextHandle func() string,
+	report *WriteReport) entities.EntitySlice {
+	result := make(entities.EntitySlice, 0, len(list))
+	for _, entity := range list {
+		if lwPolyline, ok := entity.(*entities.LWPolyline); ok && version < "AC1014" {
+			polyline := lwPolyline.ToPolyline()
+			polyline.Handle = lwPolyline.Handle
+			if nextHandle != nil {
+				if polyline.Handle == "" {
+					polyline.Handle = nextHandle()
+				}
+				for _, vertex := range polyline.Vertices {
+					vertex.Handle = nextHandle()
+					vertex.Owner = polyline.Handle
+				}
+				polyline.SeqEndHandle = nextHandle()
+			}
+			report.Converted = append(report.Converted, entity)
+			result = append(result, polyline)
+			continue
+		}
+		if version < "AC1012" && !r12EntityTypes[entityType(entity)] {
+			report.Omitted = append(report.Omitted, entity)
+			continue
+		}
+		result = append(result, entity)
+	}
+	return result
+}
+
+// entityType returns the type of the entity, the value of its (0, type)
+// tag, or "" if it cannot be written.
+func entityType(entity entities.Entity) string {
+	if unknown, ok := entity.(*entities.Unknown); ok {
+		return unknown.Type
+	}
+	if writable, ok := entity.(entities.Writable); ok {
+		if tags := writable.Tags(); len(tags) > 0 {
+			return tags[0].Value.ToString()
+		}
+	}
+	return ""
+}
+
+// DxfDocumentToStreamVersion writes the DxfDocument to the stream as a DXF
+// text file of the $ACADVER version, downgrading it as VersionTags does.
+func DxfDocumentToStreamVersion(stream io.Writer, doc *DxfDocument, version string) (WriteReport, error) {
+	tags, report, err := doc.VersionTags(version)
+	if err != nil {
+		return report, err
+	}
+	return report, core.WriteTags(stream, tags)
+}
@@ -0,0 +1,205 @@
+package document
+
+import (
+	"bytes"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func versionTestDocument() (*DxfDocument, *entities.LWPolyline, *entities.Ellipse) {
+	doc := New("AC1015")
+	model := doc.ModelSpace()
+
+	line := model.AddLine(core.Point{}, core.Point{X: 1.0})
+	line.TrueColor = core.TrueColor(0xff0000)
+	polyline := model.AddLWPolyline(core.PointSlice{{}, {X: 1.0}, {X: 1.0, Y: 1.0}}, true)
+	polyline.Points[1].Bulge = 0.5
+	ellipse := &entities.Ellipse{BaseEntity: entities.BaseEntity{On: true, Visible: true},
+		Center: core.Point{X: 2.0}, MajorAxisEnd: core.Point{X: 1.0},
+		MinorToMajorAxisRatio: 0.5, EndParameter: 6.283185307179586, ExtrusionDirection: core.Point{Z: 1.0}}
+	model.Add(ellipse)
+	return doc, polyline, ellipse
+}
+
+func codes(tags core.TagSlice) map[int]bool {
+	found := map[int]bool{}
+	for _, tag := range tags {
+		found[tag.Code] = true
+	}
+	return found
+}
+
+func TestVersionTagsR12(t *testing.T) {
+	doc, polyline, ellipse := versionTestDocument()
+
+	var buffer bytes.Buffer
+	report, err := DxfDocumentToStreamVersion(&buffer, doc, "AC1009")
+	assert.Nil(t, err)
+	assert.Equal(t, "AC1009", report.Version)
+	assert.Equal(t, entities.EntitySlice{polyline}, report.Converted)
+	assert.Equal(t, entities.EntitySlice{ellipse}, report.Omitted)
+
+	tags := core.TagSlice(core.AllTags(core.Tagger(bytes.NewReader(buffer.Bytes()))))
+	found := codes(tags)
+	for _, code := range []int{5, 100, 330, 420} {
+		assert.False(t, found[code], "group code %v", code)
+	}
+
+	written, err := DxfDocumentFromStream(&buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, "AC1009", written.Header.Get("$ACADVER")[0].Value.ToString())
+	assert.Len(t, written.Header.Get("$HANDSEED"), 0)
+	assert.Contains(t, written.Blocks, "$MODEL_SPACE")
+	assert.Contains(t, written.Blocks, "$PAPER_SPACE")
+
+	assert.Len(t, written.Entities.Entities, 2)
+	converted, ok := written.Entities.Entities[1].(*entities.Polyline)
+	assert.True(t, ok)
+	assert.True(t, converted.Closed)
+	assert.Len(t, converted.Vertices, 3)
+	assert.Equal(t, 0.5, converted.Vertices[1].Bulge)
+
+	// the document is not modified.
+	assert.Equal(t, "AC1015", doc.Header.Get("$ACADVER")[0].Value.ToString())
+	assert.Len(t, doc.Entities.Entities, 3)
+	assert.Contains(t, doc.Blocks, ModelSpaceBlockName)
+}
+
+func TestVersionTags(t *testing.T) {
+	doc, _, _ := versionTestDocument()
+
+	tags, report, err := doc.VersionTags("AC1014")
+	assert.Nil(t, err)
+	assert.Len(t, report.Converted, 0)
+	assert.Len(t, report.Omitted, 0)
+	found := codes(tags)
+	assert.True(t, found[100])
+	assert.True(t, found[5])
+	assert.False(t, found[420])
+
+	tags, report, err = doc.VersionTags("AC1012")
+	assert.Nil(t, err)
+	assert.Len(t, report.Converted, 1)
+	assert.Len(t, report.Omitted, 0)
+	// the converted VERTEX and SEQEND entities have handles and owners.
+	assertEntityHandles(t, tags)
+	seed := doc.Header.Get("$HANDSEED")[0].Value.ToString()
+	// the $HANDSEED written is after the new handles, the document's is not
+	// modified.
+	assert.NotEqual(t, seed, handSeed(tags))
+	assert.Equal(t, seed, doc.Header.Get("$HANDSEED")[0].Value.ToString())
+	written, err := DxfDocumentFromStream(bytes.NewReader(writeTestTags(t, tags)))
+	assert.Nil(t, err)
+	converted := written.Entities.Entities[1].(*entities.Polyline)
+	assert.Equal(t, converted.Handle, converted.Vertices[0].Owner)
+	assert.NotEqual(t, "", converted.SeqEndHandle)
+
+	tags, report, err = doc.VersionTags("AC1032")
+	assert.Nil(t, err)
+	assert.Len(t, report.Converted, 0)
+	assert.True(t, codes(tags)[420])
+	written, err = DxfDocumentFromStream(bytes.NewReader(writeTestTags(t, tags)))
+	assert.Nil(t, err)
+	assert.Equal(t, "AC1032", written.Header.Get("$ACADVER")[0].Value.ToString())
+	assert.True(t, doc.Entities.Equals(written.Entities))
+
+	_, _, err = doc.VersionTags("AC1016")
+	assert.NotNil(t, err)
+}
+
+// assertEntityHandles checks that each entity of the ENTITIES section of the
+// tags has a handle and an owner.
+func assertEntityHandles(t *testing.T, tags core.TagSlice) {
+	start := 0
+	for start < len(tags)-1 && tags[start+1].Value.ToString() != "ENTITIES" {
+		start++
+	}
+	var entity *core.Tag
+	handle, owner := false, false
+	for _, tag := range tags[start+2:] {
+		if tag.Code != 0 {
+			handle = handle || tag.Code == 5 && tag.Value.ToString() != ""
+			owner = owner || tag.Code == 330 && tag.Value.ToString() != ""
+			continue
+		}
+		if entity != nil {
+			assert.True(t, handle, "%v without a handle", entity.Value.ToString())
+			assert.True(t, owner, "%v without an owner", entity.Value.ToString())
+		}
+		if tag.Value.ToString() == "ENDSEC" {
+			return
+		}
+		entity, handle, owner = tag, false, false
+	}
+	t.Error("No ENTITIES section")
+}
+
+// handSeed returns the value of the $HANDSEED of the tags.
+func handSeed(tags core.TagSlice) string {
+	for i, tag := range tags[:len(tags)-1] {
+		if tag.Code == 9 && tag.Value.ToString() == "$HANDSEED" {
+			return tags[i+1].Value.ToString()
+		}
+	}
+	return ""
+}
+
+func TestVersionTagsR12ByElement(t *testing.T) {
+	doc := New("AC1015")
+	model := doc.ModelSpace()
+	line := model.AddLine(core.Point{}, core.Point{X: 1.0})
+	line.LineTypeScale = 2.0
+	mtextTags := core.TagSlice{
+		core.NewStringTag(0, "MTEXT"),
+		core.NewStringTag(100, "AcDbEntity"),
+		core.NewStringTag(8, "0"),
+		core.NewStringTag(100, "AcDbMText"),
+	}
+	mtextTags = append(mtextTags, core.NewPointTags(10, core.Point{})...)
+	mtext, err := entities.NewUnknown(append(mtextTags, core.NewFloatTag(40, 1.0), core.NewStringTag(1, "NOTES")))
+	assert.Nil(t, err)
+	model.Add(mtext)
+	doc.Tables.Others = map[string]core.TagSlice{"DIMSTYLE": {
+		core.NewStringTag(0, "TABLE"),
+		core.NewStringTag(2, "DIMSTYLE"),
+		core.NewHandleTag(5, "A"),
+		core.NewIntegerTag(70, 1),
+		core.NewStringTag(0, "DIMSTYLE"),
+		core.NewHandleTag(105, "B"),
+		core.NewStringTag(100, "AcDbSymbolTableRecord"),
+		core.NewStringTag(2, "STANDARD"),
+		core.NewIntegerTag(70, 0),
+		core.NewFloatTag(47, 0.1),
+		core.NewFloatTag(48, 0.2),
+		core.NewStringTag(0, "ENDTAB"),
+	}}
+
+	tags, report, err := doc.VersionTags("AC1009")
+	assert.Nil(t, err)
+	assert.Equal(t, entities.EntitySlice{mtext}, report.Omitted)
+	found := codes(tags)
+	for _, code := range []int{5, 100, 105, 330} {
+		assert.False(t, found[code], "group code %v", code)
+	}
+
+	written, err := DxfDocumentFromStream(bytes.NewReader(writeTestTags(t, tags)))
+	assert.Nil(t, err)
+	assert.Len(t, written.Entities.Entities, 1)
+	// the line type scale of the entities is newer than R12, the DIMTM of
+	// the dimension styles is not.
+	assert.Equal(t, 0.0, written.Entities.Entities[0].(*entities.Line).LineTypeScale)
+	dimStyle := written.Tables.Others["DIMSTYLE"]
+	assert.Equal(t, 0.2, dimStyle[dimStyle.TagIndex(48, 0, len(dimStyle))].Value.Value())
+
+	_, report, err = doc.VersionTags("AC1012")
+	assert.Nil(t, err)
+	assert.Len(t, report.Omitted, 0)
+}
+
+func writeTestTags(t *testing.T, tags core.TagSlice) []byte {
+	var buffer bytes.Buffer
+	assert.Nil(t, core.WriteTags(&buffer, tags))
+	return buffer.Bytes()
+}
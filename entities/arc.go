@@ -53,3 +53,31 @@ func NewArc(tags core.TagSlice) (*Arc, error) {
 	err := arc.Parse(tags)
 	return arc, err
 }
+
+// BoundingBox returns the minimum and maximum corners of the Arc extents.
+func (a Arc) BoundingBox() (min, max core.Point) {
+	start, sweep := degreesSweep(a.StartAngle, a.EndAngle)
+	extents := ocsArcExtents(a.Center, a.Radius, a.ExtrusionDirection, start, sweep)
+	return extents.Min, extents.Max
+}
+
+// Flatten approximates the Arc by a polyline within the chord tolerance.
+func (a Arc) Flatten(tolerance float64) core.PointSlice {
+	start, sweep := degreesSweep(a.StartAngle, a.EndAngle)
+	return ocsArcPoints(a.Center, a.Radius, a.ExtrusionDirection, start, sweep,
+		flattenTolerance(tolerance))
+}
+
+// Tags returns the tags of the Arc.
+func (a Arc) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbCircle")}
+	tags = append(tags, optionalFloatTag(39, a.Thickness)...)
+	tags = append(tags, core.NewPointTags(10, a.Center)...)
+	tags = append(tags, core.NewFloatTag(40, a.Radius))
+	tags = append(tags, extrusionTags(a.ExtrusionDirection)...)
+	tags = append(tags,
+		core.NewStringTag(100, "AcDbArc"),
+		core.NewFloatTag(50, a.StartAngle),
+		core.NewFloatTag(51, a.EndAngle))
+	return a.entityTags("ARC", tags)
+}
@@ -3,6 +3,7 @@ package entities
 import (
 	"github.com/rpaloschi/dxf-go/core"
 	"github.com/stretchr/testify/suite"
+	"math"
 	"strings"
 	"testing"
 )
@@ -95,6 +96,60 @@ func (suite *ArcTestSuite) TestArcNotEqualToDifferentType() {
 	suite.False(Arc{}.Equals(core.NewIntegerValue(0)))
 }
 
+func (suite *ArcTestSuite) TestArcBoundingBox() {
+	testCases := []struct {
+		arc      Arc
+		min, max core.Point
+	}{
+		{
+			Arc{Radius: 1.0, StartAngle: 0.0, EndAngle: 90.0,
+				ExtrusionDirection: core.Point{Z: 1.0}},
+			core.Point{X: 0.0, Y: 0.0}, core.Point{X: 1.0, Y: 1.0},
+		},
+		{
+			Arc{Center: core.Point{X: 1.0, Y: 1.0}, Radius: 2.0, StartAngle: 45.0,
+				EndAngle: 225.0, ExtrusionDirection: core.Point{Z: 1.0}},
+			core.Point{X: -1.0, Y: 1.0 - math.Sqrt2},
+			core.Point{X: 1.0 + math.Sqrt2, Y: 3.0},
+		},
+		{
+			Arc{Radius: 1.0, StartAngle: 350.0, EndAngle: 10.0,
+				ExtrusionDirection: core.Point{Z: 1.0}},
+			core.Point{X: math.Cos(10.0 * math.Pi / 180.0), Y: -math.Sin(10.0 * math.Pi / 180.0)},
+			core.Point{X: 1.0, Y: math.Sin(10.0 * math.Pi / 180.0)},
+		},
+	}
+
+	for _, test := range testCases {
+		min, max := test.arc.BoundingBox()
+		suite.True(test.min.Equals(min), "Test case: %+v, min: %+v", test, min)
+		suite.True(test.max.Equals(max), "Test case: %+v, max: %+v", test, max)
+	}
+}
+
+func (suite *ArcTestSuite) TestArcFlatten() {
+	arc := Arc{
+		Center:             core.Point{X: 1.0, Y: 1.0, Z: 2.0},
+		Radius:             5.0,
+		StartAngle:         270.0,
+		EndAngle:           90.0,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+
+	for _, tolerance := range []float64{0.5, 0.01, 0.0} {
+		points := arc.Flatten(tolerance)
+		assertArcPoints(suite.T(), points, arc.Center, arc.Radius, flattenTolerance(tolerance))
+		suite.True(core.Point{X: 1.0, Y: -4.0, Z: 2.0}.Equals(points[0]), "%+v", points[0])
+		suite.True(core.Point{X: 1.0, Y: 6.0, Z: 2.0}.Equals(points[len(points)-1]))
+		// passes by the right side.
+		maxX := 0.0
+		for _, point := range points {
+			maxX = math.Max(maxX, point.X)
+		}
+		suite.InDelta(6.0, maxX, flattenTolerance(tolerance))
+	}
+}
+
 func TestArcTestSuite(t *testing.T) {
 	suite.Run(t, new(ArcTestSuite))
 }
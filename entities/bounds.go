@@ -0,0 +1,176 @@
+package entities
+
+import (
+	"math"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// pointsExtents returns the Extents containing all the points.
+func pointsExtents(points ...core.Point) core.Extents {
+	extents := core.NewExtents()
+	for _, point := range points {
+		extents.Add(point)
+	}
+	return extents
+}
+
+// angleInSweep returns true if the angle (radians) is inside the counter clockwise
+// sweep that starts at start and has length sweep (radians).
+func angleInSweep(angle float64, start float64, sweep float64) bool {
+	if sweep >= 2*math.Pi {
+		return true
+	}
+	delta := math.Mod(angle-start, 2*math.Pi)
+	if delta < 0 {
+		delta += 2 * math.Pi
+	}
+	return delta <= sweep
+}
+
+// ellipticalArcExtents returns the exact extents of the curve
+// center + cos(t)*u + sin(t)*v for t in [start, start+sweep] (radians).
+// It covers circular arcs (u and v perpendicular with the same length) and
+// elliptical arcs in any 3D plane.
+func ellipticalArcExtents(center, u, v core.Point, start, sweep float64) core.Extents {
+	pointAt := func(t float64) core.Point {
+		return center.Add(u.Scale(math.Cos(t))).Add(v.Scale(math.Sin(t)))
+	}
+
+	extents := pointsExtents(pointAt(start), pointAt(start+sweep))
+	for _, axis := range [][2]float64{{u.X, v.X}, {u.Y, v.Y}, {u.Z, v.Z}} {
+		if axis[0] == 0.0 && axis[1] == 0.0 {
+			continue
+		}
+		// the derivative of the coordinate is zero on those angles.
+		extreme := math.Atan2(axis[1], axis[0])
+		for _, t := range []float64{extreme, extreme + math.Pi} {
+			if angleInSweep(t, start, sweep) {
+				extents.Add(pointAt(t))
+			}
+		}
+	}
+	return extents
+}
+
+// ocsArcExtents returns the extents of a circular arc defined in the OCS of
+// extrusion. Angles are in radians and the arc runs counter clockwise.
+func ocsArcExtents(center core.Point, radius float64, extrusion core.Point,
+	start float64, sweep float64) core.Extents {
+
+	xAxis, yAxis, _ := core.OCSAxes(extrusion)
+	return ellipticalArcExtents(core.OCSToWCS(center, extrusion),
+		xAxis.Scale(radius), yAxis.Scale(radius), start, sweep)
+}
+
+// degreesSweep converts an arc defined by start and end angles in degrees to
+// a start angle and a positive counter clockwise sweep in radians.
+func degreesSweep(startAngle float64, endAngle float64) (float64, float64) {
+	start := startAngle * math.Pi / 180.0
+	end := endAngle * math.Pi / 180.0
+	for end <= start {
+		end += 2 * math.Pi
+	}
+	return start, end - start
+}
+
+// bulgeArc computes the arc defined by a bulge between the points p1 and p2
+// (2D, in OCS). It returns the center, radius, start angle and the signed sweep
+// (radians), negative sweeps run clockwise. ok is false if the segment is
+// a straight line.
+func bulgeArc(p1, p2 core.Point, bulge float64) (center core.Point, radius float64,
+	start float64, sweep float64, ok bool) {
+
+	chord := core.Point{X: p2.X - p1.X, Y: p2.Y - p1.Y}
+	length := chord.Length()
+	if core.FloatEquals(bulge, 0.0) || core.FloatEquals(length, 0.0) {
+		return core.Point{}, 0.0, 0.0, 0.0, false
+	}
+
+	offset := (length / 2.0) * (1.0 - bulge*bulge) / (2.0 * bulge)
+	normal := core.Point{X: -chord.Y / length, Y: chord.X / length}
+	center = core.Point{
+		X: (p1.X+p2.X)/2.0 + normal.X*offset,
+		Y: (p1.Y+p2.Y)/2.0 + normal.Y*offset,
+		Z: p1.Z,
+	}
+	radius = length * (1.0 + bulge*bulge) / (4.0 * math.Abs(bulge))
+	start = math.Atan2(p1.Y-center.Y, p1.X-center.X)
+	sweep = 4.0 * math.Atan(bulge)
+	return center, radius, start, sweep, true
+}
+
+// bulgeSegmentExtents returns the extents of the segment from p1 to p2 (OCS)
+// with the given bulge.
+func bulgeSegmentExtents(p1, p2 core.Point, bulge float64, extrusion core.Point) core.Extents {
+	center, radius, start, sweep, ok := bulgeArc(p1, p2, bulge)
+	if !ok {
+		return pointsExtents(core.OCSToWCS(p1, extrusion), core.OCSToWCS(p2, extrusion))
+	}
+	if sweep < 0 {
+		start, sweep = start+sweep, -sweep
+	}
+	return ocsArcExtents(center, radius, extrusion, start, sweep)
+}
+
+// curveExtents returns the extents of a parametric curve in [start, end].
+// The curve is sampled on the parameters and every extreme sample is refined
+// with a golden section search, so the result is exact for smooth curves as
+// long as the samples are dense enough to isolate the extremes.
+func curveExtents(pointAt func(float64) core.Point, parameters []float64) core.Extents {
+	extents := core.NewExtents()
+	if len(parameters) == 0 {
+		return extents
+	}
+
+	points := make(core.PointSlice, len(parameters))
+	for i, t := range parameters {
+		points[i] = pointAt(t)
+		extents.Add(points[i])
+	}
+
+	coordinates := []func(core.Point) float64{
+		func(p core.Point) float64 { return p.X },
+		func(p core.Point) float64 { return p.Y },
+		func(p core.Point) float64 { return p.Z },
+	}
+	for _, coordinate := range coordinates {
+		for _, sign := range []float64{1.0, -1.0} {
+			value := func(t float64) float64 { return sign * coordinate(pointAt(t)) }
+
+			best := 0
+			for i := range points {
+				if sign*coordinate(points[i]) > sign*coordinate(points[best]) {
+					best = i
+				}
+			}
+			if best == 0 || best == len(points)-1 {
+				continue
+			}
+			t := goldenSectionMax(value, parameters[best-1], parameters[best+1])
+			extents.Add(pointAt(t))
+		}
+	}
+	return extents
+}
+
+// goldenSectionMax finds the parameter that maximizes f in [a, b], f is
+// expected to be unimodal in the interval.
+func goldenSectionMax(f func(float64) float64, a float64, b float64) float64 {
+	ratio := (math.Sqrt(5.0) - 1.0) / 2.0
+	c := b - ratio*(b-a)
+	d := a + ratio*(b-a)
+	fc, fd := f(c), f(d)
+	for i := 0; i < 60 && b-a > 1e-12; i++ {
+		if fc > fd {
+			b, d, fd = d, c, fc
+			c = b - ratio*(b-a)
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + ratio*(b-a)
+			fd = f(d)
+		}
+	}
+	return (a + b) / 2.0
+}
@@ -1,6 +1,10 @@
 package entities
 
-import "github.com/rpaloschi/dxf-go/core"
+import (
+	"math"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
 
 // Circle Entity representation
 type Circle struct {
@@ -46,3 +50,28 @@ func NewCircle(tags core.TagSlice) (*Circle, error) {
 	err := circle.Parse(tags)
 	return circle, err
 }
+
+// BoundingBox returns the minimum and maximum corners of the Circle extents.
+func (c Circle) BoundingBox() (min, max core.Point) {
+	extents := ocsArcExtents(c.Center, c.Radius, c.ExtrusionDirection, 0.0, 2*math.Pi)
+	return extents.Min, extents.Max
+}
+
+// Flatten approximates the Circle by a closed polyline within the chord
+// tolerance.
+func (c Circle) Flatten(tolerance float64) core.PointSlice {
+	points := ocsArcPoints(c.Center, c.Radius, c.ExtrusionDirection, 0.0, 2*math.Pi,
+		math.Min(flattenTolerance(tolerance), c.Radius/2.0))
+	points[len(points)-1] = points[0]
+	return points
+}
+
+// Tags returns the tags of the Circle.
+func (c Circle) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbCircle")}
+	tags = append(tags, optionalFloatTag(39, c.Thickness)...)
+	tags = append(tags, core.NewPointTags(10, c.Center)...)
+	tags = append(tags, core.NewFloatTag(40, c.Radius))
+	tags = append(tags, extrusionTags(c.ExtrusionDirection)...)
+	return c.entityTags("CIRCLE", tags)
+}
@@ -89,6 +89,39 @@ func (suite *CircleTestSuite) TestCircleNotEqualToDifferentType() {
 	suite.False(Circle{}.Equals(core.NewIntegerValue(0)))
 }
 
+func (suite *CircleTestSuite) TestCircleBoundingBox() {
+	circle := Circle{
+		Center:             core.Point{X: 1.0, Y: 2.0, Z: 3.0},
+		Radius:             2.0,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+	min, max := circle.BoundingBox()
+	suite.True(core.Point{X: -1.0, Y: 0.0, Z: 3.0}.Equals(min))
+	suite.True(core.Point{X: 3.0, Y: 4.0, Z: 3.0}.Equals(max))
+
+	// mirrored OCS: the center is converted to WCS.
+	circle.ExtrusionDirection = core.Point{Z: -1.0}
+	min, max = circle.BoundingBox()
+	suite.True(core.Point{X: -3.0, Y: 0.0, Z: -3.0}.Equals(min))
+	suite.True(core.Point{X: 1.0, Y: 4.0, Z: -3.0}.Equals(max))
+}
+
+func (suite *CircleTestSuite) TestCircleFlatten() {
+	circle := Circle{
+		Center:             core.Point{X: 1.0, Y: 2.0, Z: 3.0},
+		Radius:             2.0,
+		ExtrusionDirection: core.Point{Z: -1.0},
+	}
+	center := core.Point{X: -1.0, Y: 2.0, Z: -3.0}
+
+	points := circle.Flatten(0.001)
+	assertArcPoints(suite.T(), points, center, circle.Radius, 0.001)
+	suite.True(points[0].Equals(points[len(points)-1]))
+
+	// a huge tolerance still gives a polygon.
+	suite.Len(circle.Flatten(100.0), 4)
+}
+
 func TestCircleTestSuite(t *testing.T) {
 	suite.Run(t, new(CircleTestSuite))
 }
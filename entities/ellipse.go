@@ -1,6 +1,10 @@
 package entities
 
-import "github.com/rpaloschi/dxf-go/core"
+import (
+	"math"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
 
 // Ellipse Entity representation
 type Ellipse struct {
@@ -56,3 +60,54 @@ func NewEllipse(tags core.TagSlice) (*Ellipse, error) {
 	err := ellipse.Parse(tags)
 	return ellipse, err
 }
+
+// BoundingBox returns the minimum and maximum corners of the Ellipse extents.
+// Center and MajorAxisEnd are in WCS, the minor axis is perpendicular to both
+// the major axis and the ExtrusionDirection.
+func (e Ellipse) BoundingBox() (min, max core.Point) {
+	minorAxis, sweep := e.minorAxisAndSweep()
+	extents := ellipticalArcExtents(e.Center, e.MajorAxisEnd, minorAxis,
+		e.StartParameter, sweep)
+	return extents.Min, extents.Max
+}
+
+// Flatten approximates the Ellipse, from StartParameter to EndParameter, by a
+// polyline within the chord tolerance.
+func (e Ellipse) Flatten(tolerance float64) core.PointSlice {
+	minorAxis, sweep := e.minorAxisAndSweep()
+	// the chords of an ellipse deviate less than the ones of the circle on
+	// its major axis.
+	segments := arcSegmentCount(e.MajorAxisEnd.Length(), sweep, flattenTolerance(tolerance))
+	points := ellipticalArcPoints(e.Center, e.MajorAxisEnd, minorAxis,
+		e.StartParameter, sweep, segments)
+	if sweep >= 2*math.Pi {
+		points[len(points)-1] = points[0]
+	}
+	return points
+}
+
+// minorAxisAndSweep returns the minor axis vector, perpendicular to both the
+// major axis and the ExtrusionDirection, and the parameter sweep (radians).
+func (e Ellipse) minorAxisAndSweep() (core.Point, float64) {
+	minorAxis := e.ExtrusionDirection.Normalize().Cross(e.MajorAxisEnd).
+		Scale(e.MinorToMajorAxisRatio)
+
+	sweep := e.EndParameter - e.StartParameter
+	for sweep <= 0 {
+		sweep += 2 * math.Pi
+	}
+	return minorAxis, math.Min(sweep, 2*math.Pi)
+}
+
+// Tags returns the tags of the Ellipse.
+func (e Ellipse) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbEllipse")}
+	tags = append(tags, core.NewPointTags(10, e.Center)...)
+	tags = append(tags, core.NewPointTags(11, e.MajorAxisEnd)...)
+	tags = append(tags, extrusionTags(e.ExtrusionDirection)...)
+	tags = append(tags,
+		core.NewFloatTag(40, e.MinorToMajorAxisRatio),
+		core.NewFloatTag(41, e.StartParameter),
+		core.NewFloatTag(42, e.EndParameter))
+	return e.entityTags("ELLIPSE", tags)
+}
@@ -3,6 +3,7 @@ package entities
 import (
 	"github.com/rpaloschi/dxf-go/core"
 	"github.com/stretchr/testify/suite"
+	"math"
 	"strings"
 	"testing"
 )
@@ -97,6 +98,49 @@ func (suite *EllipseTestSuite) TestEllipseNotEqualToDifferentType() {
 	suite.False(Ellipse{}.Equals(core.NewIntegerValue(0)))
 }
 
+func (suite *EllipseTestSuite) TestEllipseBoundingBox() {
+	ellipse := Ellipse{
+		MajorAxisEnd:          core.Point{X: 2.0},
+		MinorToMajorAxisRatio: 0.5,
+		StartParameter:        0.0,
+		EndParameter:          2 * math.Pi,
+		ExtrusionDirection:    core.Point{Z: 1.0},
+	}
+	min, max := ellipse.BoundingBox()
+	suite.True(core.Point{X: -2.0, Y: -1.0}.Equals(min))
+	suite.True(core.Point{X: 2.0, Y: 1.0}.Equals(max))
+
+	ellipse.EndParameter = math.Pi
+	min, max = ellipse.BoundingBox()
+	suite.True(core.Point{X: -2.0, Y: 0.0}.Equals(min))
+	suite.True(core.Point{X: 2.0, Y: 1.0}.Equals(max))
+}
+
+func (suite *EllipseTestSuite) TestEllipseFlatten() {
+	ellipse := Ellipse{
+		Center:                core.Point{X: 1.0, Y: 1.0},
+		MajorAxisEnd:          core.Point{X: 0.0, Y: 4.0},
+		MinorToMajorAxisRatio: 0.5,
+		StartParameter:        0.0,
+		EndParameter:          math.Pi,
+		ExtrusionDirection:    core.Point{Z: 1.0},
+	}
+
+	points := ellipse.Flatten(0.01)
+	suite.True(core.Point{X: 1.0, Y: 5.0}.Equals(points[0]), "%+v", points[0])
+	suite.True(core.Point{X: 1.0, Y: -3.0}.Equals(points[len(points)-1]))
+	for _, point := range points {
+		x, y := (point.X-1.0)/2.0, (point.Y-1.0)/4.0
+		suite.InDelta(1.0, x*x+y*y, 1e-9)
+		// the minor axis points to -X (Z cross Y)
+		suite.True(point.X <= 1.0+1e-9)
+	}
+
+	ellipse.EndParameter = 2 * math.Pi
+	points = ellipse.Flatten(0.01)
+	suite.True(points[0].Equals(points[len(points)-1]))
+}
+
 func TestEllipseTestSuite(t *testing.T) {
 	suite.Run(t, new(EllipseTestSuite))
 }
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"reflect"
+
 	"github.com/rpaloschi/dxf-go/core"
 )
 
@@ -10,6 +12,7 @@ type Entity interface {
 	IsSeqEnd() bool
 	HasNestedEntities() bool
 	AddNestedEntities(entities EntitySlice)
+	BoundingBox() (min, max core.Point)
 }
 
 // RegularEntity most of the Entities will return the same values for
@@ -89,6 +92,12 @@ type BaseEntity struct {
 	ColorName     string
 	Transparency  int
 	ShadowMode    ShadowMode
+	// XData holds the extended data by application name: the tags after
+	// each 1001 group. It is nil for entities without XDATA.
+	XData map[string]core.TagSlice
+	// SeqEndHandle is the handle of the SEQEND closing the nested entities
+	// of the Polylines and Inserts.
+	SeqEndHandle string
 }
 
 // Equals compare two BaseEntity objects for equality.
@@ -109,7 +118,27 @@ func (entity BaseEntity) Equals(other BaseEntity) bool {
 		entity.TrueColor == other.TrueColor &&
 		entity.ColorName == other.ColorName &&
 		entity.Transparency == other.Transparency &&
-		entity.ShadowMode == other.ShadowMode
+		entity.ShadowMode == other.ShadowMode &&
+		entity.SeqEndHandle == other.SeqEndHandle &&
+		xDataEquals(entity.XData, other.XData)
+}
+
+func xDataEquals(xData map[string]core.TagSlice, other map[string]core.TagSlice) bool {
+	if len(xData) != len(other) {
+		return false
+	}
+	for application, tags := range xData {
+		otherTags, ok := other[application]
+		if !ok || !tags.Equals(otherTags) {
+			return false
+		}
+	}
+	return true
+}
+
+// Base returns the BaseEntity holding the attributes common to all entities.
+func (entity *BaseEntity) Base() *BaseEntity {
+	return entity
 }
 
 // InitBaseEntityParser Inits the EntityParsers for the BaseEntity attributes.
@@ -148,3 +177,62 @@ func (entity *BaseEntity) InitBaseEntityParser() {
 		440: core.NewIntTypeParserToVar(&entity.Transparency),
 	})
 }
+
+// Parse parses the tags of the entity, keeping its XDATA and its unparsed
+// tags.
+func (entity *BaseEntity) Parse(tags core.TagSlice) error {
+	entity.XData = nil
+	application := ""
+	hasXData := false
+	tags.XData()(func(tag *core.Tag) bool {
+		hasXData = true
+		if tag.Code == 1001 {
+			application = tag.Value.ToString()
+			if entity.XData == nil {
+				entity.XData = make(map[string]core.TagSlice)
+			}
+			entity.XData[application] = core.TagSlice{}
+		} else if entity.XData != nil {
+			entity.XData[application] = append(entity.XData[application], tag)
+		}
+		return true
+	})
+
+	// the XDATA is kept above, not with the unparsed tags.
+	regular := tags
+	if hasXData {
+		regular = make(core.TagSlice, 0, len(tags))
+		for _, tag := range tags {
+			if tag.Code < 1000 {
+				regular = append(regular, tag)
+			}
+		}
+	}
+	return entity.DxfParseable.Parse(regular)
+}
+
+// EntityExtents returns the BoundingBox of the entity as a core.Extents.
+func EntityExtents(entity Entity) core.Extents {
+	min, max := entity.BoundingBox()
+	return core.Extents{Min: min, Max: max}
+}
+
+// EntityBase returns the BaseEntity of the entity. Entities are parsed as
+// pointers, for entities stored by value it returns a pointer to a copy and
+// for entities not composed by a BaseEntity it returns nil.
+func EntityBase(entity Entity) *BaseEntity {
+	if holder, ok := entity.(interface{ Base() *BaseEntity }); ok {
+		return holder.Base()
+	}
+
+	value := reflect.ValueOf(entity)
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	if field := value.FieldByName("BaseEntity"); field.IsValid() {
+		if base, ok := field.Interface().(BaseEntity); ok {
+			return &base
+		}
+	}
+	return nil
+}
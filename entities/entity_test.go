@@ -1,7 +1,9 @@
 package entities
 
 import (
+	"github.com/rpaloschi/dxf-go/core"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -42,3 +44,49 @@ func TestEntitySliceEquality(t *testing.T) {
 		assert.Equal(t, test.equals, test.e1.Equals(test.e2), "Test index %v", i)
 	}
 }
+
+func TestEntityBase(t *testing.T) {
+	line := &Line{BaseEntity: BaseEntity{Handle: "AB"}}
+	base := EntityBase(line)
+	base.LayerName = "L1"
+	assert.Equal(t, "L1", line.LayerName)
+
+	assert.Equal(t, "CD", EntityBase(Arc{BaseEntity: BaseEntity{Handle: "CD"}}).Handle)
+}
+
+func TestBaseEntityXData(t *testing.T) {
+	next := core.Tagger(strings.NewReader(testLineWithXData))
+	line, err := NewLine(core.TagSlice(core.AllTags(next)))
+	assert.Nil(t, err)
+	assert.Equal(t, "0", line.LayerName)
+	assert.True(t, core.Point{X: 1.0}.Equals(line.End))
+
+	assert.Len(t, line.XData, 2)
+	assert.True(t, core.TagSlice{
+		core.NewTag(1000, core.NewStringValue("ROAD")),
+		core.NewTag(1070, core.NewIntegerValue(3)),
+	}.Equals(line.XData["GIS"]))
+	assert.Len(t, line.XData["EMPTY"], 0)
+
+	other := *line
+	other.XData = map[string]core.TagSlice{"GIS": line.XData["GIS"]}
+	assert.False(t, line.Equals(&other))
+	other.XData["EMPTY"] = core.TagSlice{}
+	assert.True(t, line.Equals(&other))
+}
+
+const testLineWithXData = `  0
+LINE
+  8
+0
+ 11
+1.0
+1001
+GIS
+1000
+ROAD
+1070
+3
+1001
+EMPTY
+`
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"math"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// Flattener is implemented by the entities that can be approximated by a
+// sequence of points, like the curves and polylines.
+type Flattener interface {
+	// Flatten returns the points, in WCS, of a polyline that deviates less
+	// than tolerance from the entity. Closed entities repeat the first point
+	// at the end.
+	Flatten(tolerance float64) core.PointSlice
+}
+
+// DefaultFlattenTolerance is the chord tolerance used by Flatten when a non
+// positive tolerance is passed.
+const DefaultFlattenTolerance = 0.01
+
+func flattenTolerance(tolerance float64) float64 {
+	if tolerance <= 0.0 {
+		return DefaultFlattenTolerance
+	}
+	return tolerance
+}
+
+// arcSegmentCount returns the number of chords needed to approximate an arc
+// of radius and sweep (radians) so that the sagitta is within tolerance.
+func arcSegmentCount(radius float64, sweep float64, tolerance float64) int {
+	sweep = math.Abs(sweep)
+	if radius <= tolerance {
+		return int(math.Max(1.0, math.Ceil(sweep/(math.Pi/2.0))))
+	}
+	step := 2.0 * math.Acos(1.0-tolerance/radius)
+	return int(math.Max(1.0, math.Ceil(sweep/step)))
+}
+
+// ellipticalArcPoints returns segments+1 points on the curve
+// center + cos(t)*u + sin(t)*v, for t from start to start+sweep (radians).
+func ellipticalArcPoints(center, u, v core.Point, start, sweep float64, segments int) core.PointSlice {
+	points := make(core.PointSlice, segments+1)
+	for i := 0; i <= segments; i++ {
+		t := start + sweep*float64(i)/float64(segments)
+		points[i] = center.Add(u.Scale(math.Cos(t))).Add(v.Scale(math.Sin(t)))
+	}
+	return points
+}
+
+// ocsArcPoints returns the points in WCS of a flattened circular arc defined
+// in the OCS of extrusion. A negative sweep runs clockwise.
+func ocsArcPoints(center core.Point, radius float64, extrusion core.Point,
+	start float64, sweep float64, tolerance float64) core.PointSlice {
+
+	xAxis, yAxis, _ := core.OCSAxes(extrusion)
+	return ellipticalArcPoints(core.OCSToWCS(center, extrusion),
+		xAxis.Scale(radius), yAxis.Scale(radius), start, sweep,
+		arcSegmentCount(radius, sweep, tolerance))
+}
+
+// bulgeSegmentPoints returns the flattened segment from p1 to p2 (OCS) with
+// the given bulge in WCS, including both ends.
+func bulgeSegmentPoints(p1, p2 core.Point, bulge float64, extrusion core.Point,
+	tolerance float64) core.PointSlice {
+
+	center, radius, start, sweep, ok := bulgeArc(p1, p2, bulge)
+	if !ok {
+		return core.PointSlice{core.OCSToWCS(p1, extrusion), core.OCSToWCS(p2, extrusion)}
+	}
+	points := ocsArcPoints(center, radius, extrusion, start, sweep, tolerance)
+	// avoid rounding differences on the vertices.
+	points[0] = core.OCSToWCS(p1, extrusion)
+	points[len(points)-1] = core.OCSToWCS(p2, extrusion)
+	return points
+}
+
+// flattenBulgedPath flattens a 2D path of OCS points and bulges. If closed,
+// the segment from the last to the first point is added.
+func flattenBulgedPath(points core.PointSlice, bulges []float64, closed bool,
+	extrusion core.Point, tolerance float64) core.PointSlice {
+
+	count := len(points)
+	if count == 0 {
+		return core.PointSlice{}
+	}
+
+	result := core.PointSlice{core.OCSToWCS(points[0], extrusion)}
+	segments := count - 1
+	if closed && count > 1 {
+		segments = count
+	}
+	for i := 0; i < segments; i++ {
+		segment := bulgeSegmentPoints(points[i], points[(i+1)%count], bulges[i],
+			extrusion, tolerance)
+		result = append(result, segment[1:]...)
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+// assertArcPoints checks that all points lie on the circle and that all the
+// chords are within tolerance.
+func assertArcPoints(t *testing.T, points core.PointSlice, center core.Point,
+	radius float64, tolerance float64) {
+
+	for i, point := range points {
+		assert.InDelta(t, radius, point.Distance(center), 1e-9, "point %v", i)
+		if i > 0 {
+			middle := point.Add(points[i-1]).Scale(0.5)
+			assert.True(t, radius-middle.Distance(center) <= tolerance,
+				"chord %v deviates %v", i, radius-middle.Distance(center))
+		}
+	}
+}
+
+func TestArcSegmentCount(t *testing.T) {
+	testCases := []struct {
+		radius, sweep, tolerance float64
+		segments                 int
+	}{
+		{1.0, math.Pi, 1.0, 2},
+		{1.0, math.Pi, 5.0, 2},
+		{1.0, 2 * math.Pi, 0.5, 3},
+		{1.0, -2 * math.Pi, 0.5, 3},
+		{10.0, 0.0, 0.5, 1},
+		{100.0, 2 * math.Pi, 0.01, 223},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.segments,
+			arcSegmentCount(test.radius, test.sweep, test.tolerance), "Test case: %+v", test)
+	}
+}
+
+func TestFlattenEntities(t *testing.T) {
+	flatteners := []Flattener{
+		&Line{}, &Arc{}, &Circle{}, &Ellipse{}, &LWPolyline{}, &Polyline{}, &Spline{},
+	}
+	for _, flattener := range flatteners {
+		assert.NotNil(t, flattener.Flatten(0.1))
+	}
+}
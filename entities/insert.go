@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"math"
+
 	"github.com/rpaloschi/dxf-go/core"
 )
 
@@ -95,3 +97,65 @@ func NewInsert(tags core.TagSlice) (*Insert, error) {
 	err := insert.Parse(tags)
 	return insert, err
 }
+
+// BoundingBox returns the minimum and maximum corners of the Insert extents.
+// An Insert has no access to its Block definition, so only the insertion point
+// and the attributes are considered. Use the document Extents to include the
+// referenced Block geometry.
+func (i Insert) BoundingBox() (min, max core.Point) {
+	extents := pointsExtents(core.OCSToWCS(i.InsertionPoint, i.ExtrusionDirection))
+	for _, entity := range i.Entities {
+		extents.Merge(EntityExtents(entity))
+	}
+	return extents.Min, extents.Max
+}
+
+// Transform returns the transformation from the coordinates of the Block
+// definition, with the given base point, to World Coordinates for the instance
+// of the Insert array at column and row (both 0 for a single Insert).
+func (i Insert) Transform(basePoint core.Point, column int, row int) core.Matrix {
+	offset := core.Point{
+		X: float64(column) * i.ColumnSpacing,
+		Y: float64(row) * i.RowSpacing,
+	}
+	return core.OCSMatrix(i.ExtrusionDirection).
+		Multiply(core.TranslationMatrix(i.InsertionPoint)).
+		Multiply(core.RotationZMatrix(i.RotationAngle * math.Pi / 180.0)).
+		Multiply(core.TranslationMatrix(offset)).
+		Multiply(core.ScaleMatrix(i.ScaleFactorX, i.ScaleFactorY, i.ScaleFactorZ)).
+		Multiply(core.TranslationMatrix(basePoint.Scale(-1.0)))
+}
+
+// Tags returns the tags of the Insert, followed by its attributes and a
+// SEQEND if AttributesFollow.
+func (i Insert) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbBlockReference")}
+	if i.AttributesFollow {
+		tags = append(tags, core.NewIntegerTag(66, 1))
+	}
+	tags = append(tags, core.NewStringTag(2, i.BlockName))
+	tags = append(tags, core.NewPointTags(10, i.InsertionPoint)...)
+	for j, scale := range []float64{i.ScaleFactorX, i.ScaleFactorY, i.ScaleFactorZ} {
+		if scale != 1.0 {
+			tags = append(tags, core.NewFloatTag(41+j, scale))
+		}
+	}
+	tags = append(tags, optionalFloatTag(50, i.RotationAngle)...)
+	if i.ColumnCount > 1 || i.RowCount > 1 {
+		tags = append(tags, core.NewIntegerTag(70, i.ColumnCount), core.NewIntegerTag(71, i.RowCount))
+		tags = append(tags, optionalFloatTag(44, i.ColumnSpacing)...)
+		tags = append(tags, optionalFloatTag(45, i.RowSpacing)...)
+	}
+	tags = append(tags, extrusionTags(i.ExtrusionDirection)...)
+
+	tags = i.entityTags("INSERT", tags)
+	if i.AttributesFollow {
+		for _, entity := range i.Entities {
+			if writable, ok := entity.(Writable); ok {
+				tags = append(tags, writable.Tags()...)
+			}
+		}
+		tags = append(tags, i.seqEndTags()...)
+	}
+	return tags
+}
@@ -151,6 +151,46 @@ func (suite *InsertTestSuite) TestAddNestedEntities() {
 	suite.True(expected.Equals(insert))
 }
 
+func (suite *InsertTestSuite) TestInsertBoundingBox() {
+	insert := Insert{
+		InsertionPoint:     core.Point{X: 1.0, Y: 2.0},
+		ExtrusionDirection: core.Point{Z: 1.0},
+		Entities: EntitySlice{
+			&Point{Location: core.Point{X: 5.0, Y: -1.0}},
+		},
+	}
+	min, max := insert.BoundingBox()
+	suite.True(core.Point{X: 1.0, Y: -1.0}.Equals(min), "%+v", min)
+	suite.True(core.Point{X: 5.0, Y: 2.0}.Equals(max), "%+v", max)
+}
+
+func (suite *InsertTestSuite) TestInsertTransform() {
+	insert := Insert{
+		InsertionPoint:     core.Point{X: 10.0, Y: 5.0},
+		ScaleFactorX:       2.0,
+		ScaleFactorY:       3.0,
+		ScaleFactorZ:       1.0,
+		RotationAngle:      90.0,
+		ColumnSpacing:      4.0,
+		RowSpacing:         6.0,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+	basePoint := core.Point{X: 1.0, Y: 1.0}
+
+	transform := insert.Transform(basePoint, 0, 0)
+	suite.True(insert.InsertionPoint.Equals(transform.Apply(basePoint)))
+	suite.True(core.Point{X: 10.0, Y: 7.0}.Equals(transform.Apply(core.Point{X: 2.0, Y: 1.0})))
+	suite.True(core.Point{X: 7.0, Y: 5.0}.Equals(transform.Apply(core.Point{X: 1.0, Y: 2.0})))
+
+	// the array spacing is rotated, but not scaled.
+	transform = insert.Transform(basePoint, 1, 1)
+	suite.True(core.Point{X: 4.0, Y: 9.0}.Equals(transform.Apply(basePoint)))
+
+	insert.ExtrusionDirection = core.Point{Z: -1.0}
+	transform = insert.Transform(basePoint, 0, 0)
+	suite.True(core.Point{X: -10.0, Y: 5.0}.Equals(transform.Apply(basePoint)))
+}
+
 func TestInsertTestSuite(t *testing.T) {
 	suite.Run(t, new(InsertTestSuite))
 }
@@ -47,3 +47,24 @@ func NewLine(tags core.TagSlice) (*Line, error) {
 	err := line.Parse(tags)
 	return line, err
 }
+
+// BoundingBox returns the minimum and maximum corners of the Line extents.
+func (a Line) BoundingBox() (min, max core.Point) {
+	extents := pointsExtents(a.Start, a.End)
+	return extents.Min, extents.Max
+}
+
+// Flatten returns the Line start and end points.
+func (a Line) Flatten(tolerance float64) core.PointSlice {
+	return core.PointSlice{a.Start, a.End}
+}
+
+// Tags returns the tags of the Line.
+func (a Line) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbLine")}
+	tags = append(tags, optionalFloatTag(39, a.Thickness)...)
+	tags = append(tags, core.NewPointTags(10, a.Start)...)
+	tags = append(tags, core.NewPointTags(11, a.End)...)
+	tags = append(tags, extrusionTags(a.ExtrusionDirection)...)
+	return a.entityTags("LINE", tags)
+}
@@ -89,6 +89,21 @@ func (suite *LineTestSuite) TestLineNotEqualToDifferentType() {
 	suite.False(Line{}.Equals(core.NewIntegerValue(0)))
 }
 
+func (suite *LineTestSuite) TestLineBoundingBox() {
+	line := Line{
+		Start: core.Point{X: 3.0, Y: -1.0, Z: 2.0},
+		End:   core.Point{X: -1.0, Y: 4.0, Z: 0.0},
+	}
+	min, max := line.BoundingBox()
+	suite.True(core.Point{X: -1.0, Y: -1.0, Z: 0.0}.Equals(min))
+	suite.True(core.Point{X: 3.0, Y: 4.0, Z: 2.0}.Equals(max))
+}
+
+func (suite *LineTestSuite) TestLineFlatten() {
+	line := Line{Start: core.Point{X: 3.0, Y: -1.0}, End: core.Point{X: -1.0, Y: 4.0}}
+	suite.True(core.PointSlice{line.Start, line.End}.Equals(line.Flatten(0.1)))
+}
+
 func TestLineTestSuite(t *testing.T) {
 	suite.Run(t, new(LineTestSuite))
 }
@@ -32,6 +32,11 @@ func (p LWPolyline) Equals(other core.DxfElement) bool {
 const closedBit = 0x1
 const plinegenBit = 0x80
 
+// maxPreallocatedPoints is the largest 90 count of vertices allocated before
+// reading them, the Points of a larger or negative count grow as they are
+// read.
+const maxPreallocatedPoints = 1 << 16
+
 // NewLWPolyline builds a new LWPolyline from a slice of Tags.
 func NewLWPolyline(tags core.TagSlice) (*LWPolyline, error) {
 	polyline := new(LWPolyline)
@@ -42,12 +47,26 @@ func NewLWPolyline(tags core.TagSlice) (*LWPolyline, error) {
 	polyline.InitBaseEntityParser()
 
 	pointIndex := -1
+	// point returns the point of the current vertex. The Points grow when the
+	// file has more vertices than its 90 count or a vertex without its 10 tag.
+	point := func() *LWPolyLinePoint {
+		if pointIndex < 0 {
+			pointIndex = 0
+		}
+		for pointIndex >= len(polyline.Points) {
+			polyline.Points = append(polyline.Points, LWPolyLinePoint{})
+		}
+		return &polyline.Points[pointIndex]
+	}
 	polyline.Update(map[int]core.TypeParser{
 		70: core.NewIntTypeParser(func(flags int) {
 			polyline.Closed = flags&closedBit != 0
 			polyline.Plinegen = flags&plinegenBit != 0
 		}),
 		90: core.NewIntTypeParser(func(value int) {
+			if value < 0 || value > maxPreallocatedPoints {
+				value = 0
+			}
 			polyline.Points = make(LWPolyLinePointSlice, value)
 		}),
 		38: core.NewFloatTypeParserToVar(&polyline.Elevation),
@@ -55,22 +74,22 @@ func NewLWPolyline(tags core.TagSlice) (*LWPolyline, error) {
 		43: core.NewFloatTypeParserToVar(&polyline.ConstantWidth),
 		10: core.NewFloatTypeParser(func(x float64) {
 			pointIndex++
-			polyline.Points[pointIndex].Point.X = x
+			point().Point.X = x
 		}),
 		20: core.NewFloatTypeParser(func(y float64) {
-			polyline.Points[pointIndex].Point.Y = y
+			point().Point.Y = y
 		}),
 		91: core.NewIntTypeParser(func(value int) {
-			polyline.Points[pointIndex].Id = value
+			point().Id = value
 		}),
 		40: core.NewFloatTypeParser(func(value float64) {
-			polyline.Points[pointIndex].StartingWidth = value
+			point().StartingWidth = value
 		}),
 		41: core.NewFloatTypeParser(func(value float64) {
-			polyline.Points[pointIndex].EndWidth = value
+			point().EndWidth = value
 		}),
 		42: core.NewFloatTypeParser(func(value float64) {
-			polyline.Points[pointIndex].Bulge = value
+			point().Bulge = value
 		}),
 		210: core.NewFloatTypeParserToVar(&polyline.ExtrusionDirection.X),
 		220: core.NewFloatTypeParserToVar(&polyline.ExtrusionDirection.Y),
@@ -118,3 +137,143 @@ func (p LWPolyLinePoint) Equals(other LWPolyLinePoint) bool {
 		core.FloatEquals(p.EndWidth, other.EndWidth) &&
 		core.FloatEquals(p.Bulge, other.Bulge)
 }
+
+// BoundingBox returns the minimum and maximum corners of the LWPolyline extents,
+// taking the bulged (arc) segments into account.
+func (p LWPolyline) BoundingBox() (min, max core.Point) {
+	extents := core.NewExtents()
+
+	count := len(p.Points)
+	for i, point := range p.Points {
+		start := core.Point{X: point.Point.X, Y: point.Point.Y, Z: p.Elevation}
+		extents.Add(core.OCSToWCS(start, p.ExtrusionDirection))
+
+		if i == count-1 && !p.Closed {
+			break
+		}
+		next := p.Points[(i+1)%count]
+		end := core.Point{X: next.Point.X, Y: next.Point.Y, Z: p.Elevation}
+		extents.Merge(bulgeSegmentExtents(start, end, point.Bulge, p.ExtrusionDirection))
+	}
+
+	return extents.Min, extents.Max
+}
+
+// Flatten approximates the LWPolyline by a polyline within the chord
+// tolerance, the bulged segments are converted to arc points.
+func (p LWPolyline) Flatten(tolerance float64) core.PointSlice {
+	points := make(core.PointSlice, len(p.Points))
+	bulges := make([]float64, len(p.Points))
+	for i, point := range p.Points {
+		points[i] = core.Point{X: point.Point.X, Y: point.Point.Y, Z: p.Elevation}
+		bulges[i] = point.Bulge
+	}
+	return flattenBulgedPath(points, bulges, p.Closed, p.ExtrusionDirection,
+		flattenTolerance(tolerance))
+}
+
+// Segments returns the line and arc segments of the LWPolyline, in its OCS.
+// Closed LWPolylines include the segment from the last point to the first.
+func (p LWPolyline) Segments() SegmentSlice {
+	points := make(core.PointSlice, len(p.Points))
+	bulges := make([]float64, len(p.Points))
+	for i, point := range p.Points {
+		points[i] = core.Point{X: point.Point.X, Y: point.Point.Y, Z: p.Elevation}
+		bulges[i] = point.Bulge
+	}
+	return bulgedPathSegments(points, bulges, p.Closed)
+}
+
+// Length returns the length of the LWPolyline, following its arcs.
+func (p LWPolyline) Length() float64 {
+	return p.Segments().Length()
+}
+
+// Area returns the area enclosed by the LWPolyline. Open LWPolylines are
+// measured as if closed by a straight segment.
+func (p LWPolyline) Area() float64 {
+	return p.Segments().Area()
+}
+
+// PointAtDistance returns the point (OCS) at the distance from the start of
+// the LWPolyline, ok is false if the distance is outside of it.
+func (p LWPolyline) PointAtDistance(distance float64) (point core.Point, ok bool) {
+	return p.Segments().PointAtDistance(distance)
+}
+
+// ToPolyline converts the LWPolyline to an equivalent 2D Polyline.
+func (p LWPolyline) ToPolyline() *Polyline {
+	polyline := &Polyline{
+		BaseEntity:           p.BaseEntity,
+		Elevation:            p.Elevation,
+		Thickness:            p.Thickness,
+		Closed:               p.Closed,
+		LineTypeParentAround: p.Plinegen,
+		DefaultStartWidth:    p.ConstantWidth,
+		DefaultEndWidth:      p.ConstantWidth,
+		ExtrusionDirection:   p.ExtrusionDirection,
+		Vertices:             make(VertexSlice, len(p.Points)),
+	}
+	polyline.DxfParseable = core.DxfParseable{}
+	polyline.Handle = ""
+
+	for i, point := range p.Points {
+		vertex := &Vertex{
+			Location:      core.Point{X: point.Point.X, Y: point.Point.Y, Z: p.Elevation},
+			StartingWidth: point.StartingWidth,
+			EndWidth:      point.EndWidth,
+			Bulge:         point.Bulge,
+			Id:            point.Id,
+		}
+		vertex.LayerName = p.LayerName
+		vertex.On = true
+		vertex.Visible = true
+		polyline.Vertices[i] = vertex
+	}
+	return polyline
+}
+
+// Outline returns the filled outline of a wide LWPolyline in WCS, taking the
+// ConstantWidth or the width of each segment into account, with its arcs
+// flattened within the chord tolerance. The segments are joined with miters.
+// An open LWPolyline has a single polygon, a closed one has two: the
+// boundaries on each side of it, to be filled with the even-odd rule. The
+// polygons are closed, repeating the first point at the end. LWPolylines
+// without width have no outline.
+func (p LWPolyline) Outline(tolerance float64) []core.PointSlice {
+	points := make(core.PointSlice, len(p.Points))
+	bulges := make([]float64, len(p.Points))
+	startWidths := make([]float64, len(p.Points))
+	endWidths := make([]float64, len(p.Points))
+	for i, point := range p.Points {
+		points[i] = core.Point{X: point.Point.X, Y: point.Point.Y, Z: p.Elevation}
+		bulges[i] = point.Bulge
+		startWidths[i], endWidths[i] = point.StartingWidth, point.EndWidth
+		if p.ConstantWidth != 0.0 {
+			startWidths[i], endWidths[i] = p.ConstantWidth, p.ConstantWidth
+		}
+	}
+	return widePathOutline(points, bulges, startWidths, endWidths, p.Closed,
+		p.ExtrusionDirection, flattenTolerance(tolerance))
+}
+
+// Tags returns the tags of the LWPolyline.
+func (p LWPolyline) Tags() core.TagSlice {
+	tags := core.TagSlice{
+		core.NewStringTag(100, "AcDbPolyline"),
+		core.NewIntegerTag(90, len(p.Points)),
+		core.NewIntegerTag(70, flags(map[int]bool{closedBit: p.Closed, plinegenBit: p.Plinegen})),
+	}
+	tags = append(tags, optionalFloatTag(43, p.ConstantWidth)...)
+	tags = append(tags, optionalFloatTag(38, p.Elevation)...)
+	tags = append(tags, optionalFloatTag(39, p.Thickness)...)
+	for _, point := range p.Points {
+		tags = append(tags, core.NewFloatTag(10, point.Point.X), core.NewFloatTag(20, point.Point.Y))
+		tags = append(tags, optionalIntegerTag(91, point.Id)...)
+		tags = append(tags, optionalFloatTag(40, point.StartingWidth)...)
+		tags = append(tags, optionalFloatTag(41, point.EndWidth)...)
+		tags = append(tags, optionalFloatTag(42, point.Bulge)...)
+	}
+	tags = append(tags, extrusionTags(p.ExtrusionDirection)...)
+	return p.entityTags("LWPOLYLINE", tags)
+}
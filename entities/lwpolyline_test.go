@@ -4,6 +4,7 @@ import (
 	"github.com/rpaloschi/dxf-go/core"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"math"
 	"strings"
 	"testing"
 )
@@ -90,10 +91,180 @@ func (suite *LWPolylineTestSuite) TestLWPolylineAllAttribs() {
 	suite.True(expected.Equals(polyline))
 }
 
+func (suite *LWPolylineTestSuite) TestLWPolylineWrongVertexCount() {
+	for _, count := range []string{"1", "-1", "1000000000"} {
+		next := core.Tagger(strings.NewReader(
+			"  0\nLWPOLYLINE\n 90\n" + count + "\n 20\n2.0\n 10\n3.0\n 20\n4.0\n 42\n0.5\n"))
+		polyline, err := NewLWPolyline(core.TagSlice(core.AllTags(next)))
+
+		// the vertices beyond the count, or without their 10 tag, are kept.
+		suite.Nil(err, count)
+		suite.True(LWPolyLinePointSlice{
+			{Point: core.Point{Y: 2.0}},
+			{Point: core.Point{X: 3.0, Y: 4.0}, Bulge: 0.5},
+		}.Equals(polyline.Points), count)
+	}
+}
+
 func (suite *LWPolylineTestSuite) TestLWPolylineNotEqualToDifferentType() {
 	suite.False(LWPolyline{}.Equals(core.NewFloatValue(0.1)))
 }
 
+func (suite *LWPolylineTestSuite) TestLWPolylineBoundingBox() {
+	polyline := LWPolyline{
+		Points: LWPolyLinePointSlice{
+			{Point: core.Point{X: 0.0, Y: 0.0}, Bulge: 1.0},
+			{Point: core.Point{X: 2.0, Y: 0.0}},
+			{Point: core.Point{X: 2.0, Y: 3.0}},
+		},
+		Elevation:          1.5,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+	min, max := polyline.BoundingBox()
+	suite.True(core.Point{X: 0.0, Y: -1.0, Z: 1.5}.Equals(min), "%+v", min)
+	suite.True(core.Point{X: 2.0, Y: 3.0, Z: 1.5}.Equals(max), "%+v", max)
+
+	// the closing segment bulges to the right of (2, 3) -> (0, 0).
+	polyline.Closed = true
+	polyline.Points[2].Bulge = 1.0
+	min, max = polyline.BoundingBox()
+	center := core.Point{X: 1.0, Y: 1.5}
+	radius := math.Hypot(1.0, 1.5)
+	suite.True(core.Point{X: center.X - radius, Y: -1.0, Z: 1.5}.Equals(min), "%+v", min)
+	suite.True(core.Point{X: 2.0, Y: center.Y + radius, Z: 1.5}.Equals(max), "%+v", max)
+}
+
+func (suite *LWPolylineTestSuite) TestLWPolylineFlatten() {
+	polyline := LWPolyline{
+		Points: LWPolyLinePointSlice{
+			{Point: core.Point{X: 0.0, Y: 0.0}, Bulge: 1.0},
+			{Point: core.Point{X: 2.0, Y: 0.0}},
+			{Point: core.Point{X: 2.0, Y: 3.0}},
+		},
+		Elevation:          1.5,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+
+	points := polyline.Flatten(0.01)
+	suite.True(core.Point{X: 0.0, Y: 0.0, Z: 1.5}.Equals(points[0]))
+	suite.True(core.Point{X: 2.0, Y: 3.0, Z: 1.5}.Equals(points[len(points)-1]))
+	suite.True(core.Point{X: 2.0, Y: 0.0, Z: 1.5}.Equals(points[len(points)-2]))
+	assertArcPoints(suite.T(), points[:len(points)-1], core.Point{X: 1.0, Z: 1.5}, 1.0, 0.01)
+	// clockwise from (0, 0), under the X axis.
+	suite.True(points[1].Y < 0.0)
+
+	polyline.Closed = true
+	closed := polyline.Flatten(0.01)
+	suite.Len(closed, len(points)+1)
+	suite.True(closed[0].Equals(closed[len(closed)-1]))
+
+	suite.Len(LWPolyline{}.Flatten(0.01), 0)
+}
+
+func (suite *LWPolylineTestSuite) TestLWPolylineSegments() {
+	polyline := LWPolyline{
+		Points: LWPolyLinePointSlice{
+			{Point: core.Point{X: 0.0, Y: 0.0}},
+			{Point: core.Point{X: 2.0, Y: 0.0}, Bulge: 1.0},
+			{Point: core.Point{X: 2.0, Y: 2.0}},
+			{Point: core.Point{X: 0.0, Y: 2.0}},
+		},
+		Elevation:          1.5,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+
+	segments := polyline.Segments()
+	suite.Len(segments, 3)
+	suite.Equal(LineSegment{Start: core.Point{Z: 1.5}, End: core.Point{X: 2.0, Z: 1.5}}, segments[0])
+	arc, ok := segments[1].(ArcSegment)
+	suite.True(ok)
+	suite.True(core.Point{X: 2.0, Y: 1.0, Z: 1.5}.Equals(arc.Center))
+	suite.InDelta(1.0, arc.Radius, 1e-12)
+
+	suite.InDelta(4.0+math.Pi, polyline.Length(), 1e-12)
+	suite.InDelta(4.0+math.Pi/2.0, polyline.Area(), 1e-12)
+	point, ok := polyline.PointAtDistance(2.0 + math.Pi/2.0)
+	suite.True(ok)
+	suite.True(core.Point{X: 3.0, Y: 1.0, Z: 1.5}.Equals(point), "%+v", point)
+
+	polyline.Closed = true
+	suite.Len(polyline.Segments(), 4)
+	suite.InDelta(6.0+math.Pi, polyline.Length(), 1e-12)
+	suite.InDelta(4.0+math.Pi/2.0, polyline.Area(), 1e-12)
+
+	suite.Len(LWPolyline{}.Segments(), 0)
+}
+
+func (suite *LWPolylineTestSuite) TestLWPolylineToPolyline() {
+	lwpolyline := LWPolyline{
+		BaseEntity:    BaseEntity{Handle: "A1", LayerName: "walls", On: true, Visible: true},
+		Closed:        true,
+		Plinegen:      true,
+		ConstantWidth: 0.5,
+		Elevation:     1.5,
+		Thickness:     2.0,
+		Points: LWPolyLinePointSlice{
+			{Point: core.Point{X: 0.0, Y: 0.0}, Bulge: 1.0, Id: 1},
+			{Point: core.Point{X: 2.0, Y: 0.0}, StartingWidth: 0.1, EndWidth: 0.2},
+		},
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+
+	polyline := lwpolyline.ToPolyline()
+	suite.True(polyline.Is2d())
+	suite.Equal("", polyline.Handle)
+	suite.Equal("walls", polyline.LayerName)
+	suite.True(polyline.Closed)
+	suite.True(polyline.LineTypeParentAround)
+	suite.Equal(0.5, polyline.DefaultStartWidth)
+	suite.Equal(0.5, polyline.DefaultEndWidth)
+	suite.Equal(1.5, polyline.Elevation)
+	suite.Equal(2.0, polyline.Thickness)
+	suite.Len(polyline.Vertices, 2)
+	suite.True(core.Point{X: 2.0, Z: 1.5}.Equals(polyline.Vertices[1].Location))
+	suite.Equal(1.0, polyline.Vertices[0].Bulge)
+	suite.Equal(1, polyline.Vertices[0].Id)
+	suite.Equal(0.2, polyline.Vertices[1].EndWidth)
+	suite.InDelta(lwpolyline.Length(), polyline.Length(), 1e-12)
+
+	back, err := polyline.ToLWPolyline()
+	suite.Nil(err)
+	lwpolyline.Handle = ""
+	suite.True(lwpolyline.Equals(back))
+}
+
+func (suite *LWPolylineTestSuite) TestLWPolylineOutline() {
+	polyline := LWPolyline{
+		Closed:        true,
+		ConstantWidth: 2.0,
+		Points: LWPolyLinePointSlice{
+			{Point: core.Point{X: 0.0, Y: 0.0}},
+			{Point: core.Point{X: 10.0, Y: 0.0}},
+			{Point: core.Point{X: 10.0, Y: 10.0}},
+			{Point: core.Point{X: 0.0, Y: 10.0}},
+		},
+		Elevation:          1.0,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+
+	outline := polyline.Outline(0.01)
+	suite.Len(outline, 2)
+	suite.InDelta(64.0, polygonArea(outline[0]), 1e-9)
+	suite.InDelta(144.0, polygonArea(outline[1]), 1e-9)
+	suite.True(core.Point{X: 1.0, Y: 1.0, Z: 1.0}.Equals(outline[0][0]), "%+v", outline[0])
+	suite.True(outline[1][0].Equals(outline[1][len(outline[1])-1]))
+
+	// per segment widths.
+	polyline.ConstantWidth = 0.0
+	polyline.Closed = false
+	polyline.Points[0].StartingWidth = 2.0
+	outline = polyline.Outline(0.01)
+	suite.Len(outline, 1)
+	suite.InDelta(10.0, polygonArea(outline[0]), 1e-9)
+
+	suite.Len(LWPolyline{}.Outline(0.01), 0)
+}
+
 func TestLWPolylineTestSuite(t *testing.T) {
 	suite.Run(t, new(LWPolylineTestSuite))
 }
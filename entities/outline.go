@@ -0,0 +1,186 @@
+package entities
+
+import (
+	"math"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// outlineMiterLimit is the longest distance, in widths, from a vertex to the
+// miter join of its offset sides. Sharper joins are beveled.
+const outlineMiterLimit = 4.0
+
+// widePathOutline computes the outline of a 2D path of OCS points with
+// bulges and a start and end width per segment, in WCS. The arcs are
+// flattened within the chord tolerance. An open path has a single polygon
+// with butt ends, a closed path has two, the boundaries on each side of it.
+// The polygons are closed (the first point is repeated at the end) and
+// should be filled with the even-odd rule. Paths without width have no
+// outline.
+func widePathOutline(points core.PointSlice, bulges, startWidths, endWidths []float64,
+	closed bool, extrusion core.Point, tolerance float64) []core.PointSlice {
+
+	segments := bulgedPathSegments(points, bulges, closed)
+
+	var lefts, rights []core.PointSlice
+	var widths []float64
+	hasWidth := false
+	for i, segment := range segments {
+		if core.FloatEquals(segment.Length(), 0.0) {
+			continue
+		}
+		startWidth, endWidth := math.Abs(startWidths[i]), math.Abs(endWidths[i])
+		hasWidth = hasWidth || startWidth > 0.0 || endWidth > 0.0
+
+		left, right := segmentSides(segment, startWidth, endWidth, tolerance)
+		lefts = append(lefts, left)
+		rights = append(rights, right)
+		widths = append(widths, endWidth)
+	}
+	if !hasWidth {
+		return []core.PointSlice{}
+	}
+
+	count := len(lefts)
+	joins := count - 1
+	if closed {
+		joins = count
+	}
+	for i := 0; i < joins; i++ {
+		next := (i + 1) % count
+		miterJoin(lefts[i], lefts[next], widths[i])
+		miterJoin(rights[i], rights[next], widths[i])
+	}
+
+	var polygons []core.PointSlice
+	if closed {
+		left := joinSides(lefts)
+		right := joinSides(rights)
+		polygons = []core.PointSlice{
+			closeRing(left),
+			closeRing(reversedPoints(right)),
+		}
+	} else {
+		outline := joinSides(lefts)
+		for i := count - 1; i >= 0; i-- {
+			outline = appendDistinct(outline, reversedPoints(rights[i])...)
+		}
+		polygons = []core.PointSlice{closeRing(outline)}
+	}
+
+	for _, polygon := range polygons {
+		for i, point := range polygon {
+			polygon[i] = core.OCSToWCS(point, extrusion)
+		}
+	}
+	return polygons
+}
+
+// segmentSides returns the sides of a Segment offset by half of its width
+// to the left and to the right of its direction, the width changes linearly
+// from the start to the end of the Segment.
+func segmentSides(segment Segment, startWidth, endWidth float64,
+	tolerance float64) (left, right core.PointSlice) {
+
+	switch s := segment.(type) {
+	case ArcSegment:
+		sweep := s.Sweep()
+		start := s.StartAngle * math.Pi / 180.0
+		side := math.Copysign(1.0, sweep)
+		count := arcSegmentCount(s.Radius+math.Max(startWidth, endWidth)/2.0, sweep, tolerance)
+
+		left = make(core.PointSlice, count+1)
+		right = make(core.PointSlice, count+1)
+		for i := 0; i <= count; i++ {
+			t := float64(i) / float64(count)
+			angle := start + sweep*t
+			halfWidth := (startWidth + (endWidth-startWidth)*t) / 2.0
+			radial := core.Point{X: math.Cos(angle), Y: math.Sin(angle)}
+			// left of a counter-clockwise arc is towards the center.
+			left[i] = s.Center.Add(radial.Scale(math.Max(0.0, s.Radius-side*halfWidth)))
+			right[i] = s.Center.Add(radial.Scale(math.Max(0.0, s.Radius+side*halfWidth)))
+		}
+		return left, right
+	default:
+		start, end := segment.StartPoint(), segment.EndPoint()
+		direction := end.Sub(start).Normalize()
+		normal := core.Point{X: -direction.Y, Y: direction.X}
+		return core.PointSlice{
+			start.Add(normal.Scale(startWidth / 2.0)),
+			end.Add(normal.Scale(endWidth / 2.0)),
+		}, core.PointSlice{
+			start.Sub(normal.Scale(startWidth / 2.0)),
+			end.Sub(normal.Scale(endWidth / 2.0)),
+		}
+	}
+}
+
+// miterJoin joins the end of a side to the start of the next one on the
+// intersection of their end and start edges. Joins that are almost straight
+// or too sharp are left as they are, a bevel.
+func miterJoin(side core.PointSlice, next core.PointSlice, width float64) {
+	end, start := side[len(side)-1], next[0]
+	if end.Equals(start) {
+		return
+	}
+
+	intersection, ok := linesIntersection(side[len(side)-2], end, start, next[1])
+	if !ok || intersection.Distance(end) > outlineMiterLimit*width {
+		return
+	}
+	side[len(side)-1] = intersection
+	next[0] = intersection
+}
+
+// linesIntersection returns the intersection in the XY plane of the line
+// through a1 and a2 with the line through b1 and b2. ok is false if the lines
+// are parallel.
+func linesIntersection(a1, a2, b1, b2 core.Point) (point core.Point, ok bool) {
+	da := a2.Sub(a1)
+	db := b2.Sub(b1)
+	denominator := da.X*db.Y - da.Y*db.X
+	if math.Abs(denominator) <= 1e-9*da.Length()*db.Length() {
+		return core.Point{}, false
+	}
+
+	diff := b1.Sub(a1)
+	t := (diff.X*db.Y - diff.Y*db.X) / denominator
+	return core.Point{X: a1.X + da.X*t, Y: a1.Y + da.Y*t, Z: a2.Z}, true
+}
+
+// joinSides concatenates the sides, skipping the points shared by the joins.
+func joinSides(sides []core.PointSlice) core.PointSlice {
+	result := core.PointSlice{}
+	for _, side := range sides {
+		result = appendDistinct(result, side...)
+	}
+	return result
+}
+
+// appendDistinct appends the points, skipping the ones equal to the last
+// point of the slice.
+func appendDistinct(points core.PointSlice, others ...core.Point) core.PointSlice {
+	for _, point := range others {
+		if len(points) == 0 || !points[len(points)-1].Equals(point) {
+			points = append(points, point)
+		}
+	}
+	return points
+}
+
+// reversedPoints returns a copy of the points in reverse order.
+func reversedPoints(points core.PointSlice) core.PointSlice {
+	result := make(core.PointSlice, len(points))
+	for i, point := range points {
+		result[len(points)-1-i] = point
+	}
+	return result
+}
+
+// closeRing repeats the first point at the end of the points, if needed.
+func closeRing(points core.PointSlice) core.PointSlice {
+	if len(points) > 0 && !points[0].Equals(points[len(points)-1]) {
+		points = append(points, points[0])
+	}
+	return points
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func polygonArea(points core.PointSlice) float64 {
+	area := 0.0
+	for i := 0; i < len(points)-1; i++ {
+		area += points[i].X*points[i+1].Y - points[i+1].X*points[i].Y
+	}
+	return math.Abs(area) / 2.0
+}
+
+func TestWidePathOutlineMiter(t *testing.T) {
+	points := core.PointSlice{{X: 0.0}, {X: 10.0}, {X: 10.0, Y: 10.0}}
+	widths := []float64{2.0, 2.0, 2.0}
+
+	outline := widePathOutline(points, []float64{0.0, 0.0, 0.0}, widths, widths, false,
+		core.Point{Z: 1.0}, 0.01)
+	assert.Len(t, outline, 1)
+	assert.True(t, core.PointSlice{
+		{X: 0.0, Y: 1.0}, {X: 9.0, Y: 1.0}, {X: 9.0, Y: 10.0},
+		{X: 11.0, Y: 10.0}, {X: 11.0, Y: -1.0}, {X: 0.0, Y: -1.0}, {X: 0.0, Y: 1.0},
+	}.Equals(outline[0]), "%+v", outline[0])
+	assert.InDelta(t, 40.0, polygonArea(outline[0]), 1e-9)
+}
+
+func TestWidePathOutlineTaper(t *testing.T) {
+	points := core.PointSlice{{X: 0.0}, {X: 10.0}}
+	outline := widePathOutline(points, []float64{0.0, 0.0}, []float64{0.0, 0.0},
+		[]float64{2.0, 0.0}, false, core.Point{Z: 1.0}, 0.01)
+	assert.Len(t, outline, 1)
+	assert.InDelta(t, 10.0, polygonArea(outline[0]), 1e-9)
+
+	outline = widePathOutline(points, []float64{0.0, 0.0}, []float64{0.0, 0.0},
+		[]float64{0.0, 0.0}, false, core.Point{Z: 1.0}, 0.01)
+	assert.Len(t, outline, 0)
+}
+
+func TestWidePathOutlineArc(t *testing.T) {
+	points := core.PointSlice{{X: 0.0}, {X: 2.0}}
+	widths := []float64{0.5, 0.5}
+	outline := widePathOutline(points, []float64{1.0, 0.0}, widths, widths, false,
+		core.Point{Z: 1.0}, 0.001)
+	assert.Len(t, outline, 1)
+
+	center := core.Point{X: 1.0}
+	for _, point := range outline[0] {
+		radius := point.Distance(center)
+		assert.True(t, core.FloatEquals(radius, 0.75) || core.FloatEquals(radius, 1.25),
+			"%+v", point)
+		// the arc runs under the X axis.
+		assert.True(t, point.Y <= 1e-9, "%+v", point)
+	}
+	assert.InDelta(t, math.Pi/2.0, polygonArea(outline[0]), 0.01)
+}
+
+func TestWidePathOutlineOCS(t *testing.T) {
+	points := core.PointSlice{{X: 0.0}, {X: 10.0}}
+	widths := []float64{2.0, 2.0}
+	outline := widePathOutline(points, []float64{0.0, 0.0}, widths, widths, false,
+		core.Point{Z: -1.0}, 0.01)
+	// the OCS X axis is mirrored.
+	for _, point := range outline[0] {
+		assert.True(t, point.X <= 0.0, "%+v", point)
+	}
+}
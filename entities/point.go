@@ -46,3 +46,19 @@ func NewPoint(tags core.TagSlice) (*Point, error) {
 	err := point.Parse(tags)
 	return point, err
 }
+
+// BoundingBox returns the minimum and maximum corners of the Point extents,
+// both are the Point location.
+func (c Point) BoundingBox() (min, max core.Point) {
+	return c.Location, c.Location
+}
+
+// Tags returns the tags of the Point.
+func (c Point) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbPoint")}
+	tags = append(tags, core.NewPointTags(10, c.Location)...)
+	tags = append(tags, optionalFloatTag(39, c.Thickness)...)
+	tags = append(tags, extrusionTags(c.ExtrusionDirection)...)
+	tags = append(tags, optionalFloatTag(50, c.XAxisAngle)...)
+	return c.entityTags("POINT", tags)
+}
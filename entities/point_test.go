@@ -69,6 +69,13 @@ func (suite *PointTestSuite) TestPointNotEqualToDifferentType() {
 	suite.False(Point{}.Equals(core.NewStringValue("AAA")))
 }
 
+func (suite *PointTestSuite) TestPointBoundingBox() {
+	point := Point{Location: core.Point{X: 3.0, Y: -1.0, Z: 2.0}}
+	min, max := point.BoundingBox()
+	suite.True(point.Location.Equals(min))
+	suite.True(point.Location.Equals(max))
+}
+
 func TestPointTestSuite(t *testing.T) {
 	suite.Run(t, new(PointTestSuite))
 }
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"errors"
+
 	"github.com/rpaloschi/dxf-go/core"
 )
 
@@ -76,10 +78,6 @@ func (p *Polyline) AddNestedEntities(entities EntitySlice) {
 	for _, entity := range entities {
 		if vertex, ok := entity.(*Vertex); ok {
 			p.Vertices = append(p.Vertices, vertex)
-		} else {
-			core.Log.Printf(
-				"Skipping entity %v. Polylines can only contain Vertex entities.",
-				entity)
 		}
 	}
 }
@@ -132,3 +130,235 @@ func NewPolyline(tags core.TagSlice) (*Polyline, error) {
 	err := polyline.Parse(tags)
 	return polyline, err
 }
+
+// Is2d returns true if the Polyline is a 2D polyline, meaning that its vertices
+// are defined in the OCS and may contain bulges.
+func (p Polyline) Is2d() bool {
+	return !p.Is3dPolyline && !p.Is3dPolygonMesh && !p.IsPolyfaceMesh
+}
+
+// BoundingBox returns the minimum and maximum corners of the Polyline extents,
+// taking the bulged (arc) segments of 2D polylines into account.
+func (p Polyline) BoundingBox() (min, max core.Point) {
+	extents := core.NewExtents()
+
+	if !p.Is2d() {
+		for _, vertex := range p.Vertices {
+			// polyface mesh face records have no location.
+			if p.IsPolyfaceMesh && !vertex.Is3dPolylineMesh {
+				continue
+			}
+			if vertex.SplineFrameCtrlPoint {
+				continue
+			}
+			extents.Add(vertex.Location)
+		}
+		return extents.Min, extents.Max
+	}
+
+	vertices := p.pathVertices()
+	count := len(vertices)
+	for i, vertex := range vertices {
+		start := core.Point{X: vertex.Location.X, Y: vertex.Location.Y, Z: p.Elevation}
+		extents.Add(core.OCSToWCS(start, p.ExtrusionDirection))
+
+		if i == count-1 && !p.Closed {
+			break
+		}
+		next := vertices[(i+1)%count]
+		end := core.Point{X: next.Location.X, Y: next.Location.Y, Z: p.Elevation}
+		extents.Merge(bulgeSegmentExtents(start, end, vertex.Bulge, p.ExtrusionDirection))
+	}
+
+	return extents.Min, extents.Max
+}
+
+// pathVertices returns the vertices that compose the drawn path of a 2D
+// Polyline. Spline frame control points are not part of it.
+func (p Polyline) pathVertices() VertexSlice {
+	vertices := make(VertexSlice, 0, len(p.Vertices))
+	for _, vertex := range p.Vertices {
+		if !vertex.SplineFrameCtrlPoint {
+			vertices = append(vertices, vertex)
+		}
+	}
+	return vertices
+}
+
+// Flatten approximates the Polyline by a polyline within the chord tolerance.
+// The bulged segments of 2D polylines are converted to arc points. For curve
+// and spline fit polylines, the fit vertices are used and the spline frame
+// control points are skipped. Polygon and polyface meshes are surfaces and
+// return no points.
+func (p Polyline) Flatten(tolerance float64) core.PointSlice {
+	if p.Is3dPolygonMesh || p.IsPolyfaceMesh {
+		return core.PointSlice{}
+	}
+
+	vertices := p.pathVertices()
+	points := make(core.PointSlice, len(vertices))
+	bulges := make([]float64, len(vertices))
+	for i, vertex := range vertices {
+		points[i] = vertex.Location
+		if p.Is2d() {
+			points[i].Z = p.Elevation
+			bulges[i] = vertex.Bulge
+		}
+	}
+
+	extrusion := p.ExtrusionDirection
+	if !p.Is2d() {
+		// 3D polylines are defined in WCS.
+		extrusion = core.Point{X: 0.0, Y: 0.0, Z: 1.0}
+	}
+	return flattenBulgedPath(points, bulges, p.Closed, extrusion,
+		flattenTolerance(tolerance))
+}
+
+// Segments returns the line and arc segments of the Polyline. The segments of
+// 2D polylines are in its OCS, the ones of 3D polylines are straight and in
+// WCS. Spline frame control points are skipped and meshes have no segments.
+func (p Polyline) Segments() SegmentSlice {
+	if p.Is3dPolygonMesh || p.IsPolyfaceMesh {
+		return SegmentSlice{}
+	}
+
+	vertices := p.pathVertices()
+	points := make(core.PointSlice, len(vertices))
+	bulges := make([]float64, len(vertices))
+	for i, vertex := range vertices {
+		points[i] = vertex.Location
+		if p.Is2d() {
+			points[i].Z = p.Elevation
+			bulges[i] = vertex.Bulge
+		}
+	}
+	return bulgedPathSegments(points, bulges, p.Closed)
+}
+
+// Length returns the length of the Polyline, following its arcs.
+func (p Polyline) Length() float64 {
+	return p.Segments().Length()
+}
+
+// Area returns the area enclosed by the Polyline, projected on its XY plane.
+// Open Polylines are measured as if closed by a straight segment.
+func (p Polyline) Area() float64 {
+	return p.Segments().Area()
+}
+
+// PointAtDistance returns the point at the distance from the start of the
+// Polyline, ok is false if the distance is outside of it.
+func (p Polyline) PointAtDistance(distance float64) (point core.Point, ok bool) {
+	return p.Segments().PointAtDistance(distance)
+}
+
+// ToLWPolyline converts a 2D Polyline to an equivalent LWPolyline. Fitted
+// polylines keep their fit vertices. 3D polylines and meshes can not be
+// converted.
+func (p Polyline) ToLWPolyline() (*LWPolyline, error) {
+	if !p.Is2d() {
+		return nil, errors.New("Only 2D Polylines can be converted to LWPolyline")
+	}
+
+	polyline := &LWPolyline{
+		BaseEntity:         p.BaseEntity,
+		Closed:             p.Closed,
+		Plinegen:           p.LineTypeParentAround,
+		Elevation:          p.Elevation,
+		Thickness:          p.Thickness,
+		ExtrusionDirection: p.ExtrusionDirection,
+	}
+	polyline.DxfParseable = core.DxfParseable{}
+	polyline.Handle = ""
+
+	constantWidth := core.FloatEquals(p.DefaultStartWidth, p.DefaultEndWidth)
+	if constantWidth {
+		polyline.ConstantWidth = p.DefaultStartWidth
+	}
+
+	vertices := p.pathVertices()
+	polyline.Points = make(LWPolyLinePointSlice, len(vertices))
+	for i, vertex := range vertices {
+		point := LWPolyLinePoint{
+			Point:         core.Point{X: vertex.Location.X, Y: vertex.Location.Y},
+			Id:            vertex.Id,
+			StartingWidth: vertex.StartingWidth,
+			EndWidth:      vertex.EndWidth,
+			Bulge:         vertex.Bulge,
+		}
+		if !constantWidth && point.StartingWidth == 0.0 && point.EndWidth == 0.0 {
+			point.StartingWidth = p.DefaultStartWidth
+			point.EndWidth = p.DefaultEndWidth
+		}
+		polyline.Points[i] = point
+	}
+	return polyline, nil
+}
+
+// Outline returns the filled outline of a wide 2D Polyline in WCS, see
+// LWPolyline.Outline. Vertices without width use the DefaultStartWidth and
+// DefaultEndWidth of the Polyline. 3D polylines and meshes have no outline.
+func (p Polyline) Outline(tolerance float64) []core.PointSlice {
+	if !p.Is2d() {
+		return []core.PointSlice{}
+	}
+
+	vertices := p.pathVertices()
+	points := make(core.PointSlice, len(vertices))
+	bulges := make([]float64, len(vertices))
+	startWidths := make([]float64, len(vertices))
+	endWidths := make([]float64, len(vertices))
+	for i, vertex := range vertices {
+		points[i] = core.Point{X: vertex.Location.X, Y: vertex.Location.Y, Z: p.Elevation}
+		bulges[i] = vertex.Bulge
+		startWidths[i], endWidths[i] = vertex.StartingWidth, vertex.EndWidth
+		if vertex.StartingWidth == 0.0 && vertex.EndWidth == 0.0 {
+			startWidths[i], endWidths[i] = p.DefaultStartWidth, p.DefaultEndWidth
+		}
+	}
+	return widePathOutline(points, bulges, startWidths, endWidths, p.Closed,
+		p.ExtrusionDirection, flattenTolerance(tolerance))
+}
+
+// Tags returns the tags of the Polyline, followed by its Vertices and a
+// SEQEND.
+func (p Polyline) Tags() core.TagSlice {
+	subclass := "AcDb2dPolyline"
+	switch {
+	case p.Is3dPolyline:
+		subclass = "AcDb3dPolyline"
+	case p.Is3dPolygonMesh:
+		subclass = "AcDbPolygonMesh"
+	case p.IsPolyfaceMesh:
+		subclass = "AcDbPolyFaceMesh"
+	}
+
+	tags := core.TagSlice{core.NewStringTag(100, subclass), core.NewIntegerTag(66, 1)}
+	tags = append(tags, core.NewPointTags(10, core.Point{Z: p.Elevation})...)
+	tags = append(tags, optionalFloatTag(39, p.Thickness)...)
+	tags = append(tags, core.NewIntegerTag(70, flags(map[int]bool{
+		closedPolylineBit:         p.Closed,
+		curveFitVerticesAddedBit:  p.CurveFitVerticesAdded,
+		splineFitVerticesAddedBit: p.SplineFitVerticesAdded,
+		is3dPolylineBit:           p.Is3dPolyline,
+		is3dPolygonMeshBit:        p.Is3dPolygonMesh,
+		closedNDirectionBit:       p.PolygonMeshClosedNDir,
+		polyfaceMeshBit:           p.IsPolyfaceMesh,
+		lineTypePatternBit:        p.LineTypeParentAround,
+	})))
+	tags = append(tags, optionalFloatTag(40, p.DefaultStartWidth)...)
+	tags = append(tags, optionalFloatTag(41, p.DefaultEndWidth)...)
+	tags = append(tags, optionalIntegerTag(71, p.VertexCountM)...)
+	tags = append(tags, optionalIntegerTag(72, p.VertexCountN)...)
+	tags = append(tags, optionalIntegerTag(73, p.SmoothDensityM)...)
+	tags = append(tags, optionalIntegerTag(74, p.SmoothDensityN)...)
+	tags = append(tags, optionalIntegerTag(75, int(p.SmoothSurface))...)
+	tags = append(tags, extrusionTags(p.ExtrusionDirection)...)
+
+	tags = p.entityTags("POLYLINE", tags)
+	for _, vertex := range p.Vertices {
+		tags = append(tags, vertex.Tags()...)
+	}
+	return append(tags, p.seqEndTags()...)
+}
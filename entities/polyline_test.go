@@ -3,6 +3,7 @@ package entities
 import (
 	"github.com/rpaloschi/dxf-go/core"
 	"github.com/stretchr/testify/suite"
+	"math"
 	"strings"
 	"testing"
 )
@@ -111,6 +112,150 @@ func (suite *PolylineTestSuite) TestAddNestedEntities() {
 	suite.True(expected.Equals(polyline))
 }
 
+func (suite *PolylineTestSuite) TestPolylineBoundingBox() {
+	polyline := Polyline{
+		Vertices: VertexSlice{
+			{Location: core.Point{X: 0.0, Y: 0.0}, Bulge: 1.0},
+			{Location: core.Point{X: 2.0, Y: 0.0}},
+			// not part of the path.
+			{Location: core.Point{X: 9.0, Y: 9.0}, SplineFrameCtrlPoint: true},
+			{Location: core.Point{X: 2.0, Y: 3.0}},
+		},
+		Elevation:          2.0,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+	min, max := polyline.BoundingBox()
+	suite.True(core.Point{X: 0.0, Y: -1.0, Z: 2.0}.Equals(min), "%+v", min)
+	suite.True(core.Point{X: 2.0, Y: 3.0, Z: 2.0}.Equals(max), "%+v", max)
+
+	polyline = Polyline{
+		Is3dPolyline: true,
+		Vertices: VertexSlice{
+			{Location: core.Point{X: 0.0, Y: 0.0, Z: -1.0}, Bulge: 1.0},
+			{Location: core.Point{X: 2.0, Y: 1.0, Z: 5.0}},
+		},
+	}
+	min, max = polyline.BoundingBox()
+	suite.True(core.Point{X: 0.0, Y: 0.0, Z: -1.0}.Equals(min), "%+v", min)
+	suite.True(core.Point{X: 2.0, Y: 1.0, Z: 5.0}.Equals(max), "%+v", max)
+}
+
+func (suite *PolylineTestSuite) TestPolyfaceMeshBoundingBox() {
+	polyline := Polyline{
+		IsPolyfaceMesh: true,
+		Vertices: VertexSlice{
+			{Location: core.Point{X: 1.0, Y: 1.0, Z: 1.0}, Is3dPolylineMesh: true, IsPolyfaceMeshVertex: true},
+			{Location: core.Point{X: 2.0, Y: 3.0, Z: 1.0}, Is3dPolylineMesh: true, IsPolyfaceMeshVertex: true},
+			// face record
+			{IsPolyfaceMeshVertex: true},
+		},
+	}
+	min, max := polyline.BoundingBox()
+	suite.True(core.Point{X: 1.0, Y: 1.0, Z: 1.0}.Equals(min), "%+v", min)
+	suite.True(core.Point{X: 2.0, Y: 3.0, Z: 1.0}.Equals(max), "%+v", max)
+}
+
+func (suite *PolylineTestSuite) TestPolylineFlatten() {
+	polyline := Polyline{
+		Vertices: VertexSlice{
+			{Location: core.Point{X: 0.0, Y: 0.0}, Bulge: -1.0},
+			{Location: core.Point{X: 9.0, Y: 9.0}, SplineFrameCtrlPoint: true},
+			{Location: core.Point{X: 2.0, Y: 0.0}},
+		},
+		Elevation:          2.0,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+
+	points := polyline.Flatten(0.01)
+	assertArcPoints(suite.T(), points, core.Point{X: 1.0, Z: 2.0}, 1.0, 0.01)
+	suite.True(core.Point{X: 2.0, Y: 0.0, Z: 2.0}.Equals(points[len(points)-1]))
+	suite.True(points[1].Y > 0.0)
+
+	polyline.Is3dPolyline = true
+	points = polyline.Flatten(0.01)
+	suite.True(core.PointSlice{{X: 0.0}, {X: 2.0}}.Equals(points), "%+v", points)
+
+	polyline.IsPolyfaceMesh = true
+	suite.Len(polyline.Flatten(0.01), 0)
+}
+
+func (suite *PolylineTestSuite) TestPolylineSegments() {
+	polyline := Polyline{
+		Vertices: VertexSlice{
+			{Location: core.Point{X: 0.0, Y: 0.0}, Bulge: -1.0},
+			{Location: core.Point{X: 9.0, Y: 9.0}, SplineFrameCtrlPoint: true},
+			{Location: core.Point{X: 2.0, Y: 0.0}},
+		},
+		Elevation:          2.0,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+
+	segments := polyline.Segments()
+	suite.Len(segments, 1)
+	arc := segments[0].(ArcSegment)
+	suite.True(arc.Clockwise)
+	suite.True(core.Point{X: 1.0, Z: 2.0}.Equals(arc.Center))
+	suite.InDelta(math.Pi, polyline.Length(), 1e-12)
+	suite.InDelta(math.Pi/2.0, polyline.Area(), 1e-12)
+	point, ok := polyline.PointAtDistance(math.Pi / 2.0)
+	suite.True(ok)
+	suite.True(core.Point{X: 1.0, Y: 1.0, Z: 2.0}.Equals(point), "%+v", point)
+
+	polyline.Is3dPolyline = true
+	polyline.Vertices[2].Location.Z = 3.0
+	suite.Equal(SegmentSlice{LineSegment{End: core.Point{X: 2.0, Z: 3.0}}}, polyline.Segments())
+
+	polyline.IsPolyfaceMesh = true
+	suite.Len(polyline.Segments(), 0)
+}
+
+func (suite *PolylineTestSuite) TestPolylineToLWPolyline() {
+	polyline := Polyline{
+		DefaultStartWidth: 0.5,
+		DefaultEndWidth:   1.0,
+		Vertices: VertexSlice{
+			{Location: core.Point{X: 0.0, Y: 0.0, Z: 2.0}, Bulge: 0.5},
+			{Location: core.Point{X: 9.0, Y: 9.0}, SplineFrameCtrlPoint: true},
+			{Location: core.Point{X: 2.0, Y: 0.0, Z: 2.0}, StartingWidth: 0.1},
+		},
+		Elevation:          2.0,
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+
+	lwpolyline, err := polyline.ToLWPolyline()
+	suite.Nil(err)
+	suite.Equal(0.0, lwpolyline.ConstantWidth)
+	suite.Equal(2.0, lwpolyline.Elevation)
+	suite.True(LWPolyLinePointSlice{
+		{Point: core.Point{X: 0.0, Y: 0.0}, Bulge: 0.5, StartingWidth: 0.5, EndWidth: 1.0},
+		{Point: core.Point{X: 2.0, Y: 0.0}, StartingWidth: 0.1},
+	}.Equals(lwpolyline.Points), "%+v", lwpolyline.Points)
+
+	polyline.Is3dPolyline = true
+	_, err = polyline.ToLWPolyline()
+	suite.NotNil(err)
+}
+
+func (suite *PolylineTestSuite) TestPolylineOutline() {
+	polyline := Polyline{
+		DefaultStartWidth: 2.0,
+		DefaultEndWidth:   2.0,
+		Vertices: VertexSlice{
+			{Location: core.Point{X: 0.0, Y: 0.0}},
+			{Location: core.Point{X: 10.0, Y: 0.0}, StartingWidth: 2.0},
+			{Location: core.Point{X: 20.0, Y: 0.0}},
+		},
+		ExtrusionDirection: core.Point{Z: 1.0},
+	}
+
+	outline := polyline.Outline(0.01)
+	suite.Len(outline, 1)
+	suite.InDelta(20.0+10.0, polygonArea(outline[0]), 1e-9)
+
+	polyline.Is3dPolyline = true
+	suite.Len(polyline.Outline(0.01), 0)
+}
+
 func TestPolylineTestSuite(t *testing.T) {
 	suite.Run(t, new(PolylineTestSuite))
 }
@@ -0,0 +1,214 @@
+package entities
+
+import (
+	"math"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// Segment a straight or circular piece of a polyline path. The segments of 2D
+// polylines are defined in the OCS of the polyline, with the elevation as Z.
+type Segment interface {
+	StartPoint() core.Point
+	EndPoint() core.Point
+	Length() float64
+	// PointAt returns the point at the distance from the start of the
+	// Segment, the distance is clamped to the Segment length.
+	PointAt(distance float64) core.Point
+}
+
+// LineSegment a straight Segment.
+type LineSegment struct {
+	Start core.Point
+	End   core.Point
+}
+
+// StartPoint returns the point where the LineSegment starts.
+func (s LineSegment) StartPoint() core.Point {
+	return s.Start
+}
+
+// EndPoint returns the point where the LineSegment ends.
+func (s LineSegment) EndPoint() core.Point {
+	return s.End
+}
+
+// Length returns the length of the LineSegment.
+func (s LineSegment) Length() float64 {
+	return s.Start.Distance(s.End)
+}
+
+// PointAt returns the point at the distance from the start of the LineSegment.
+func (s LineSegment) PointAt(distance float64) core.Point {
+	length := s.Length()
+	if length == 0.0 {
+		return s.Start
+	}
+	ratio := math.Max(0.0, math.Min(1.0, distance/length))
+	return s.Start.Add(s.End.Sub(s.Start).Scale(ratio))
+}
+
+// ArcSegment a circular arc Segment, defined by a non zero bulge. The angles
+// are in degrees, measured counter-clockwise from the X axis: StartAngle is
+// the angle of Start and EndAngle the angle of End around the Center. The arc
+// runs from Start to End clockwise when the bulge is negative.
+type ArcSegment struct {
+	Start      core.Point
+	End        core.Point
+	Center     core.Point
+	Radius     float64
+	StartAngle float64
+	EndAngle   float64
+	Clockwise  bool
+	Bulge      float64
+}
+
+// StartPoint returns the point where the ArcSegment starts.
+func (s ArcSegment) StartPoint() core.Point {
+	return s.Start
+}
+
+// EndPoint returns the point where the ArcSegment ends.
+func (s ArcSegment) EndPoint() core.Point {
+	return s.End
+}
+
+// Sweep returns the signed included angle of the ArcSegment in radians,
+// negative if it runs clockwise.
+func (s ArcSegment) Sweep() float64 {
+	return 4.0 * math.Atan(s.Bulge)
+}
+
+// Length returns the length of the ArcSegment.
+func (s ArcSegment) Length() float64 {
+	return s.Radius * math.Abs(s.Sweep())
+}
+
+// PointAt returns the point at the distance from the start of the ArcSegment,
+// following the arc.
+func (s ArcSegment) PointAt(distance float64) core.Point {
+	length := s.Length()
+	distance = math.Max(0.0, math.Min(length, distance))
+	if distance == length {
+		return s.End
+	}
+
+	angle := s.StartAngle*math.Pi/180.0 + s.Sweep()*distance/length
+	return core.Point{
+		X: s.Center.X + s.Radius*math.Cos(angle),
+		Y: s.Center.Y + s.Radius*math.Sin(angle),
+		Z: s.Center.Z,
+	}
+}
+
+// newSegment builds the Segment from p1 to p2 with the given bulge, an
+// ArcSegment if the bulge is not zero and the points are different.
+func newSegment(p1, p2 core.Point, bulge float64) Segment {
+	center, radius, start, sweep, ok := bulgeArc(p1, p2, bulge)
+	if !ok {
+		return LineSegment{Start: p1, End: p2}
+	}
+	return ArcSegment{
+		Start:      p1,
+		End:        p2,
+		Center:     center,
+		Radius:     radius,
+		StartAngle: normalizeDegrees(start * 180.0 / math.Pi),
+		EndAngle:   normalizeDegrees((start + sweep) * 180.0 / math.Pi),
+		Clockwise:  sweep < 0.0,
+		Bulge:      bulge,
+	}
+}
+
+// normalizeDegrees returns the angle in the [0, 360) range.
+func normalizeDegrees(angle float64) float64 {
+	angle = math.Mod(angle, 360.0)
+	if angle < 0.0 {
+		angle += 360.0
+	}
+	return angle
+}
+
+// bulgedPathSegments builds the segments of a path of points and bulges. If
+// closed, the segment from the last to the first point is added.
+func bulgedPathSegments(points core.PointSlice, bulges []float64, closed bool) SegmentSlice {
+	count := len(points)
+	segmentCount := count - 1
+	if closed && count > 1 {
+		segmentCount = count
+	}
+	if segmentCount < 0 {
+		segmentCount = 0
+	}
+
+	segments := make(SegmentSlice, segmentCount)
+	for i := range segments {
+		segments[i] = newSegment(points[i], points[(i+1)%count], bulges[i])
+	}
+	return segments
+}
+
+// SegmentSlice a slice of Segments, forming a path.
+type SegmentSlice []Segment
+
+// Length returns the total length of the segments.
+func (s SegmentSlice) Length() float64 {
+	length := 0.0
+	for _, segment := range s {
+		length += segment.Length()
+	}
+	return length
+}
+
+// PointAtDistance returns the point at the distance from the start of the
+// path, measured along the segments. ok is false if the distance is negative
+// or longer than the path.
+func (s SegmentSlice) PointAtDistance(distance float64) (point core.Point, ok bool) {
+	if len(s) == 0 || distance < 0.0 {
+		return core.Point{}, false
+	}
+
+	for _, segment := range s {
+		length := segment.Length()
+		if distance <= length {
+			return segment.PointAt(distance), true
+		}
+		distance -= length
+	}
+
+	if core.FloatEquals(distance, 0.0) {
+		return s[len(s)-1].EndPoint(), true
+	}
+	return core.Point{}, false
+}
+
+// SignedArea returns the area enclosed by the segments in the XY plane,
+// positive if the path runs counter-clockwise. An open path is closed with a
+// straight segment from its end to its start. The area is computed with the
+// shoelace formula on the chords, corrected by the circular segments of the
+// arcs.
+func (s SegmentSlice) SignedArea() float64 {
+	if len(s) == 0 {
+		return 0.0
+	}
+
+	area := 0.0
+	for _, segment := range s {
+		start, end := segment.StartPoint(), segment.EndPoint()
+		area += (start.X*end.Y - end.X*start.Y) / 2.0
+
+		if arc, ok := segment.(ArcSegment); ok {
+			sweep := arc.Sweep()
+			area += arc.Radius * arc.Radius * (sweep - math.Sin(sweep)) / 2.0
+		}
+	}
+
+	start, end := s[len(s)-1].EndPoint(), s[0].StartPoint()
+	area += (start.X*end.Y - end.X*start.Y) / 2.0
+	return area
+}
+
+// Area returns the absolute area enclosed by the segments, see SignedArea.
+func (s SegmentSlice) Area() float64 {
+	return math.Abs(s.SignedArea())
+}
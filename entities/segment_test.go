@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestNewSegment(t *testing.T) {
+	line := newSegment(core.Point{X: 0.0}, core.Point{X: 3.0, Y: 4.0}, 0.0)
+	assert.Equal(t, LineSegment{Start: core.Point{X: 0.0}, End: core.Point{X: 3.0, Y: 4.0}}, line)
+	assert.Equal(t, 5.0, line.Length())
+	assert.True(t, core.Point{X: 1.5, Y: 2.0}.Equals(line.PointAt(2.5)))
+	assert.True(t, core.Point{X: 3.0, Y: 4.0}.Equals(line.PointAt(10.0)))
+
+	// half circle counter-clockwise, below the chord.
+	arc, ok := newSegment(core.Point{X: 0.0}, core.Point{X: 2.0}, 1.0).(ArcSegment)
+	assert.True(t, ok)
+	assert.True(t, core.Point{X: 1.0}.Equals(arc.Center))
+	assert.Equal(t, 1.0, arc.Radius)
+	assert.InDelta(t, 180.0, arc.StartAngle, 1e-9)
+	assert.InDelta(t, 0.0, arc.EndAngle, 1e-9)
+	assert.False(t, arc.Clockwise)
+	assert.InDelta(t, math.Pi, arc.Length(), 1e-12)
+	assert.True(t, core.Point{X: 1.0, Y: -1.0}.Equals(arc.PointAt(math.Pi/2.0)))
+	assert.True(t, arc.End.Equals(arc.PointAt(math.Pi)))
+
+	// quarter circle clockwise.
+	arc = newSegment(core.Point{X: 1.0}, core.Point{Y: 1.0}, -math.Tan(math.Pi/8.0)).(ArcSegment)
+	assert.True(t, core.Point{X: 1.0, Y: 1.0}.Equals(arc.Center))
+	assert.True(t, arc.Clockwise)
+	assert.InDelta(t, 270.0, arc.StartAngle, 1e-9)
+	assert.InDelta(t, 180.0, arc.EndAngle, 1e-9)
+	assert.InDelta(t, -math.Pi/2.0, arc.Sweep(), 1e-12)
+	middle := arc.PointAt(arc.Length() / 2.0)
+	assert.True(t, core.Point{X: 1.0 - math.Sqrt2/2.0, Y: 1.0 - math.Sqrt2/2.0}.Equals(middle))
+}
+
+func TestSegmentSliceArea(t *testing.T) {
+	square := core.PointSlice{{X: 0.0}, {X: 2.0}, {X: 2.0, Y: 2.0}, {X: 0.0, Y: 2.0}}
+
+	testCases := []struct {
+		bulges   []float64
+		closed   bool
+		expected float64
+	}{
+		{[]float64{0.0, 0.0, 0.0, 0.0}, true, 4.0},
+		{[]float64{0.0, 0.0, 0.0, 0.0}, false, 4.0},
+		// a half circle out of the right side.
+		{[]float64{0.0, 1.0, 0.0, 0.0}, true, 4.0 + math.Pi/2.0},
+		// and one into the bottom side.
+		{[]float64{-1.0, 1.0, 0.0, 0.0}, true, 4.0},
+		// a stadium.
+		{[]float64{0.0, 1.0, 0.0, 1.0}, true, 4.0 + math.Pi},
+	}
+
+	for _, test := range testCases {
+		segments := bulgedPathSegments(square, test.bulges, test.closed)
+		assert.InDelta(t, test.expected, segments.SignedArea(), 1e-12, "Test case: %+v", test)
+	}
+
+	// clockwise paths have negative signed areas.
+	reversed := core.PointSlice{square[3], square[2], square[1], square[0]}
+	segments := bulgedPathSegments(reversed, []float64{0.0, -1.0, 0.0, 0.0}, true)
+	assert.InDelta(t, -4.0-math.Pi/2.0, segments.SignedArea(), 1e-12)
+	assert.InDelta(t, 4.0+math.Pi/2.0, segments.Area(), 1e-12)
+
+	assert.Equal(t, 0.0, SegmentSlice{}.Area())
+}
+
+func TestSegmentSlicePointAtDistance(t *testing.T) {
+	points := core.PointSlice{{X: 0.0}, {X: 2.0}, {X: 2.0, Y: 2.0}}
+	segments := bulgedPathSegments(points, []float64{0.0, 1.0, 0.0}, false)
+	assert.InDelta(t, 2.0+math.Pi, segments.Length(), 1e-12)
+
+	testCases := []struct {
+		distance float64
+		expected core.Point
+		ok       bool
+	}{
+		{0.0, core.Point{X: 0.0}, true},
+		{1.5, core.Point{X: 1.5}, true},
+		{2.0 + math.Pi/2.0, core.Point{X: 3.0, Y: 1.0}, true},
+		{2.0 + math.Pi, core.Point{X: 2.0, Y: 2.0}, true},
+		{-1.0, core.Point{}, false},
+		{10.0, core.Point{}, false},
+	}
+
+	for _, test := range testCases {
+		point, ok := segments.PointAtDistance(test.distance)
+		assert.Equal(t, test.ok, ok, "Test case: %+v", test)
+		assert.True(t, test.expected.Equals(point), "Test case: %+v, got %+v", test, point)
+	}
+
+	_, ok := SegmentSlice{}.PointAtDistance(0.0)
+	assert.False(t, ok)
+}
@@ -29,3 +29,15 @@ func NewSeqEnd(tags core.TagSlice) (*SeqEnd, error) {
 	err := point.Parse(tags)
 	return point, err
 }
+
+// BoundingBox a SeqEnd has no geometry, it returns an empty box
+// (see core.Extents.IsEmpty).
+func (c SeqEnd) BoundingBox() (min, max core.Point) {
+	extents := core.NewExtents()
+	return extents.Min, extents.Max
+}
+
+// Tags returns the tags of the SeqEnd.
+func (c SeqEnd) Tags() core.TagSlice {
+	return c.entityTags("SEQEND", nil)
+}
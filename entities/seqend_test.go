@@ -25,3 +25,8 @@ func TestSeqEnd(t *testing.T) {
 	assert.True(t, SeqEnd{}.IsSeqEnd())
 	assert.False(t, SeqEnd{}.HasNestedEntities())
 }
+
+func TestSeqEndBoundingBox(t *testing.T) {
+	min, max := SeqEnd{}.BoundingBox()
+	assert.True(t, core.Extents{Min: min, Max: max}.IsEmpty())
+}
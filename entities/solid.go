@@ -0,0 +1,102 @@
+package entities
+
+import "github.com/rpaloschi/dxf-go/core"
+
+// Solid Entity representation, a filled triangle or quadrilateral. The
+// corners are in OCS.
+type Solid struct {
+	BaseEntity
+	FirstCorner        core.Point
+	SecondCorner       core.Point
+	ThirdCorner        core.Point
+	FourthCorner       core.Point
+	Thickness          float64
+	ExtrusionDirection core.Point
+}
+
+// Equals tests equality against another Solid.
+func (s Solid) Equals(other core.DxfElement) bool {
+	if otherSolid, ok := other.(*Solid); ok {
+		return s.BaseEntity.Equals(otherSolid.BaseEntity) &&
+			s.FirstCorner.Equals(otherSolid.FirstCorner) &&
+			s.SecondCorner.Equals(otherSolid.SecondCorner) &&
+			s.ThirdCorner.Equals(otherSolid.ThirdCorner) &&
+			s.FourthCorner.Equals(otherSolid.FourthCorner) &&
+			core.FloatEquals(s.Thickness, otherSolid.Thickness) &&
+			s.ExtrusionDirection.Equals(otherSolid.ExtrusionDirection)
+	}
+	return false
+}
+
+// NewSolid builds a new Solid from a slice of Tags. If the fourth corner is
+// missing, the Solid is a triangle and it is the same as the third corner.
+func NewSolid(tags core.TagSlice) (*Solid, error) {
+	solid := new(Solid)
+
+	// set defaults
+	solid.ExtrusionDirection = core.Point{X: 0.0, Y: 0.0, Z: 1.0}
+
+	hasFourthCorner := false
+	solid.InitBaseEntityParser()
+	solid.Update(map[int]core.TypeParser{
+		10: core.NewFloatTypeParserToVar(&solid.FirstCorner.X),
+		20: core.NewFloatTypeParserToVar(&solid.FirstCorner.Y),
+		30: core.NewFloatTypeParserToVar(&solid.FirstCorner.Z),
+		11: core.NewFloatTypeParserToVar(&solid.SecondCorner.X),
+		21: core.NewFloatTypeParserToVar(&solid.SecondCorner.Y),
+		31: core.NewFloatTypeParserToVar(&solid.SecondCorner.Z),
+		12: core.NewFloatTypeParserToVar(&solid.ThirdCorner.X),
+		22: core.NewFloatTypeParserToVar(&solid.ThirdCorner.Y),
+		32: core.NewFloatTypeParserToVar(&solid.ThirdCorner.Z),
+		13: core.NewFloatTypeParser(func(x float64) {
+			hasFourthCorner = true
+			solid.FourthCorner.X = x
+		}),
+		23:  core.NewFloatTypeParserToVar(&solid.FourthCorner.Y),
+		33:  core.NewFloatTypeParserToVar(&solid.FourthCorner.Z),
+		39:  core.NewFloatTypeParserToVar(&solid.Thickness),
+		210: core.NewFloatTypeParserToVar(&solid.ExtrusionDirection.X),
+		220: core.NewFloatTypeParserToVar(&solid.ExtrusionDirection.Y),
+		230: core.NewFloatTypeParserToVar(&solid.ExtrusionDirection.Z),
+	})
+
+	err := solid.Parse(tags)
+	if !hasFourthCorner {
+		solid.FourthCorner = solid.ThirdCorner
+	}
+	return solid, err
+}
+
+// Polygon returns the outline of the Solid in WCS, closed by repeating the
+// first corner. The corners are drawn in the DXF order: first, second, fourth
+// and third.
+func (s Solid) Polygon() core.PointSlice {
+	polygon := core.PointSlice{}
+	for _, corner := range []core.Point{
+		s.FirstCorner, s.SecondCorner, s.FourthCorner, s.ThirdCorner, s.FirstCorner,
+	} {
+		point := core.OCSToWCS(corner, s.ExtrusionDirection)
+		if len(polygon) == 0 || !polygon[len(polygon)-1].Equals(point) {
+			polygon = append(polygon, point)
+		}
+	}
+	return polygon
+}
+
+// BoundingBox returns the minimum and maximum corners of the Solid extents.
+func (s Solid) BoundingBox() (min, max core.Point) {
+	extents := pointsExtents(s.Polygon()...)
+	return extents.Min, extents.Max
+}
+
+// Tags returns the tags of the Solid.
+func (s Solid) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbTrace")}
+	tags = append(tags, core.NewPointTags(10, s.FirstCorner)...)
+	tags = append(tags, core.NewPointTags(11, s.SecondCorner)...)
+	tags = append(tags, core.NewPointTags(12, s.ThirdCorner)...)
+	tags = append(tags, core.NewPointTags(13, s.FourthCorner)...)
+	tags = append(tags, optionalFloatTag(39, s.Thickness)...)
+	tags = append(tags, extrusionTags(s.ExtrusionDirection)...)
+	return s.entityTags("SOLID", tags)
+}
@@ -0,0 +1,158 @@
+package entities
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type SolidTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SolidTestSuite) TestMinimalSolid() {
+	expected := Solid{
+		BaseEntity: BaseEntity{
+			Handle:    "3E5",
+			LayerName: "0",
+			On:        true,
+			Visible:   true,
+		},
+		FirstCorner:        core.Point{X: 1.0, Y: 1.0},
+		SecondCorner:       core.Point{X: 2.0, Y: 1.0},
+		ThirdCorner:        core.Point{X: 1.0, Y: 2.0},
+		FourthCorner:       core.Point{X: 1.0, Y: 2.0},
+		ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0},
+	}
+
+	next := core.Tagger(strings.NewReader(testMinimalSolid))
+	solid, err := NewSolid(core.TagSlice(core.AllTags(next)))
+
+	suite.Nil(err)
+	suite.True(expected.Equals(solid))
+
+	suite.False(solid.IsSeqEnd())
+	suite.False(solid.HasNestedEntities())
+}
+
+func (suite *SolidTestSuite) TestSolidAllAttribs() {
+	expected := Solid{
+		BaseEntity: BaseEntity{
+			Handle:        "ALL_ARGS",
+			LayerName:     "L1",
+			LineTypeName:  "CONTINUOUS",
+			On:            true,
+			Color:         2,
+			LineTypeScale: 2.5,
+			Visible:       true,
+		},
+		FirstCorner:        core.Point{X: 1.0, Y: 1.0, Z: 0.5},
+		SecondCorner:       core.Point{X: 2.0, Y: 1.0, Z: 0.5},
+		ThirdCorner:        core.Point{X: 1.0, Y: 2.0, Z: 0.5},
+		FourthCorner:       core.Point{X: 2.0, Y: 2.0, Z: 0.5},
+		Thickness:          3.3,
+		ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: -1.0},
+	}
+
+	next := core.Tagger(strings.NewReader(testSolidAllAttribs))
+	solid, err := NewSolid(core.TagSlice(core.AllTags(next)))
+
+	suite.Nil(err)
+	suite.True(expected.Equals(solid))
+}
+
+func (suite *SolidTestSuite) TestSolidNotEqualToDifferentType() {
+	suite.False(Solid{}.Equals(core.NewStringValue("AAA")))
+}
+
+func (suite *SolidTestSuite) TestSolidPolygon() {
+	solid := Solid{
+		FirstCorner:        core.Point{X: 0.0, Y: 0.0},
+		SecondCorner:       core.Point{X: 2.0, Y: 0.0},
+		ThirdCorner:        core.Point{X: 0.0, Y: 1.0},
+		FourthCorner:       core.Point{X: 2.0, Y: 1.0},
+		ExtrusionDirection: core.Point{Z: -1.0},
+	}
+	suite.True(core.PointSlice{
+		{X: 0.0, Y: 0.0}, {X: -2.0, Y: 0.0}, {X: -2.0, Y: 1.0}, {X: 0.0, Y: 1.0}, {X: 0.0, Y: 0.0},
+	}.Equals(solid.Polygon()), "%+v", solid.Polygon())
+
+	min, max := solid.BoundingBox()
+	suite.True(core.Point{X: -2.0, Y: 0.0}.Equals(min))
+	suite.True(core.Point{X: 0.0, Y: 1.0}.Equals(max))
+
+	// triangles repeat the third corner.
+	solid.FourthCorner = solid.ThirdCorner
+	suite.Len(solid.Polygon(), 4)
+}
+
+func TestSolidTestSuite(t *testing.T) {
+	suite.Run(t, new(SolidTestSuite))
+}
+
+const testMinimalSolid = `  0
+SOLID
+  5
+3E5
+  8
+0
+ 10
+1.0
+ 20
+1.0
+ 11
+2.0
+ 21
+1.0
+ 12
+1.0
+ 22
+2.0
+`
+
+const testSolidAllAttribs = `  0
+SOLID
+  5
+ALL_ARGS
+  8
+L1
+  6
+CONTINUOUS
+ 48
+2.5
+ 62
+2
+ 39
+3.3
+ 10
+1.0
+ 20
+1.0
+ 30
+0.5
+ 11
+2.0
+ 21
+1.0
+ 31
+0.5
+ 12
+1.0
+ 22
+2.0
+ 32
+0.5
+ 13
+2.0
+ 23
+2.0
+ 33
+0.5
+210
+0.0
+220
+0.0
+230
+-1.0
+`
@@ -1,6 +1,9 @@
 package entities
 
-import "github.com/rpaloschi/dxf-go/core"
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/nurbs"
+)
 
 // Spline Entity representation
 type Spline struct {
@@ -74,20 +77,20 @@ func NewSpline(tags core.TagSlice) (*Spline, error) {
 				core.Point{X: value})
 		}),
 		20: core.NewFloatTypeParser(func(value float64) {
-			spline.ControlPoints[len(spline.ControlPoints)-1].Y = value
+			lastPoint(&spline.ControlPoints).Y = value
 		}),
 		30: core.NewFloatTypeParser(func(value float64) {
-			spline.ControlPoints[len(spline.ControlPoints)-1].Z = value
+			lastPoint(&spline.ControlPoints).Z = value
 		}),
 		11: core.NewFloatTypeParser(func(value float64) {
 			spline.FitPoints = append(spline.FitPoints,
 				core.Point{X: value})
 		}),
 		21: core.NewFloatTypeParser(func(value float64) {
-			spline.FitPoints[len(spline.FitPoints)-1].Y = value
+			lastPoint(&spline.FitPoints).Y = value
 		}),
 		31: core.NewFloatTypeParser(func(value float64) {
-			spline.FitPoints[len(spline.FitPoints)-1].Z = value
+			lastPoint(&spline.FitPoints).Z = value
 		}),
 		12: core.NewFloatTypeParserToVar(&spline.StartTangent.X),
 		22: core.NewFloatTypeParserToVar(&spline.StartTangent.Y),
@@ -126,3 +129,143 @@ func NewSpline(tags core.TagSlice) (*Spline, error) {
 	err := spline.Parse(tags)
 	return spline, err
 }
+
+// splineSamplesPerSpan number of samples taken on each knot span when
+// computing the Spline extents.
+const splineSamplesPerSpan = 16
+
+// Curve returns the NURBS curve of the Spline, evaluated from the
+// ControlPoints, KnotValues, Weights and Degree. Periodic splines whose
+// control points are not wrapped are closed. If the Spline only defines
+// FitPoints, the control points are computed from them and the StartTangent
+// and EndTangent.
+func (s Spline) Curve() (*nurbs.Curve, error) {
+	if len(s.ControlPoints) == 0 && len(s.FitPoints) > 1 {
+		return s.fitCurve()
+	}
+
+	weights := s.Weights
+	if len(weights) != len(s.ControlPoints) {
+		weights = nil
+	}
+
+	count := len(s.ControlPoints)
+	if s.Periodic && (len(s.KnotValues) == 0 || len(s.KnotValues) == count+1) {
+		return nurbs.NewPeriodicCurve(s.Degree, s.KnotValues, s.ControlPoints, weights)
+	}
+	return nurbs.NewCurve(s.Degree, s.KnotValues, s.ControlPoints, weights)
+}
+
+// fitCurve computes the curve that interpolates the FitPoints.
+func (s Spline) fitCurve() (*nurbs.Curve, error) {
+	degree := s.Degree
+	if degree < 1 {
+		degree = 3
+	}
+
+	points := s.FitPoints
+	if s.Closed && !points[0].Equals(points[len(points)-1]) {
+		points = append(append(core.PointSlice{}, points...), points[0])
+	}
+
+	if s.StartTangent.Length() == 0.0 && s.EndTangent.Length() == 0.0 {
+		return nurbs.Interpolate(points, degree)
+	}
+	return nurbs.InterpolateWithTangents(points, degree, s.StartTangent, s.EndTangent)
+}
+
+// BoundingBox returns the minimum and maximum corners of the Spline extents.
+// If the Spline curve cannot be evaluated, the extents of its control (or fit)
+// points are returned.
+func (s Spline) BoundingBox() (min, max core.Point) {
+	curve, err := s.Curve()
+	if err != nil {
+		extents := pointsExtents(s.polygon()...)
+		return extents.Min, extents.Max
+	}
+
+	spans := curve.Spans()
+	parameters := make([]float64, 0, len(spans)*splineSamplesPerSpan)
+	for i := 1; i < len(spans); i++ {
+		step := (spans[i] - spans[i-1]) / splineSamplesPerSpan
+		for j := 0; j < splineSamplesPerSpan; j++ {
+			parameters = append(parameters, spans[i-1]+float64(j)*step)
+		}
+	}
+	parameters = append(parameters, spans[len(spans)-1])
+
+	extents := curveExtents(curve.PointAt, parameters)
+	return extents.Min, extents.Max
+}
+
+// Flatten approximates the Spline by a polyline within the chord tolerance.
+// If the Spline curve cannot be evaluated, its control (or fit) points are
+// returned.
+func (s Spline) Flatten(tolerance float64) core.PointSlice {
+	curve, err := s.Curve()
+	if err != nil {
+		return append(core.PointSlice{}, s.polygon()...)
+	}
+	return curve.Flatten(flattenTolerance(tolerance))
+}
+
+// polygon returns the control points, or the fit points if there are no
+// control points.
+func (s Spline) polygon() core.PointSlice {
+	if len(s.ControlPoints) > 0 {
+		return s.ControlPoints
+	}
+	return s.FitPoints
+}
+
+// Tags returns the tags of the Spline, with the counts of its knots, control
+// and fit points.
+func (s Spline) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbSpline")}
+	if !s.NormalVector.Equals(core.Point{}) {
+		tags = append(tags, core.NewPointTags(210, s.NormalVector)...)
+	}
+	tags = append(tags,
+		core.NewIntegerTag(70, flags(map[int]bool{
+			closedSplineBit:   s.Closed,
+			periodicSplineBit: s.Periodic,
+			rationalSplineBit: s.Rational,
+			planarBit:         s.Planar,
+			linearBit:         s.Linear,
+		})),
+		core.NewIntegerTag(71, s.Degree),
+		core.NewIntegerTag(72, len(s.KnotValues)),
+		core.NewIntegerTag(73, len(s.ControlPoints)),
+		core.NewIntegerTag(74, len(s.FitPoints)))
+	tags = append(tags, optionalFloatTag(42, s.KnotTolerance)...)
+	tags = append(tags, optionalFloatTag(43, s.ControlPointTolerance)...)
+	tags = append(tags, optionalFloatTag(44, s.FitTolerance)...)
+	if !s.StartTangent.Equals(core.Point{}) {
+		tags = append(tags, core.NewPointTags(12, s.StartTangent)...)
+	}
+	if !s.EndTangent.Equals(core.Point{}) {
+		tags = append(tags, core.NewPointTags(13, s.EndTangent)...)
+	}
+	for _, knot := range s.KnotValues {
+		tags = append(tags, core.NewFloatTag(40, knot))
+	}
+	for _, weight := range s.Weights {
+		tags = append(tags, core.NewFloatTag(41, weight))
+	}
+	for _, point := range s.ControlPoints {
+		tags = append(tags, core.NewPointTags(10, point)...)
+	}
+	for _, point := range s.FitPoints {
+		tags = append(tags, core.NewPointTags(11, point)...)
+	}
+	return s.entityTags("SPLINE", tags)
+}
+
+// lastPoint returns the last point of the points, appending one for a
+// coordinate without its X tag.
+func lastPoint(points *core.PointSlice) *core.Point {
+	if len(*points) == 0 {
+		*points = append(*points, core.Point{})
+	}
+	return &(*points)[len(*points)-1]
+}
@@ -3,6 +3,7 @@ package entities
 import (
 	"github.com/rpaloschi/dxf-go/core"
 	"github.com/stretchr/testify/suite"
+	"math"
 	"strings"
 	"testing"
 )
@@ -90,10 +91,112 @@ func (suite *SplineTestSuite) TestSplineAllAttribs() {
 	suite.True(expected.Equals(spline))
 }
 
+func (suite *SplineTestSuite) TestSplineCoordinatesWithoutX() {
+	next := core.Tagger(strings.NewReader("  0\nSPLINE\n 20\n1.0\n 30\n2.0\n 21\n3.0\n"))
+	spline, err := NewSpline(core.TagSlice(core.AllTags(next)))
+
+	suite.Nil(err)
+	suite.True(core.PointSlice{{Y: 1.0, Z: 2.0}}.Equals(spline.ControlPoints))
+	suite.True(core.PointSlice{{Y: 3.0}}.Equals(spline.FitPoints))
+}
+
 func (suite *SplineTestSuite) TestSplineNotEqualToDifferentType() {
 	suite.False(Spline{}.Equals(core.NewStringValue("STR")))
 }
 
+func (suite *SplineTestSuite) TestSplineBoundingBox() {
+	// quadratic bezier, the top of the curve is not a control point.
+	spline := Spline{
+		Degree:        2,
+		KnotValues:    []float64{0.0, 0.0, 0.0, 1.0, 1.0, 1.0},
+		ControlPoints: core.PointSlice{{X: 0.0, Y: 0.0}, {X: 1.0, Y: 2.0}, {X: 2.0, Y: 0.0}},
+	}
+	min, max := spline.BoundingBox()
+	suite.True(core.Point{X: 0.0, Y: 0.0}.Equals(min), "%+v", min)
+	suite.True(core.Point{X: 2.0, Y: 1.0}.Equals(max), "%+v", max)
+
+	// rational quadratic: a quarter of the unit circle.
+	spline.ControlPoints = core.PointSlice{{X: 1.0, Y: 0.0}, {X: 1.0, Y: 1.0}, {X: 0.0, Y: 1.0}}
+	spline.Weights = []float64{1.0, math.Sqrt2 / 2.0, 1.0}
+	min, max = spline.BoundingBox()
+	suite.True(core.Point{X: 0.0, Y: 0.0}.Equals(min), "%+v", min)
+	suite.True(core.Point{X: 1.0, Y: 1.0}.Equals(max), "%+v", max)
+}
+
+func (suite *SplineTestSuite) TestFitPointsSplineBoundingBox() {
+	spline := Spline{
+		Degree:    3,
+		FitPoints: core.PointSlice{{X: 0.0, Y: 0.0}, {X: 1.0, Y: 2.0}, {X: 3.0, Y: -1.0}},
+	}
+	// the degree is reduced to 2 for 3 fit points: the curve is the
+	// quadratic bezier through (1, 2) at the chord length parameter
+	// t = √5 / (√5 + √13), its top at y = 2.046144 is above the fit points.
+	min, max := spline.BoundingBox()
+	suite.True(core.Point{X: 0.0, Y: -1.0}.Equals(min), "%+v", min)
+	suite.InDelta(3.0, max.X, 1e-9)
+	suite.InDelta(2.046144278111889, max.Y, 1e-9)
+	suite.InDelta(0.0, max.Z, 1e-9)
+}
+
+func (suite *SplineTestSuite) TestSplineFlatten() {
+	spline := Spline{
+		Degree:        2,
+		KnotValues:    []float64{0.0, 0.0, 0.0, 1.0, 1.0, 1.0},
+		ControlPoints: core.PointSlice{{X: 1.0, Y: 0.0}, {X: 1.0, Y: 1.0}, {X: 0.0, Y: 1.0}},
+		Weights:       []float64{1.0, math.Sqrt2 / 2.0, 1.0},
+	}
+	points := spline.Flatten(0.001)
+	assertArcPoints(suite.T(), points, core.Point{}, 1.0, 0.001)
+	suite.True(spline.ControlPoints[0].Equals(points[0]))
+	suite.True(spline.ControlPoints[2].Equals(points[len(points)-1]))
+
+	fitted := Spline{
+		Degree:    3,
+		FitPoints: core.PointSlice{{X: 0.0, Y: 0.0}, {X: 1.0, Y: 2.0}, {X: 3.0, Y: -1.0}},
+	}
+	points = fitted.Flatten(0.001)
+	suite.True(fitted.FitPoints[0].Equals(points[0]))
+	suite.True(fitted.FitPoints[2].Equals(points[len(points)-1]))
+
+	// not enough data to build a curve.
+	broken := Spline{Degree: 3, ControlPoints: core.PointSlice{{X: 1.0}, {X: 2.0}}}
+	suite.True(broken.ControlPoints.Equals(broken.Flatten(0.1)))
+}
+
+func (suite *SplineTestSuite) TestPeriodicSplineCurve() {
+	spline := Spline{
+		Degree:        3,
+		Periodic:      true,
+		ControlPoints: core.PointSlice{{X: 0.0, Y: 0.0}, {X: 2.0, Y: 0.0}, {X: 2.0, Y: 2.0}, {X: 0.0, Y: 2.0}},
+	}
+	curve, err := spline.Curve()
+	suite.Nil(err)
+	suite.True(curve.IsClosed())
+
+	points := spline.Flatten(0.01)
+	suite.True(points[0].Equals(points[len(points)-1]))
+}
+
+func (suite *SplineTestSuite) TestFitPointsSplineTangents() {
+	spline := Spline{
+		Degree:       3,
+		FitPoints:    core.PointSlice{{X: 0.0, Y: 0.0}, {X: 1.0, Y: 2.0}, {X: 3.0, Y: -1.0}},
+		StartTangent: core.Point{X: 0.0, Y: 1.0},
+		EndTangent:   core.Point{X: 1.0, Y: 0.0},
+	}
+	curve, err := spline.Curve()
+	suite.Nil(err)
+
+	start, end := curve.Domain()
+	suite.True(spline.StartTangent.Equals(curve.Tangent(start)))
+	suite.True(spline.EndTangent.Equals(curve.Tangent(end)))
+
+	spline.Closed = true
+	curve, err = spline.Curve()
+	suite.Nil(err)
+	suite.True(curve.IsClosed())
+}
+
 func TestSplineTestSuite(t *testing.T) {
 	suite.Run(t, new(SplineTestSuite))
 }
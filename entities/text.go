@@ -1,6 +1,11 @@
 package entities
 
-import "github.com/rpaloschi/dxf-go/core"
+import (
+	"math"
+	"unicode/utf8"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
 
 // HorizontalTextJustification Horizontal Text Justification type
 type HorizontalTextJustification int
@@ -110,3 +115,104 @@ func NewText(tags core.TagSlice) (*Text, error) {
 	err := text.Parse(tags)
 	return text, err
 }
+
+// TextCharWidthFactor is the average character width, relative to the text
+// height, used to approximate the Text extents without font metrics.
+const TextCharWidthFactor = 0.6
+
+// BoundingBox returns the minimum and maximum corners of the Text extents.
+// The extents are approximated from the Height, RelativeXScale and the number
+// of characters (see TextCharWidthFactor), no font metrics are used.
+func (e Text) BoundingBox() (min, max core.Point) {
+	height := e.Height
+	chars := float64(utf8.RuneCountInString(e.Value))
+	width := chars * height * TextCharWidthFactor * e.RelativeXScale
+	rotation := e.Rotation * math.Pi / 180.0
+	anchor := e.FirstAlignmentPoint
+	x0, y0 := 0.0, 0.0
+
+	switch e.HorizontalJustification {
+	case HTEXT_ALIGNED, HTEXT_FIT:
+		baseline := e.SecondAlignmentPoint.Sub(e.FirstAlignmentPoint)
+		baseline.Z = 0.0
+		if baseline.Length() > 0 {
+			if e.HorizontalJustification == HTEXT_ALIGNED && width > 0 {
+				height *= baseline.Length() / width
+			}
+			width = baseline.Length()
+			rotation = math.Atan2(baseline.Y, baseline.X)
+		}
+	case HTEXT_CENTER:
+		x0 = -width / 2.0
+	case HTEXT_RIGHT:
+		x0 = -width
+	case HTEXT_MIDDLE:
+		x0, y0 = -width/2.0, -height/2.0
+	}
+
+	if e.HorizontalJustification != HTEXT_ALIGNED && e.HorizontalJustification != HTEXT_FIT &&
+		(e.HorizontalJustification != HTEXT_LEFT || e.VerticalJustification != VTEXT_BASELINE) {
+		anchor = e.SecondAlignmentPoint
+	}
+
+	if e.HorizontalJustification != HTEXT_MIDDLE {
+		switch e.VerticalJustification {
+		case VTEXT_MIDDLE:
+			y0 = -height / 2.0
+		case VTEXT_TOP:
+			y0 = -height
+		}
+	}
+
+	shear := math.Tan(e.ObliqueAngle * math.Pi / 180.0)
+	cos, sin := math.Cos(rotation), math.Sin(rotation)
+	extents := core.NewExtents()
+	for _, corner := range [][2]float64{
+		{x0, y0}, {x0 + width, y0}, {x0 + width, y0 + height}, {x0, y0 + height},
+	} {
+		x, y := corner[0]+corner[1]*shear, corner[1]
+		if e.MirroredX {
+			x = -x
+		}
+		if e.MirroredY {
+			y = -y
+		}
+		local := core.Point{
+			X: anchor.X + x*cos - y*sin,
+			Y: anchor.Y + x*sin + y*cos,
+			Z: anchor.Z,
+		}
+		extents.Add(core.OCSToWCS(local, e.ExtrusionDirection))
+	}
+
+	return extents.Min, extents.Max
+}
+
+// Tags returns the tags of the Text. The second alignment point is written
+// only for justified texts.
+func (e Text) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbText")}
+	tags = append(tags, optionalFloatTag(39, e.Thickness)...)
+	tags = append(tags, core.NewPointTags(10, e.FirstAlignmentPoint)...)
+	tags = append(tags, core.NewFloatTag(40, e.Height), core.NewStringTag(1, e.Value))
+	tags = append(tags, optionalFloatTag(50, e.Rotation)...)
+	if e.RelativeXScale != 1.0 {
+		tags = append(tags, core.NewFloatTag(41, e.RelativeXScale))
+	}
+	tags = append(tags, optionalFloatTag(51, e.ObliqueAngle)...)
+	if e.StyleName != "" {
+		tags = append(tags, core.NewStringTag(7, e.StyleName))
+	}
+	tags = append(tags, optionalIntegerTag(71, flags(map[int]bool{
+		backwardTextBit:   e.MirroredX,
+		upsideDownTextBit: e.MirroredY,
+	}))...)
+	tags = append(tags, optionalIntegerTag(72, int(e.HorizontalJustification))...)
+	if e.HorizontalJustification != HTEXT_LEFT || e.VerticalJustification != VTEXT_BASELINE {
+		tags = append(tags, core.NewPointTags(11, e.SecondAlignmentPoint)...)
+	}
+	tags = append(tags, extrusionTags(e.ExtrusionDirection)...)
+	tags = append(tags, core.NewStringTag(100, "AcDbText"))
+	tags = append(tags, optionalIntegerTag(73, int(e.VerticalJustification))...)
+	return e.entityTags("TEXT", tags)
+}
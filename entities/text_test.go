@@ -80,6 +80,49 @@ func (suite *TextTestSuite) TestTextNotEqualToDifferentType() {
 	suite.False(Text{}.Equals(core.NewStringValue("AAA")))
 }
 
+func (suite *TextTestSuite) TestTextBoundingBox() {
+	width := 2 * TextCharWidthFactor
+	testCases := []struct {
+		text     Text
+		min, max core.Point
+	}{
+		{
+			Text{Value: "AB", Height: 1.0, RelativeXScale: 1.0,
+				FirstAlignmentPoint: core.Point{X: 1.0, Y: 1.0},
+				ExtrusionDirection:  core.Point{Z: 1.0}},
+			core.Point{X: 1.0, Y: 1.0}, core.Point{X: 1.0 + width, Y: 2.0},
+		},
+		{
+			Text{Value: "AB", Height: 1.0, RelativeXScale: 1.0,
+				HorizontalJustification: HTEXT_CENTER,
+				FirstAlignmentPoint:     core.Point{X: 1.0, Y: 1.0},
+				SecondAlignmentPoint:    core.Point{X: 5.0, Y: 5.0},
+				ExtrusionDirection:      core.Point{Z: 1.0}},
+			core.Point{X: 5.0 - width/2, Y: 5.0}, core.Point{X: 5.0 + width/2, Y: 6.0},
+		},
+		{
+			Text{Value: "AB", Height: 1.0, RelativeXScale: 1.0, Rotation: 90.0,
+				FirstAlignmentPoint: core.Point{X: 1.0, Y: 1.0},
+				ExtrusionDirection:  core.Point{Z: 1.0}},
+			core.Point{X: 0.0, Y: 1.0}, core.Point{X: 1.0, Y: 1.0 + width},
+		},
+		{
+			Text{Value: "AB", Height: 1.0, RelativeXScale: 1.0,
+				HorizontalJustification: HTEXT_FIT,
+				FirstAlignmentPoint:     core.Point{X: 0.0, Y: 0.0},
+				SecondAlignmentPoint:    core.Point{X: 10.0, Y: 0.0},
+				ExtrusionDirection:      core.Point{Z: 1.0}},
+			core.Point{X: 0.0, Y: 0.0}, core.Point{X: 10.0, Y: 1.0},
+		},
+	}
+
+	for _, test := range testCases {
+		min, max := test.text.BoundingBox()
+		suite.True(test.min.Equals(min), "Test case: %+v, min: %+v", test, min)
+		suite.True(test.max.Equals(max), "Test case: %+v, max: %+v", test, max)
+	}
+}
+
 func TestTextTestSuite(t *testing.T) {
 	suite.Run(t, new(TextTestSuite))
 }
@@ -0,0 +1,50 @@
+package entities
+
+import "github.com/rpaloschi/dxf-go/core"
+
+// Unknown holds an entity of a type this library does not parse, like an
+// MTEXT or a HATCH, to write it back unchanged. Its common attributes are
+// parsed into the BaseEntity for reference only, it is written from its
+// original tags.
+type Unknown struct {
+	BaseEntity
+	Type string
+	// Raw has all the tags of the entity, starting with its (0, Type) tag.
+	Raw core.TagSlice
+}
+
+// Equals tests equality against another Unknown.
+func (u Unknown) Equals(other core.DxfElement) bool {
+	if otherUnknown, ok := other.(*Unknown); ok {
+		return u.BaseEntity.Equals(otherUnknown.BaseEntity) &&
+			u.Type == otherUnknown.Type &&
+			u.Raw.Equals(otherUnknown.Raw)
+	}
+	return false
+}
+
+// NewUnknown builds a new Unknown from a slice of Tags.
+func NewUnknown(tags core.TagSlice) (*Unknown, error) {
+	unknown := new(Unknown)
+	unknown.Raw = tags
+	if len(tags) > 0 {
+		unknown.Type = tags[0].Value.ToString()
+	}
+
+	unknown.InitBaseEntityParser()
+
+	err := unknown.Parse(tags)
+	return unknown, err
+}
+
+// BoundingBox the geometry of an Unknown is not known, it returns an empty
+// box (see core.Extents.IsEmpty).
+func (u Unknown) BoundingBox() (min, max core.Point) {
+	extents := core.NewExtents()
+	return extents.Min, extents.Max
+}
+
+// Tags returns the original tags of the Unknown.
+func (u Unknown) Tags() core.TagSlice {
+	return u.Raw
+}
@@ -0,0 +1,119 @@
+package entities
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type UnknownTestSuite struct {
+	suite.Suite
+}
+
+func (suite *UnknownTestSuite) TestUnknown() {
+	tags := core.TagSlice(core.AllTags(core.Tagger(strings.NewReader(testUnknown))))
+	unknown, err := NewUnknown(tags)
+
+	suite.Nil(err)
+	suite.Equal("MTEXT", unknown.Type)
+	suite.Equal("1A", unknown.Handle)
+	suite.Equal("NOTES", unknown.LayerName)
+	suite.Equal(3, unknown.Color)
+	suite.True(unknown.On)
+	suite.True(tags.Equals(unknown.Tags()))
+
+	other, err := NewUnknown(tags[:len(tags)-2])
+	suite.Nil(err)
+	suite.False(unknown.Equals(other))
+	suite.True(unknown.Equals(unknown))
+	suite.False(unknown.Equals(core.NewStringValue("MTEXT")))
+
+	suite.False(unknown.IsSeqEnd())
+	suite.False(unknown.HasNestedEntities())
+	min, max := unknown.BoundingBox()
+	suite.True(core.Extents{Min: min, Max: max}.IsEmpty())
+}
+
+// an entity keeps the tags it does not parse and writes them back where
+// they were.
+func (suite *UnknownTestSuite) TestUnparsedTagsAreWritten() {
+	tags := core.TagSlice(core.AllTags(core.Tagger(strings.NewReader(testLineWithUnparsedTags))))
+	line, err := NewLine(tags)
+	suite.Nil(err)
+	suite.Equal("OWNER", line.Owner)
+	suite.True(tags.Equals(line.Tags()))
+
+	line.LayerName = "EDITED"
+	written, err := NewLine(line.Tags())
+	suite.Nil(err)
+	suite.True(line.Equals(written))
+	suite.True(written.Tags().Equals(line.Tags()))
+	suite.Equal(len(tags), len(line.Tags()))
+}
+
+func TestUnknownTestSuite(t *testing.T) {
+	suite.Run(t, new(UnknownTestSuite))
+}
+
+const testUnknown = `  0
+MTEXT
+  5
+1A
+100
+AcDbEntity
+  8
+NOTES
+ 62
+3
+100
+AcDbMText
+ 10
+1.0
+ 20
+2.0
+ 30
+0.0
+  1
+Some text
+`
+
+const testLineWithUnparsedTags = `  0
+LINE
+  5
+LH
+102
+{ACAD_REACTORS
+330
+REACTOR
+102
+}
+330
+OWNER
+100
+AcDbEntity
+  8
+0
+347
+MATERIAL
+390
+PLOTSTYLE
+100
+AcDbLine
+ 10
+1.0
+ 20
+1.0
+ 30
+0.0
+ 11
+2.0
+ 21
+2.0
+ 31
+0.0
+1001
+APP
+1000
+Data
+`
@@ -98,3 +98,42 @@ func (v VertexSlice) Equals(other VertexSlice) bool {
 
 	return true
 }
+
+// BoundingBox returns the minimum and maximum corners of the Vertex extents,
+// both are the Vertex location.
+func (c Vertex) BoundingBox() (min, max core.Point) {
+	return c.Location, c.Location
+}
+
+// Tags returns the tags of the Vertex.
+func (c Vertex) Tags() core.TagSlice {
+	subclass := "AcDb2dVertex"
+	switch {
+	case c.Is3dPolylineVertex:
+		subclass = "AcDb3dPolylineVertex"
+	case c.Is3dPolylineMesh:
+		subclass = "AcDbPolygonMeshVertex"
+	case c.IsPolyfaceMeshVertex:
+		subclass = "AcDbPolyFaceMeshVertex"
+	}
+
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbVertex"), core.NewStringTag(100, subclass)}
+	tags = append(tags, core.NewPointTags(10, c.Location)...)
+	tags = append(tags, optionalFloatTag(40, c.StartingWidth)...)
+	tags = append(tags, optionalFloatTag(41, c.EndWidth)...)
+	tags = append(tags, optionalFloatTag(42, c.Bulge)...)
+	tags = append(tags, core.NewIntegerTag(70, flags(map[int]bool{
+		extraVertexCurveFittingBit: c.CreatedByCurveFitting,
+		curveFitTangentDefinedBit:  c.CurveFitTangentDefined,
+		splineVertexCreatedBit:     c.SplineVertex,
+		splineFrameCtrlPointBit:    c.SplineFrameCtrlPoint,
+		polylineVertex3dBit:        c.Is3dPolylineVertex,
+		polygonMesh3dBit:           c.Is3dPolylineMesh,
+		polyfaceMeshVertexBit:      c.IsPolyfaceMeshVertex,
+	})))
+	if c.CurveFitTangentDefined {
+		tags = append(tags, core.NewFloatTag(50, c.CurveFitTangentDirection))
+	}
+	tags = append(tags, optionalIntegerTag(91, c.Id)...)
+	return c.entityTags("VERTEX", tags)
+}
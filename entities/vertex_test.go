@@ -78,6 +78,13 @@ func (suite *VertexTestSuite) TestVertexNotEqualToDifferentType() {
 	suite.False(Vertex{}.Equals(core.NewIntegerValue(0)))
 }
 
+func (suite *VertexTestSuite) TestVertexBoundingBox() {
+	vertex := Vertex{Location: core.Point{X: 3.0, Y: -1.0, Z: 2.0}}
+	min, max := vertex.BoundingBox()
+	suite.True(vertex.Location.Equals(min))
+	suite.True(vertex.Location.Equals(max))
+}
+
 func TestVertexTestSuite(t *testing.T) {
 	suite.Run(t, new(VertexTestSuite))
 }
@@ -0,0 +1,158 @@
+package entities
+
+import (
+	"math"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// Viewport Entity representation, a window in paper space showing the model
+// space. The Center, Width and Height are in paper space units, the view
+// values are in model space.
+type Viewport struct {
+	BaseEntity
+	Center core.Point
+	Width  float64
+	Height float64
+	// Status is 0 for viewports turned off, positive for the active ones. The
+	// viewport with ID 1 is the paper space itself.
+	Status        int
+	ID            int
+	ViewCenter    core.Point
+	ViewDirection core.Point
+	ViewTarget    core.Point
+	ViewHeight    float64
+	// TwistAngle in degrees, rotates the model counter-clockwise on paper.
+	TwistAngle     float64
+	Flags          int
+	PlotStyleSheet string
+}
+
+// Equals tests equality against another Viewport.
+func (v Viewport) Equals(other core.DxfElement) bool {
+	if otherViewport, ok := other.(*Viewport); ok {
+		return v.BaseEntity.Equals(otherViewport.BaseEntity) &&
+			v.Center.Equals(otherViewport.Center) &&
+			core.FloatEquals(v.Width, otherViewport.Width) &&
+			core.FloatEquals(v.Height, otherViewport.Height) &&
+			v.Status == otherViewport.Status &&
+			v.ID == otherViewport.ID &&
+			v.ViewCenter.Equals(otherViewport.ViewCenter) &&
+			v.ViewDirection.Equals(otherViewport.ViewDirection) &&
+			v.ViewTarget.Equals(otherViewport.ViewTarget) &&
+			core.FloatEquals(v.ViewHeight, otherViewport.ViewHeight) &&
+			core.FloatEquals(v.TwistAngle, otherViewport.TwistAngle) &&
+			v.Flags == otherViewport.Flags &&
+			v.PlotStyleSheet == otherViewport.PlotStyleSheet
+	}
+	return false
+}
+
+// NewViewport builds a new Viewport from a slice of Tags.
+func NewViewport(tags core.TagSlice) (*Viewport, error) {
+	viewport := new(Viewport)
+
+	// set defaults
+	viewport.ViewDirection = core.Point{X: 0.0, Y: 0.0, Z: 1.0}
+
+	viewport.InitBaseEntityParser()
+	viewport.Update(map[int]core.TypeParser{
+		1:  core.NewStringTypeParserToVar(&viewport.PlotStyleSheet),
+		10: core.NewFloatTypeParserToVar(&viewport.Center.X),
+		20: core.NewFloatTypeParserToVar(&viewport.Center.Y),
+		30: core.NewFloatTypeParserToVar(&viewport.Center.Z),
+		12: core.NewFloatTypeParserToVar(&viewport.ViewCenter.X),
+		22: core.NewFloatTypeParserToVar(&viewport.ViewCenter.Y),
+		16: core.NewFloatTypeParserToVar(&viewport.ViewDirection.X),
+		26: core.NewFloatTypeParserToVar(&viewport.ViewDirection.Y),
+		36: core.NewFloatTypeParserToVar(&viewport.ViewDirection.Z),
+		17: core.NewFloatTypeParserToVar(&viewport.ViewTarget.X),
+		27: core.NewFloatTypeParserToVar(&viewport.ViewTarget.Y),
+		37: core.NewFloatTypeParserToVar(&viewport.ViewTarget.Z),
+		40: core.NewFloatTypeParserToVar(&viewport.Width),
+		41: core.NewFloatTypeParserToVar(&viewport.Height),
+		45: core.NewFloatTypeParserToVar(&viewport.ViewHeight),
+		51: core.NewFloatTypeParserToVar(&viewport.TwistAngle),
+		68: core.NewIntTypeParserToVar(&viewport.Status),
+		69: core.NewIntTypeParserToVar(&viewport.ID),
+		90: core.NewIntTypeParserToVar(&viewport.Flags),
+	})
+
+	err := viewport.Parse(tags)
+	return viewport, err
+}
+
+// Scale returns the paper space units per model space unit of the view, 0
+// if the view has no height.
+func (v Viewport) Scale() float64 {
+	if v.ViewHeight == 0.0 {
+		return 0.0
+	}
+	return v.Height / v.ViewHeight
+}
+
+// ModelToPaper returns the transformation from model space to paper space:
+// the model is seen from the ViewDirection towards the ViewTarget, the view
+// center is placed on the viewport Center and the view height fills the
+// viewport Height. The view is an orthographic projection, the returned Z
+// coordinates are the depth in the view.
+func (v Viewport) ModelToPaper() core.Matrix {
+	// the transposed OCS is its inverse, from WCS to the view axes.
+	ocs := core.OCSMatrix(v.ViewDirection)
+	view := core.IdentityMatrix()
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			view[row][column] = ocs[column][row]
+		}
+	}
+
+	scale := v.Scale()
+	return core.TranslationMatrix(v.Center).
+		Multiply(core.ScaleMatrix(scale, scale, scale)).
+		Multiply(core.TranslationMatrix(core.Point{X: -v.ViewCenter.X, Y: -v.ViewCenter.Y})).
+		Multiply(core.RotationZMatrix(v.TwistAngle * math.Pi / 180.0)).
+		Multiply(view).
+		Multiply(core.TranslationMatrix(v.ViewTarget.Scale(-1.0)))
+}
+
+// Window returns the rectangle of the viewport in paper space, closed by
+// repeating the first corner.
+func (v Viewport) Window() core.PointSlice {
+	halfWidth, halfHeight := v.Width/2.0, v.Height/2.0
+	return core.PointSlice{
+		{X: v.Center.X - halfWidth, Y: v.Center.Y - halfHeight, Z: v.Center.Z},
+		{X: v.Center.X + halfWidth, Y: v.Center.Y - halfHeight, Z: v.Center.Z},
+		{X: v.Center.X + halfWidth, Y: v.Center.Y + halfHeight, Z: v.Center.Z},
+		{X: v.Center.X - halfWidth, Y: v.Center.Y + halfHeight, Z: v.Center.Z},
+		{X: v.Center.X - halfWidth, Y: v.Center.Y - halfHeight, Z: v.Center.Z},
+	}
+}
+
+// BoundingBox returns the minimum and maximum corners of the Viewport window
+// in paper space.
+func (v Viewport) BoundingBox() (min, max core.Point) {
+	extents := pointsExtents(v.Window()...)
+	return extents.Min, extents.Max
+}
+
+// Tags returns the tags of the Viewport.
+func (v Viewport) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(100, "AcDbViewport")}
+	tags = append(tags, core.NewPointTags(10, v.Center)...)
+	tags = append(tags,
+		core.NewFloatTag(40, v.Width),
+		core.NewFloatTag(41, v.Height),
+		core.NewIntegerTag(68, v.Status),
+		core.NewIntegerTag(69, v.ID),
+		core.NewFloatTag(12, v.ViewCenter.X),
+		core.NewFloatTag(22, v.ViewCenter.Y))
+	tags = append(tags, core.NewPointTags(16, v.ViewDirection)...)
+	tags = append(tags, core.NewPointTags(17, v.ViewTarget)...)
+	tags = append(tags, core.NewFloatTag(45, v.ViewHeight))
+	tags = append(tags, optionalFloatTag(51, v.TwistAngle)...)
+	tags = append(tags, core.NewIntegerTag(90, v.Flags))
+	if v.PlotStyleSheet != "" {
+		tags = append(tags, core.NewStringTag(1, v.PlotStyleSheet))
+	}
+	return v.entityTags("VIEWPORT", tags)
+}
@@ -0,0 +1,176 @@
+package entities
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+)
+
+type ViewportTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ViewportTestSuite) TestMinimalViewport() {
+	expected := Viewport{
+		BaseEntity: BaseEntity{
+			Handle:    "2A",
+			LayerName: "0",
+			On:        true,
+			Visible:   true,
+		},
+		Center:        core.Point{X: 148.5, Y: 105.0},
+		Width:         297.0,
+		Height:        210.0,
+		ID:            1,
+		ViewDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0},
+	}
+
+	next := core.Tagger(strings.NewReader(testMinimalViewport))
+	viewport, err := NewViewport(core.TagSlice(core.AllTags(next)))
+
+	suite.Nil(err)
+	suite.True(expected.Equals(viewport))
+
+	suite.False(viewport.IsSeqEnd())
+	suite.False(viewport.HasNestedEntities())
+}
+
+func (suite *ViewportTestSuite) TestViewportAllAttribs() {
+	expected := Viewport{
+		BaseEntity: BaseEntity{
+			Handle:        "ALL_ARGS",
+			Space:         PAPER,
+			LayoutTabName: "Layout1",
+			LayerName:     "VIEWPORTS",
+			On:            true,
+			Visible:       true,
+		},
+		Center:         core.Point{X: 100.0, Y: 80.0, Z: 0.5},
+		Width:          120.0,
+		Height:         90.0,
+		Status:         2,
+		ID:             3,
+		ViewCenter:     core.Point{X: 1000.0, Y: 500.0},
+		ViewDirection:  core.Point{X: 1.0, Y: 1.0, Z: 1.0},
+		ViewTarget:     core.Point{X: 10.0, Y: 20.0, Z: 30.0},
+		ViewHeight:     900.0,
+		TwistAngle:     15.0,
+		Flags:          32864,
+		PlotStyleSheet: "monochrome.ctb",
+	}
+
+	next := core.Tagger(strings.NewReader(testViewportAllAttribs))
+	viewport, err := NewViewport(core.TagSlice(core.AllTags(next)))
+
+	suite.Nil(err)
+	suite.True(expected.Equals(viewport))
+	suite.InDelta(0.1, viewport.Scale(), 1e-12)
+}
+
+func (suite *ViewportTestSuite) TestViewportNotEqualToDifferentType() {
+	suite.False(Viewport{}.Equals(core.NewStringValue("AAA")))
+}
+
+func (suite *ViewportTestSuite) TestViewportModelToPaper() {
+	viewport := Viewport{
+		Center:        core.Point{X: 100.0, Y: 50.0},
+		Width:         40.0,
+		Height:        20.0,
+		ViewCenter:    core.Point{X: 10.0, Y: 0.0},
+		ViewDirection: core.Point{Z: 1.0},
+		ViewTarget:    core.Point{X: 0.0, Y: 5.0},
+		ViewHeight:    10.0,
+	}
+	transform := viewport.ModelToPaper()
+
+	// the view center is relative to the target.
+	suite.True(core.Point{X: 100.0, Y: 50.0}.Equals(
+		transform.Apply(core.Point{X: 10.0, Y: 5.0})))
+	suite.True(core.Point{X: 102.0, Y: 50.0}.Equals(
+		transform.Apply(core.Point{X: 11.0, Y: 5.0})))
+
+	viewport.TwistAngle = 90.0
+	viewport.ViewCenter = core.Point{}
+	suite.True(core.Point{X: 100.0, Y: 52.0}.Equals(
+		viewport.ModelToPaper().Apply(core.Point{X: 1.0, Y: 5.0})))
+
+	min, max := viewport.BoundingBox()
+	suite.True(core.Point{X: 80.0, Y: 40.0}.Equals(min))
+	suite.True(core.Point{X: 120.0, Y: 60.0}.Equals(max))
+
+	// without view height there is no scale.
+	suite.Equal(0.0, Viewport{Height: 1.0}.Scale())
+}
+
+func TestViewportTestSuite(t *testing.T) {
+	suite.Run(t, new(ViewportTestSuite))
+}
+
+const testMinimalViewport = `  0
+VIEWPORT
+  5
+2A
+  8
+0
+ 10
+148.5
+ 20
+105.0
+ 40
+297.0
+ 41
+210.0
+ 69
+1
+`
+
+const testViewportAllAttribs = `  0
+VIEWPORT
+  5
+ALL_ARGS
+ 67
+1
+410
+Layout1
+  8
+VIEWPORTS
+ 10
+100.0
+ 20
+80.0
+ 30
+0.5
+ 40
+120.0
+ 41
+90.0
+ 68
+2
+ 69
+3
+ 12
+1000.0
+ 22
+500.0
+ 16
+1.0
+ 26
+1.0
+ 36
+1.0
+ 17
+10.0
+ 27
+20.0
+ 37
+30.0
+ 45
+900.0
+ 51
+15.0
+ 90
+32864
+  1
+monochrome.ctb
+`
@@ -0,0 +1,142 @@
+package entities
+
+import (
+	"sort"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// Writable is implemented by the entities that can be written to a DXF file.
+type Writable interface {
+	// Tags returns the tags of the entity, starting with its (0, type) tag.
+	// Entities followed by nested ones, like the Polylines, include them and
+	// the closing SEQEND.
+	Tags() core.TagSlice
+}
+
+// defaultExtrusion is the extrusion direction of the entities without one.
+var defaultExtrusion = core.Point{X: 0.0, Y: 0.0, Z: 1.0}
+
+// entityTags returns the tags of an entity of entityType: the common
+// attributes of the BaseEntity, the tags of the entity, the unparsed ones
+// and its XDATA.
+func (entity BaseEntity) entityTags(entityType string, tags core.TagSlice) core.TagSlice {
+	result := core.TagSlice{core.NewStringTag(0, entityType)}
+	if entity.Handle != "" {
+		result = append(result, core.NewStringTag(5, entity.Handle))
+	}
+	if entity.Owner != "" {
+		result = append(result, core.NewHandleTag(330, entity.Owner))
+	}
+	result = append(result, core.NewStringTag(100, "AcDbEntity"))
+	if entity.Space != MODEL {
+		result = append(result, core.NewIntegerTag(67, int(entity.Space)))
+	}
+	if entity.LayoutTabName != "" {
+		result = append(result, core.NewStringTag(410, entity.LayoutTabName))
+	}
+	result = append(result, core.NewStringTag(8, entity.LayerName))
+	if entity.LineTypeName != "" {
+		result = append(result, core.NewStringTag(6, entity.LineTypeName))
+	}
+	if entity.Color != 0 {
+		color := entity.Color
+		if !entity.On {
+			color = -color
+		}
+		result = append(result, core.NewIntegerTag(62, color))
+	}
+	if entity.LineWeight != 0 {
+		result = append(result, core.NewIntegerTag(370, entity.LineWeight))
+	}
+	if entity.LineTypeScale != 0.0 {
+		result = append(result, core.NewFloatTag(48, entity.LineTypeScale))
+	}
+	if !entity.Visible {
+		result = append(result, core.NewIntegerTag(60, 1))
+	}
+	if entity.TrueColor != 0 {
+		result = append(result, core.NewIntegerTag(420, int(entity.TrueColor)))
+	}
+	if entity.ColorName != "" {
+		result = append(result, core.NewStringTag(430, entity.ColorName))
+	}
+	if entity.Transparency != 0 {
+		result = append(result, core.NewIntegerTag(440, entity.Transparency))
+	}
+	if entity.ShadowMode != CASTS_AND_RECEIVE {
+		result = append(result, core.NewIntegerTag(284, int(entity.ShadowMode)))
+	}
+
+	result = append(result, tags...)
+	result = entity.WithUnparsedTags(result)
+	return append(result, entity.xDataTags()...)
+}
+
+// xDataTags returns the XDATA tags, sorted by application name.
+func (entity BaseEntity) xDataTags() core.TagSlice {
+	applications := make([]string, 0, len(entity.XData))
+	for application := range entity.XData {
+		applications = append(applications, application)
+	}
+	sort.Strings(applications)
+
+	var tags core.TagSlice
+	for _, application := range applications {
+		tags = append(tags, core.NewStringTag(1001, application))
+		tags = append(tags, entity.XData[application]...)
+	}
+	return tags
+}
+
+// seqEndTags returns the tags of the SEQEND closing the nested entities of
+// the entity, which owns it.
+func (entity BaseEntity) seqEndTags() core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(0, "SEQEND")}
+	if entity.SeqEndHandle != "" {
+		tags = append(tags, core.NewStringTag(5, entity.SeqEndHandle))
+		if entity.Handle != "" {
+			tags = append(tags, core.NewHandleTag(330, entity.Handle))
+		}
+	}
+	return append(tags,
+		core.NewStringTag(100, "AcDbEntity"),
+		core.NewStringTag(8, entity.LayerName))
+}
+
+// optionalFloatTag returns the tag of the value, or no tag if it is 0, the
+// default of the optional codes.
+func optionalFloatTag(code int, value float64) core.TagSlice {
+	if value == 0.0 {
+		return nil
+	}
+	return core.TagSlice{core.NewFloatTag(code, value)}
+}
+
+// optionalIntegerTag returns the tag of the value, or no tag if it is 0.
+func optionalIntegerTag(code int, value int) core.TagSlice {
+	if value == 0 {
+		return nil
+	}
+	return core.TagSlice{core.NewIntegerTag(code, value)}
+}
+
+// extrusionTags returns the 210 tags of the direction, or no tags for the
+// default one.
+func extrusionTags(direction core.Point) core.TagSlice {
+	if direction.Equals(defaultExtrusion) || direction.Equals(core.Point{}) {
+		return nil
+	}
+	return core.NewPointTags(210, direction)
+}
+
+// flags returns the bits of the values that are true.
+func flags(bits map[int]bool) int {
+	result := 0
+	for bit, set := range bits {
+		if set {
+			result |= bit
+		}
+	}
+	return result
+}
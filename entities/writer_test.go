@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"bytes"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func parseTestEntity(t *testing.T, dxf string, factory func(core.TagSlice) (Entity, error)) Entity {
+	entity, err := factory(core.TagSlice(core.AllTags(core.Tagger(strings.NewReader(dxf)))))
+	assert.Nil(t, err)
+	return entity
+}
+
+func TestEntityTagsRoundTrip(t *testing.T) {
+	tests := []struct {
+		dxf     string
+		factory func(core.TagSlice) (Entity, error)
+	}{
+		{testMinimalLine, func(tags core.TagSlice) (Entity, error) { return NewLine(tags) }},
+		{testLineAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewLine(tags) }},
+		{testLineOff, func(tags core.TagSlice) (Entity, error) { return NewLine(tags) }},
+		{testLineWithXData, func(tags core.TagSlice) (Entity, error) { return NewLine(tags) }},
+		{testPointAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewPoint(tags) }},
+		{testCircleAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewCircle(tags) }},
+		{testArcAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewArc(tags) }},
+		{testEllipseAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewEllipse(tags) }},
+		{testTextAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewText(tags) }},
+		{testMinimalText, func(tags core.TagSlice) (Entity, error) { return NewText(tags) }},
+		{testInsertAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewInsert(tags) }},
+		{testMinimalInsert, func(tags core.TagSlice) (Entity, error) { return NewInsert(tags) }},
+		{testLWPolylineAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewLWPolyline(tags) }},
+		{testPolylineAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewPolyline(tags) }},
+		{testVertexAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewVertex(tags) }},
+		{testSplineAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewSpline(tags) }},
+		{testSolidAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewSolid(tags) }},
+		{testViewportAllAttribs, func(tags core.TagSlice) (Entity, error) { return NewViewport(tags) }},
+	}
+
+	for _, test := range tests {
+		entity := parseTestEntity(t, test.dxf, test.factory)
+
+		var buffer bytes.Buffer
+		assert.Nil(t, core.WriteTags(&buffer, entity.(Writable).Tags()))
+
+		// nested entities follow the first group.
+		tags := core.TagSlice(core.AllTags(core.Tagger(&buffer)))
+		written, err := test.factory(core.TagGroups(tags, 0)[0])
+		assert.Nil(t, err)
+		assert.True(t, entity.Equals(written), "%v\nwritten as\n%v", test.dxf, tags)
+	}
+}
+
+func TestPolylineTags(t *testing.T) {
+	polyline := Polyline{BaseEntity: BaseEntity{LayerName: "P"}, Closed: true,
+		Vertices: VertexSlice{{Location: core.Point{X: 1.0}}, {Location: core.Point{Y: 1.0}, Bulge: 0.5}}}
+
+	groups := core.TagGroups(polyline.Tags(), 0)
+	assert.Len(t, groups, 4)
+	assert.Equal(t, "POLYLINE", groups[0][0].Value.ToString())
+	assert.Equal(t, "VERTEX", groups[2][0].Value.ToString())
+	assert.Equal(t, "SEQEND", groups[3][0].Value.ToString())
+	assert.Equal(t, "P", groups[3][2].Value.ToString())
+
+	vertex, err := NewVertex(groups[2])
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, vertex.Bulge)
+	assert.True(t, core.Point{Y: 1.0}.Equals(vertex.Location))
+}
+
+func TestEntityTagsDefaults(t *testing.T) {
+	line := Line{BaseEntity: BaseEntity{LayerName: "0", On: true, Visible: true},
+		End: core.Point{X: 1.0}}
+	codes := []int{}
+	for _, tag := range line.Tags() {
+		codes = append(codes, tag.Code)
+	}
+	// no handle, color, extrusion or XDATA.
+	assert.Equal(t, []int{0, 100, 8, 100, 10, 20, 30, 11, 21, 31}, codes)
+
+	line.On = false
+	line.Color = 3
+	line.XData = map[string]core.TagSlice{"B": {}, "A": {core.NewStringTag(1000, "X")}}
+	tags := line.Tags()
+	assert.True(t, core.NewIntegerTag(62, -3).Equals(tags[3]))
+	assert.True(t, core.TagSlice{
+		core.NewStringTag(1001, "A"),
+		core.NewStringTag(1000, "X"),
+		core.NewStringTag(1001, "B"),
+	}.Equals(tags[len(tags)-3:]))
+}
@@ -0,0 +1,29 @@
+package pdf
+
+// helveticaWidths holds the widths of the printable ASCII characters of
+// Helvetica, in thousandths of the font size, starting from the space.
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// helveticaDefaultWidth is the width used for the characters out of ASCII.
+const helveticaDefaultWidth = 556
+
+// helveticaWidth returns the width of the WinAnsi encoded text for a font
+// size of 1.
+func helveticaWidth(text []byte) float64 {
+	width := 0
+	for _, c := range text {
+		if c >= ' ' && int(c-' ') < len(helveticaWidths) {
+			width += helveticaWidths[c-' ']
+		} else {
+			width += helveticaDefaultWidth
+		}
+	}
+	return float64(width) / 1000.0
+}
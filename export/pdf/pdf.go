@@ -0,0 +1,325 @@
+// Package pdf plots DXF documents as vector PDF files, one page per paper
+// space layout.
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/export/plot"
+)
+
+// Options controls the PDF plotting.
+type Options struct {
+	plot.Options
+	// PageWidth and PageHeight in millimeters. If not positive, the pages
+	// are A4 landscape.
+	PageWidth  float64
+	PageHeight float64
+	// Margin around the drawing in millimeters. The drawing is scaled to
+	// fit the page inside the margins and centered.
+	Margin float64
+	// IncludeModelSpace adds a first page with the model space. Documents
+	// without layouts always plot the model space.
+	IncludeModelSpace bool
+}
+
+// pointsPerMillimeter converts millimeters to PDF units.
+const pointsPerMillimeter = 72.0 / 25.4
+
+// Write plots the layouts of the document to PDF, one page each, in the
+// order of document.DxfDocument.Layouts.
+func Write(w io.Writer, doc *document.DxfDocument, options Options) error {
+	var drawings []*plot.Drawing
+
+	layouts := doc.Layouts()
+	if options.IncludeModelSpace || len(layouts) == 0 {
+		drawings = append(drawings, plot.FromDocument(doc, options.Options))
+	}
+	for _, layout := range layouts {
+		drawing, err := plot.FromLayout(doc, layout.BlockName, options.Options)
+		if err != nil {
+			return err
+		}
+		drawings = append(drawings, drawing)
+	}
+
+	return WriteDrawings(w, drawings, options)
+}
+
+// WriteDrawings writes the plotted drawings to PDF, one page each. The
+// drawings are seen from the top, the Z coordinates are ignored.
+func WriteDrawings(w io.Writer, drawings []*plot.Drawing, options Options) error {
+	pageWidth, pageHeight := options.PageWidth, options.PageHeight
+	if pageWidth <= 0.0 || pageHeight <= 0.0 {
+		pageWidth, pageHeight = 297.0, 210.0
+	}
+	pageWidth *= pointsPerMillimeter
+	pageHeight *= pointsPerMillimeter
+
+	out := &pdfWriter{out: bufio.NewWriter(w)}
+	out.printf("%%PDF-1.4\n%%\xe2\xe3\xcf\xd3\n")
+
+	// the catalog, the page tree and the font go first, then every page is
+	// followed by its content stream.
+	kids := make([]string, len(drawings))
+	for i := range drawings {
+		kids[i] = fmt.Sprintf("%v 0 R", 4+2*i)
+	}
+	out.object("<< /Type /Catalog /Pages 2 0 R >>")
+	out.object(fmt.Sprintf("<< /Type /Pages /Kids [%v] /Count %v >>",
+		strings.Join(kids, " "), len(drawings)))
+	out.object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+
+	for i, drawing := range drawings {
+		content, err := compress(pageContent(drawing, pageWidth, pageHeight, options))
+		if err != nil {
+			return err
+		}
+
+		out.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %v %v] "+
+			"/Resources << /Font << /F1 3 0 R >> >> /Contents %v 0 R >>",
+			number(pageWidth), number(pageHeight), 5+2*i))
+		out.object(fmt.Sprintf("<< /Length %v /Filter /FlateDecode >>\nstream\n%s\nendstream",
+			len(content), content))
+	}
+
+	xref := out.offset
+	out.printf("xref\n0 %v\n0000000000 65535 f \n", len(out.offsets)+1)
+	for _, offset := range out.offsets {
+		out.printf("%010d 00000 n \n", offset)
+	}
+	out.printf("trailer\n<< /Size %v /Root 1 0 R >>\nstartxref\n%v\n%%%%EOF\n",
+		len(out.offsets)+1, xref)
+
+	if out.err != nil {
+		return out.err
+	}
+	return out.out.Flush()
+}
+
+// pdfWriter writes the PDF objects, keeping their offsets for the cross
+// reference table. The first error stops the writing.
+type pdfWriter struct {
+	out     *bufio.Writer
+	offset  int
+	offsets []int
+	err     error
+}
+
+func (w *pdfWriter) printf(format string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(w.out, format, args...)
+	w.offset += n
+	w.err = err
+}
+
+// object writes the next object, numbered from 1.
+func (w *pdfWriter) object(body string) {
+	w.offsets = append(w.offsets, w.offset)
+	w.printf("%v 0 obj\n%v\nendobj\n", len(w.offsets), body)
+}
+
+func compress(content []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := zlib.NewWriter(&buffer)
+	if _, err := writer.Write(content); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// pageContent returns the content stream drawing the primitives fitted in
+// the page.
+func pageContent(drawing *plot.Drawing, pageWidth float64, pageHeight float64,
+	options Options) []byte {
+
+	var content bytes.Buffer
+	if options.DarkBackground {
+		fmt.Fprintf(&content, "0 0 0 rg 0 0 %v %v re f\n", number(pageWidth), number(pageHeight))
+	}
+
+	extents := drawing.Extents()
+	if extents.IsEmpty() {
+		return content.Bytes()
+	}
+
+	margin := options.Margin * pointsPerMillimeter
+	width := math.Max(extents.Max.X-extents.Min.X, 1e-9)
+	height := math.Max(extents.Max.Y-extents.Min.Y, 1e-9)
+	scale := math.Min((pageWidth-2.0*margin)/width, (pageHeight-2.0*margin)/height)
+	if scale <= 0.0 || math.IsInf(scale, 0) {
+		return content.Bytes()
+	}
+	offsetX := pageWidth/2.0 - (extents.Min.X+extents.Max.X)/2.0*scale
+	offsetY := pageHeight/2.0 - (extents.Min.Y+extents.Max.Y)/2.0*scale
+
+	fmt.Fprintf(&content, "q %v 0 0 %v %v %v cm 1 J 1 j\n",
+		number(scale), number(scale), number(offsetX), number(offsetY))
+	for _, primitive := range drawing.Primitives {
+		switch p := primitive.(type) {
+		case plot.Path:
+			writePath(&content, p, scale)
+		case plot.Label:
+			writeLabel(&content, p)
+		}
+	}
+	content.WriteString("Q\n")
+	return content.Bytes()
+}
+
+func writePath(content *bytes.Buffer, p plot.Path, scale float64) {
+	if len(p.Polylines) == 0 {
+		return
+	}
+
+	r, g, b := p.Color.Rgb()
+	operator := "RG"
+	if p.Filled {
+		operator = "rg"
+	}
+	fmt.Fprintf(content, "%v %v %v %v\n", colorComponent(r), colorComponent(g),
+		colorComponent(b), operator)
+
+	if !p.Filled {
+		// line weights are in millimeters on paper, 0 is the thinnest line.
+		fmt.Fprintf(content, "%v w [", number(p.LineWeight*pointsPerMillimeter/scale))
+		for i, length := range p.Dashes {
+			if i > 0 {
+				content.WriteString(" ")
+			}
+			content.WriteString(number(length))
+		}
+		content.WriteString("] 0 d\n")
+	}
+
+	for _, polyline := range p.Polylines {
+		for i, point := range polyline {
+			operator := "l"
+			if i == 0 {
+				operator = "m"
+			}
+			fmt.Fprintf(content, "%v %v %v\n", number(point.X), number(point.Y), operator)
+		}
+		if p.Filled {
+			content.WriteString("h\n")
+		}
+	}
+
+	if p.Filled {
+		content.WriteString("f*\n")
+	} else {
+		content.WriteString("S\n")
+	}
+}
+
+// helveticaCapHeight is the height of the capital letters of Helvetica for
+// a font size of 1, the height of the DXF texts.
+const helveticaCapHeight = 0.718
+
+// helveticaDescent is the depth of the descenders of Helvetica for a font
+// size of 1.
+const helveticaDescent = 0.207
+
+func writeLabel(content *bytes.Buffer, l plot.Label) {
+	text := winAnsi(l.Value)
+	if len(text) == 0 || l.Height <= 0.0 {
+		return
+	}
+
+	size := l.Height / helveticaCapHeight
+	width := helveticaWidth(text) * size
+	horizontalScale := 100.0
+	if l.Length > 0.0 && width > 0.0 {
+		horizontalScale = 100.0 * l.Length / width
+		width = l.Length
+	}
+
+	x, y := 0.0, 0.0
+	switch l.HorizontalJustification {
+	case entities.HTEXT_CENTER:
+		x = -width / 2.0
+	case entities.HTEXT_RIGHT:
+		x = -width
+	}
+	switch l.VerticalJustification {
+	case entities.VTEXT_BOTTOM:
+		y = helveticaDescent * size
+	case entities.VTEXT_MIDDLE:
+		y = -l.Height / 2.0
+	case entities.VTEXT_TOP:
+		y = -l.Height
+	}
+
+	r, g, b := l.Color.Rgb()
+	m := l.Transform
+	fmt.Fprintf(content, "q %v %v %v rg %v %v %v %v %v %v cm\n",
+		colorComponent(r), colorComponent(g), colorComponent(b),
+		number(m[0][0]), number(m[1][0]), number(m[0][1]), number(m[1][1]),
+		number(m[0][3]), number(m[1][3]))
+	fmt.Fprintf(content, "BT /F1 %v Tf %v Tz %v %v Td (%v) Tj ET Q\n",
+		number(size), number(horizontalScale), number(x), number(y), escape(text))
+}
+
+// winAnsi encodes the text for the standard fonts, characters out of the
+// encoding are replaced by a question mark.
+func winAnsi(value string) []byte {
+	var text []byte
+	for _, character := range value {
+		switch {
+		case character >= 0x20 && character < 0x7f, character >= 0xa0 && character <= 0xff:
+			text = append(text, byte(character))
+		case character == '⌀':
+			text = append(text, 0xd8)
+		case character == '€':
+			text = append(text, 0x80)
+		default:
+			text = append(text, '?')
+		}
+	}
+	return text
+}
+
+// escape returns the PDF literal string of the text, in ASCII.
+func escape(text []byte) string {
+	var escaped strings.Builder
+	for _, c := range text {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			escaped.WriteByte('\\')
+			escaped.WriteByte(c)
+		case c >= 0x80:
+			fmt.Fprintf(&escaped, "\\%03o", c)
+		default:
+			escaped.WriteByte(c)
+		}
+	}
+	return escaped.String()
+}
+
+func colorComponent(value byte) string {
+	return number(float64(value) / 255.0)
+}
+
+// number formats the value as a PDF real, without exponent.
+func number(value float64) string {
+	formatted := strconv.FormatFloat(value, 'f', 6, 64)
+	formatted = strings.TrimRight(strings.TrimRight(formatted, "0"), ".")
+	if formatted == "-0" || formatted == "" {
+		return "0"
+	}
+	return formatted
+}
@@ -0,0 +1,126 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/export/plot"
+	"github.com/rpaloschi/dxf-go/sections"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func pdfTestDocument() *document.DxfDocument {
+	model := entities.BaseEntity{LayerName: "0", Color: 1, On: true, Visible: true, LineWeight: 50}
+	paper := entities.BaseEntity{Space: entities.PAPER, LayoutTabName: "Sheet",
+		LayerName: "0", Color: 5, On: true, Visible: true}
+	return &document.DxfDocument{
+		Entities: &sections.EntitiesSection{Entities: entities.EntitySlice{
+			&entities.Line{BaseEntity: model, End: core.Point{X: 10.0, Y: 10.0}},
+			&entities.Text{BaseEntity: model, Value: "A(1)", Height: 1.0, RelativeXScale: 1.0,
+				ExtrusionDirection: core.Point{Z: 1.0}},
+			&entities.Viewport{BaseEntity: paper, ID: 2, Status: 1,
+				Center: core.Point{X: 100.0, Y: 100.0}, Width: 100.0, Height: 100.0,
+				ViewCenter: core.Point{X: 5.0, Y: 5.0}, ViewHeight: 10.0,
+				ViewDirection: core.Point{Z: 1.0}},
+			&entities.Solid{BaseEntity: paper, SecondCorner: core.Point{X: 10.0},
+				ThirdCorner: core.Point{Y: 10.0}, FourthCorner: core.Point{X: 10.0, Y: 10.0},
+				ExtrusionDirection: core.Point{Z: 1.0}},
+		}},
+		Blocks: sections.BlocksSection{
+			"*Paper_Space0": &sections.Block{Name: "*Paper_Space0"},
+		},
+	}
+}
+
+// pdfPages returns the decompressed content streams of the PDF, checking
+// its structure.
+func pdfPages(t *testing.T, data []byte) []string {
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+
+	// the cross reference table points to the objects.
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	assert.True(t, bytes.HasPrefix(data[xref:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(data[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		assert.True(t, bytes.HasPrefix(data[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")),
+			"object %v", i+1)
+	}
+
+	var pages []string
+	streams := regexp.MustCompile(`(?s)/Length (\d+) /Filter /FlateDecode >>\nstream\n`)
+	for _, match := range streams.FindAllSubmatchIndex(data, -1) {
+		length, _ := strconv.Atoi(string(data[match[2]:match[3]]))
+		reader, err := zlib.NewReader(bytes.NewReader(data[match[1] : match[1]+length]))
+		assert.Nil(t, err)
+		content, err := io.ReadAll(reader)
+		assert.Nil(t, err)
+		pages = append(pages, string(content))
+	}
+	assert.Equal(t, len(pages), bytes.Count(data, []byte("/Type /Page ")))
+	return pages
+}
+
+func TestWrite(t *testing.T) {
+	var out bytes.Buffer
+	err := Write(&out, pdfTestDocument(), Options{Margin: 10.0, IncludeModelSpace: true})
+	assert.Nil(t, err)
+
+	pages := pdfPages(t, out.Bytes())
+	assert.Len(t, pages, 3)
+	assert.Contains(t, out.String(), "/MediaBox [0 0 841.889764 595.275591]")
+
+	// model space: a red line 0.5mm wide and a text.
+	assert.Contains(t, pages[0], "1 0 0 RG\n")
+	assert.Contains(t, pages[0], "0 0 m\n10 10 l\nS\n")
+	assert.Contains(t, pages[0], "(A\\(1\\)) Tj")
+
+	// the layout: the viewport shows the line, the text and the solid.
+	assert.Contains(t, pages[1], "50 50 m\n150 150 l\nS\n")
+	assert.Contains(t, pages[1], "0 0 1 rg\n")
+	assert.Contains(t, pages[1], "f*\n")
+
+	// the empty layout.
+	assert.Equal(t, "", pages[2])
+}
+
+func TestWriteLineWeightsAndPlotStyles(t *testing.T) {
+	var out bytes.Buffer
+	options := Options{PageWidth: 100.0, PageHeight: 100.0,
+		Options: plot.Options{PlotStyles: plot.MonochromePlotStyles()}}
+	err := Write(&out, &document.DxfDocument{Entities: &sections.EntitiesSection{
+		Entities: pdfTestDocument().Entities.Entities[:1]}}, options)
+	assert.Nil(t, err)
+
+	pages := pdfPages(t, out.Bytes())
+	assert.Len(t, pages, 1)
+	// 10 units fill 100mm: 0.5mm are 0.05 units.
+	assert.Contains(t, pages[0], "0 0 0 RG\n0.05 w [] 0 d\n")
+}
+
+func TestWriteDrawingsDarkBackground(t *testing.T) {
+	var out bytes.Buffer
+	err := WriteDrawings(&out, []*plot.Drawing{{}}, Options{
+		PageWidth: 10.0, PageHeight: 20.0, Options: plot.Options{DarkBackground: true}})
+	assert.Nil(t, err)
+
+	pages := pdfPages(t, out.Bytes())
+	assert.Equal(t, []string{"0 0 0 rg 0 0 28.346457 56.692913 re f\n"}, pages)
+}
+
+func TestWinAnsi(t *testing.T) {
+	assert.Equal(t, []byte("a\xb0\xd8?"), winAnsi("a°⌀→"))
+	assert.Equal(t, `\(\\\)\260`, escape([]byte("(\\)\xb0")))
+	assert.InDelta(t, 1.334, helveticaWidth([]byte("AB")), 1e-12)
+	assert.True(t, strings.HasPrefix(number(-0.0000001), "0"))
+	assert.Equal(t, "1.5", number(1.5))
+}
@@ -0,0 +1,526 @@
+package plot
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/sections"
+)
+
+// defaultToleranceFactor is the fraction of the drawing size used as chord
+// tolerance when none is given.
+const defaultToleranceFactor = 0.001
+
+// FromDocument plots the model space entities of the document. Entities that
+// are invisible, turned off or on frozen or turned off layers are skipped, as
+// are Point entities. Inserts are resolved to the entities of their Block.
+func FromDocument(doc *document.DxfDocument, options Options) *Drawing {
+	min, max := doc.Extents(document.ExtentsOptions{IncludeInserts: true})
+	return newBuilder(doc, options, core.Extents{Min: min, Max: max}).build(modelSpace(doc))
+}
+
+// FromLayout plots the paper space entities of the named layout, see
+// document.DxfDocument.Layout. The active viewports show the model space
+// scaled and clipped to their window, line weights are not scaled.
+func FromLayout(doc *document.DxfDocument, name string, options Options) (*Drawing, error) {
+	layout, ok := doc.Layout(name)
+	if !ok {
+		return nil, fmt.Errorf("Layout %v not found", name)
+	}
+
+	extents := core.NewExtents()
+	for _, entity := range layout.Entities {
+		extents.Merge(entities.EntityExtents(entity))
+	}
+	builder := newBuilder(doc, options, extents)
+	builder.modelSpace = modelSpace(doc)
+	return builder.build(layout.Entities), nil
+}
+
+// FromBlock plots the entities of the named Block, in Block coordinates.
+func FromBlock(doc *document.DxfDocument, name string, options Options) (*Drawing, error) {
+	block, ok := doc.Blocks[name]
+	if !ok {
+		return nil, fmt.Errorf("Block %v not found", name)
+	}
+
+	extents := core.NewExtents()
+	for _, entity := range block.Entities {
+		extents.Merge(entities.EntityExtents(entity))
+	}
+	builder := newBuilder(doc, options, extents)
+	builder.active[name] = true
+	return builder.build(block.Entities), nil
+}
+
+// modelSpace returns the model space entities of the ENTITIES section.
+func modelSpace(doc *document.DxfDocument) entities.EntitySlice {
+	var entityList entities.EntitySlice
+	if doc.Entities != nil {
+		for _, entity := range doc.Entities.Entities {
+			if base := entities.EntityBase(entity); base == nil || base.Space == entities.MODEL {
+				entityList = append(entityList, entity)
+			}
+		}
+	}
+	return entityList
+}
+
+// pen holds the resolved properties of an entity, inherited by the entities
+// of the Block it inserts.
+type pen struct {
+	color core.TrueColor
+	// colorIndex is the ACI color of the pen, 0 for true colors.
+	colorIndex int
+	lineType   string
+	lineWeight float64
+	layer      string
+	transform  core.Matrix
+	inBlock    bool
+	// viewportScale is the scale of the viewport showing the entities, 0
+	// outside viewports.
+	viewportScale float64
+}
+
+type builder struct {
+	doc       *document.DxfDocument
+	options   Options
+	tolerance float64
+	ltScale   float64
+	// paperLtScale draws the linetypes in viewports with the paper space
+	// scale ($PSLTSCALE).
+	paperLtScale bool
+	// modelSpace holds the entities shown by the viewports of a layout.
+	modelSpace entities.EntitySlice
+	active     map[string]bool
+	drawing    *Drawing
+}
+
+func newBuilder(doc *document.DxfDocument, options Options, extents core.Extents) *builder {
+	tolerance := options.Tolerance
+	if tolerance <= 0.0 {
+		tolerance = entities.DefaultFlattenTolerance
+		if !extents.IsEmpty() {
+			if size := extents.Max.Distance(extents.Min); size > 0.0 {
+				tolerance = size * defaultToleranceFactor
+			}
+		}
+	}
+
+	ltScale := 1.0
+	paperLtScale := true
+	if doc.Header != nil {
+		if tags := doc.Header.Get("$LTSCALE"); len(tags) > 0 {
+			if value, ok := core.AsFloat(tags[0].Value); ok && value > 0.0 {
+				ltScale = value
+			}
+		}
+		if tags := doc.Header.Get("$PSLTSCALE"); len(tags) > 0 {
+			if value, ok := core.AsInt(tags[0].Value); ok {
+				paperLtScale = value != 0
+			}
+		}
+	}
+
+	return &builder{
+		doc:          doc,
+		options:      options,
+		tolerance:    tolerance,
+		ltScale:      ltScale,
+		paperLtScale: paperLtScale,
+		active:       make(map[string]bool),
+		drawing:      new(Drawing),
+	}
+}
+
+func (b *builder) build(entityList entities.EntitySlice) *Drawing {
+	for _, entity := range entityList {
+		b.entity(entity, b.root())
+	}
+	return b.drawing
+}
+
+// root returns the pen of the entities outside blocks.
+func (b *builder) root() pen {
+	return pen{
+		color:      ColorFromACI(7, b.options.DarkBackground),
+		colorIndex: 7,
+		layer:      "0",
+		transform:  core.IdentityMatrix(),
+	}
+}
+
+func (b *builder) entity(entity entities.Entity, parent pen) {
+	base := entities.EntityBase(entity)
+	if base == nil {
+		return
+	}
+
+	layerName := base.LayerName
+	if parent.inBlock && (layerName == "0" || layerName == "") {
+		layerName = parent.layer
+	}
+	layer := b.layer(layerName)
+	if !base.Visible || !base.On || (layer != nil && (layer.Frozen || !layer.On)) {
+		return
+	}
+
+	p := pen{
+		lineType:      b.lineTypeName(base, layer, parent),
+		lineWeight:    parent.lineWeight,
+		layer:         layerName,
+		transform:     parent.transform,
+		inBlock:       parent.inBlock,
+		viewportScale: parent.viewportScale,
+	}
+	p.color, p.colorIndex = b.color(base, layer, parent)
+	if base.LineWeight > 0 {
+		p.lineWeight = float64(base.LineWeight) / 100.0
+	} else if base.LineWeight != -2 {
+		p.lineWeight = 0.0
+	}
+
+	tolerance := b.tolerance
+	if scale := matrixScale(p.transform); scale > 0.0 {
+		tolerance /= scale
+	}
+
+	switch e := entity.(type) {
+	case *entities.Insert:
+		b.insert(e, p)
+	case *entities.Text:
+		b.text(e, p)
+	case *entities.Point:
+		// points have no extents to draw.
+	case *entities.Solid:
+		b.fill([]core.PointSlice{e.Polygon()}, p)
+	case *entities.Viewport:
+		b.viewport(e, p)
+	case *entities.LWPolyline:
+		if outline := e.Outline(tolerance); len(outline) > 0 {
+			b.fill(outline, p)
+		} else {
+			b.stroke(e.Flatten(tolerance), base, p)
+		}
+	case *entities.Polyline:
+		if outline := e.Outline(tolerance); len(outline) > 0 {
+			b.fill(outline, p)
+		} else {
+			b.stroke(e.Flatten(tolerance), base, p)
+		}
+	case entities.Flattener:
+		b.stroke(e.Flatten(tolerance), base, p)
+	}
+}
+
+// stroke adds the polyline drawn with the pen and the entity linetype scale.
+func (b *builder) stroke(points core.PointSlice, base *entities.BaseEntity, p pen) {
+	if len(points) < 2 {
+		return
+	}
+
+	scale := b.ltScale * matrixScale(p.transform)
+	if base.LineTypeScale > 0.0 {
+		scale *= base.LineTypeScale
+	}
+	if b.paperLtScale && p.viewportScale > 0.0 {
+		scale /= p.viewportScale
+	}
+
+	color, lineWeight := b.plotStyle(p)
+	b.drawing.Primitives = append(b.drawing.Primitives, Path{
+		Polylines:  []core.PointSlice{p.transform.ApplySlice(points)},
+		Color:      color,
+		LineWeight: lineWeight,
+		Dashes:     b.dashes(p.lineType, scale),
+		Layer:      p.layer,
+	})
+}
+
+// fill adds the polygons filled with the pen color.
+func (b *builder) fill(polygons []core.PointSlice, p pen) {
+	transformed := make([]core.PointSlice, len(polygons))
+	for i, polygon := range polygons {
+		transformed[i] = p.transform.ApplySlice(polygon)
+	}
+
+	color, _ := b.plotStyle(p)
+	b.drawing.Primitives = append(b.drawing.Primitives, Path{
+		Polylines: transformed,
+		Filled:    true,
+		Color:     color,
+		Layer:     p.layer,
+	})
+}
+
+// plotStyle returns the color and line weight of the pen mapped by the
+// PlotStyles of the options.
+func (b *builder) plotStyle(p pen) (core.TrueColor, float64) {
+	color, lineWeight := p.color, p.lineWeight
+	if style, ok := b.options.PlotStyles[p.colorIndex]; ok && p.colorIndex != 0 {
+		if !style.UseObjectColor {
+			color = style.Color
+		}
+		if style.LineWeight > 0.0 {
+			lineWeight = style.LineWeight
+		}
+	}
+	return color, lineWeight
+}
+
+// viewport adds the model space seen through the active Viewport, clipped to
+// its window. The paper space Viewport (ID 1) is not a window.
+func (b *builder) viewport(viewport *entities.Viewport, p pen) {
+	if viewport.ID == 1 || viewport.Status == 0 || viewport.Scale() <= 0.0 {
+		return
+	}
+
+	window := core.NewExtents()
+	for _, corner := range p.transform.ApplySlice(viewport.Window()) {
+		window.Add(corner)
+	}
+
+	child := b.root()
+	child.transform = p.transform.Multiply(viewport.ModelToPaper())
+	child.viewportScale = viewport.Scale()
+
+	start := len(b.drawing.Primitives)
+	for _, entity := range b.modelSpace {
+		b.entity(entity, child)
+	}
+
+	clipped := b.drawing.Primitives[:start]
+	for _, primitive := range b.drawing.Primitives[start:] {
+		if primitive, ok := clipPrimitive(primitive, window); ok {
+			clipped = append(clipped, primitive)
+		}
+	}
+	b.drawing.Primitives = clipped
+}
+
+// insert adds the entities of the Block for every instance of the Insert
+// array and then its attributes. Blocks inserting themselves are skipped.
+func (b *builder) insert(insert *entities.Insert, p pen) {
+	if block, ok := b.doc.Blocks[insert.BlockName]; ok && !b.active[block.Name] {
+		b.active[block.Name] = true
+
+		for column := 0; column < maxInt(insert.ColumnCount, 1); column++ {
+			for row := 0; row < maxInt(insert.RowCount, 1); row++ {
+				child := p
+				child.inBlock = true
+				child.transform = p.transform.Multiply(
+					insert.Transform(block.BasePoint, column, row))
+
+				for _, entity := range block.Entities {
+					b.entity(entity, child)
+				}
+			}
+		}
+
+		delete(b.active, block.Name)
+	}
+
+	for _, attribute := range insert.Entities {
+		b.entity(attribute, p)
+	}
+}
+
+// textControlCodes replaces the DXF special characters codes.
+var textControlCodes = strings.NewReplacer(
+	"%%d", "°", "%%D", "°",
+	"%%p", "±", "%%P", "±",
+	"%%c", "⌀", "%%C", "⌀",
+	"%%%", "%",
+	"%%u", "", "%%U", "",
+	"%%o", "", "%%O", "",
+	"%%k", "", "%%K", "",
+)
+
+// text adds the Label of the Text, resolving its justification.
+func (b *builder) text(text *entities.Text, p pen) {
+	value := textControlCodes.Replace(text.Value)
+	if value == "" {
+		return
+	}
+
+	label := Label{
+		Value:                   value,
+		Height:                  text.Height,
+		HorizontalJustification: text.HorizontalJustification,
+		VerticalJustification:   text.VerticalJustification,
+		Layer:                   p.layer,
+	}
+	label.Color, _ = b.plotStyle(p)
+	if style := b.style(text.StyleName); style != nil {
+		label.Font = style.Font
+		if label.Height == 0.0 {
+			label.Height = style.Height
+		}
+	}
+
+	anchor := text.SecondAlignmentPoint
+	rotation := text.Rotation * math.Pi / 180.0
+	xScale := text.RelativeXScale
+	if xScale == 0.0 {
+		xScale = 1.0
+	}
+
+	switch text.HorizontalJustification {
+	case entities.HTEXT_ALIGNED, entities.HTEXT_FIT:
+		label.HorizontalJustification = entities.HTEXT_LEFT
+		label.VerticalJustification = entities.VTEXT_BASELINE
+		anchor = text.FirstAlignmentPoint
+
+		baseline := text.SecondAlignmentPoint.Sub(text.FirstAlignmentPoint)
+		baseline.Z = 0.0
+		if length := baseline.Length(); length > 0.0 {
+			if text.HorizontalJustification == entities.HTEXT_ALIGNED {
+				label.Height *= length / (label.Width() * xScale)
+			}
+			label.Length = length
+			rotation = math.Atan2(baseline.Y, baseline.X)
+			xScale = 1.0
+		}
+	case entities.HTEXT_MIDDLE:
+		label.HorizontalJustification = entities.HTEXT_CENTER
+		label.VerticalJustification = entities.VTEXT_MIDDLE
+	case entities.HTEXT_LEFT:
+		if text.VerticalJustification == entities.VTEXT_BASELINE {
+			anchor = text.FirstAlignmentPoint
+		}
+	}
+
+	mirrorX, mirrorY := 1.0, 1.0
+	if text.MirroredX {
+		mirrorX = -1.0
+	}
+	if text.MirroredY {
+		mirrorY = -1.0
+	}
+	shear := core.IdentityMatrix()
+	shear[0][1] = math.Tan(text.ObliqueAngle * math.Pi / 180.0)
+
+	label.Transform = p.transform.
+		Multiply(core.OCSMatrix(text.ExtrusionDirection)).
+		Multiply(core.TranslationMatrix(anchor)).
+		Multiply(core.RotationZMatrix(rotation)).
+		Multiply(core.ScaleMatrix(mirrorX, mirrorY, 1.0)).
+		Multiply(shear).
+		Multiply(core.ScaleMatrix(xScale, 1.0, 1.0))
+
+	b.drawing.Primitives = append(b.drawing.Primitives, label)
+}
+
+func (b *builder) layer(name string) *sections.Layer {
+	if b.doc.Tables == nil {
+		return nil
+	}
+	layer, _ := b.doc.Tables.Layers[name].(*sections.Layer)
+	return layer
+}
+
+func (b *builder) style(name string) *sections.Style {
+	if b.doc.Tables == nil {
+		return nil
+	}
+	if style, ok := b.doc.Tables.Styles[name].(*sections.Style); ok {
+		return style
+	}
+	for key, element := range b.doc.Tables.Styles {
+		if strings.EqualFold(key, name) {
+			style, _ := element.(*sections.Style)
+			return style
+		}
+	}
+	return nil
+}
+
+// color resolves the entity color and its ACI index, 0 for true colors.
+// Entities without color (0) are drawn by block inside Blocks and by layer
+// outside of them.
+func (b *builder) color(base *entities.BaseEntity, layer *sections.Layer,
+	parent pen) (core.TrueColor, int) {
+
+	if base.TrueColor != 0 {
+		return base.TrueColor, 0
+	}
+
+	index := base.Color
+	if index == 0 && parent.inBlock {
+		return parent.color, parent.colorIndex
+	}
+	if index == 0 || index == 256 {
+		index = 7
+		if layer != nil {
+			index = layer.Color
+		}
+	}
+	return ColorFromACI(index, b.options.DarkBackground), index
+}
+
+// lineTypeName resolves the BYLAYER and BYBLOCK linetypes of the entity.
+func (b *builder) lineTypeName(base *entities.BaseEntity, layer *sections.Layer,
+	parent pen) string {
+
+	name := base.LineTypeName
+	if strings.EqualFold(name, "BYBLOCK") && parent.inBlock {
+		return parent.lineType
+	}
+	if name == "" || strings.EqualFold(name, "BYLAYER") || strings.EqualFold(name, "BYBLOCK") {
+		if layer != nil {
+			return layer.LineType
+		}
+		return ""
+	}
+	return name
+}
+
+// dashes returns the pattern of the linetype, scaled. The elements of a
+// LineType pattern are positive for dashes, negative for gaps and zero for
+// dots, consecutive elements of the same kind are merged.
+func (b *builder) dashes(name string, scale float64) []float64 {
+	if name == "" || strings.EqualFold(name, "CONTINUOUS") || b.doc.Tables == nil {
+		return nil
+	}
+
+	lineType, ok := b.doc.Tables.LineTypes[name].(*sections.LineType)
+	if !ok {
+		for key, element := range b.doc.Tables.LineTypes {
+			if strings.EqualFold(key, name) {
+				lineType, ok = element.(*sections.LineType)
+			}
+		}
+	}
+	if !ok || len(lineType.Pattern) == 0 {
+		return nil
+	}
+
+	// even indexes hold dashes and odd indexes gaps.
+	dashes := []float64{}
+	for _, element := range lineType.Pattern {
+		isDash := element.Length >= 0.0
+		length := math.Abs(element.Length) * scale
+		if isDash == (len(dashes)%2 == 0) {
+			dashes = append(dashes, length)
+		} else if len(dashes) == 0 {
+			dashes = append(dashes, 0.0, length)
+		} else {
+			dashes[len(dashes)-1] += length
+		}
+	}
+	if len(dashes)%2 == 1 {
+		dashes = append(dashes, 0.0)
+	}
+	return dashes
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,296 @@
+package plot
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/sections"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func plotTestDocument() *document.DxfDocument {
+	base := func(layer string, color int) entities.BaseEntity {
+		return entities.BaseEntity{LayerName: layer, Color: color, On: true, Visible: true}
+	}
+
+	return &document.DxfDocument{
+		Header: &sections.HeaderSection{Values: map[string]core.TagSlice{
+			"$LTSCALE": {core.NewTag(40, core.NewFloatValue(2.0))},
+		}},
+		Tables: &sections.TablesSection{
+			Layers: sections.Table{
+				"0":      &sections.Layer{Name: "0", Color: 7, On: true},
+				"RED":    &sections.Layer{Name: "RED", Color: 1, On: true, LineType: "DASHED"},
+				"FROZEN": &sections.Layer{Name: "FROZEN", Color: 2, On: true, Frozen: true},
+				"OFF":    &sections.Layer{Name: "OFF", Color: 3, On: false},
+			},
+			Styles: sections.Table{
+				"STANDARD": &sections.Style{Name: "STANDARD", Font: "arial.ttf"},
+			},
+			LineTypes: sections.Table{
+				"DASHED": &sections.LineType{Name: "DASHED", Pattern: []*sections.LineElement{
+					{Length: 0.5}, {Length: -0.25},
+				}},
+				"DOT": &sections.LineType{Name: "DOT", Pattern: []*sections.LineElement{
+					{Length: -0.25}, {Length: 0.0}, {Length: -0.25},
+				}},
+			},
+		},
+		Entities: &sections.EntitiesSection{
+			Entities: entities.EntitySlice{
+				&entities.Line{BaseEntity: base("RED", 256), End: core.Point{X: 10.0}},
+				&entities.Line{BaseEntity: base("FROZEN", 256), End: core.Point{X: 100.0}},
+				&entities.Line{BaseEntity: base("OFF", 256), End: core.Point{X: 100.0}},
+				&entities.Point{BaseEntity: base("0", 256), Location: core.Point{X: -100.0}},
+				&entities.Insert{BaseEntity: base("RED", 5), BlockName: "B",
+					InsertionPoint: core.Point{X: 20.0},
+					ScaleFactorX:   2.0, ScaleFactorY: 2.0, ScaleFactorZ: 1.0,
+					ColumnCount: 2, RowCount: 1, ColumnSpacing: 10.0,
+					ExtrusionDirection: core.Point{Z: 1.0}},
+			},
+		},
+		Blocks: sections.BlocksSection{
+			"B": &sections.Block{
+				Name: "B",
+				Entities: entities.EntitySlice{
+					// by block color, by layer linetype from the insert layer.
+					&entities.Line{BaseEntity: base("0", 0), End: core.Point{Y: 1.0}},
+					&entities.Circle{BaseEntity: base("0", 3), Radius: 1.0,
+						ExtrusionDirection: core.Point{Z: 1.0}},
+					&entities.Insert{BaseEntity: base("0", 0), BlockName: "B",
+						ScaleFactorX: 1.0, ScaleFactorY: 1.0, ScaleFactorZ: 1.0,
+						ExtrusionDirection: core.Point{Z: 1.0}},
+				},
+			},
+		},
+	}
+}
+
+func TestFromDocument(t *testing.T) {
+	drawing := FromDocument(plotTestDocument(), Options{Tolerance: 0.01})
+	assert.Len(t, drawing.Primitives, 5)
+
+	line := drawing.Primitives[0].(Path)
+	assert.Equal(t, core.TrueColor(0xff0000), line.Color)
+	assert.Equal(t, "RED", line.Layer)
+	assert.Equal(t, []core.PointSlice{{{}, {X: 10.0}}}, line.Polylines)
+	// $LTSCALE applied.
+	assert.Equal(t, []float64{1.0, 0.5}, line.Dashes)
+
+	// the block is inserted twice.
+	for i, offset := range []float64{20.0, 30.0} {
+		blockLine := drawing.Primitives[1+2*i].(Path)
+		assert.Equal(t, core.TrueColor(0x0000ff), blockLine.Color)
+		assert.Equal(t, "RED", blockLine.Layer)
+		assert.True(t, core.PointSlice{{X: offset}, {X: offset, Y: 2.0}}.Equals(
+			blockLine.Polylines[0]), "%+v", blockLine.Polylines)
+		// scaled by the insert.
+		assert.Equal(t, []float64{2.0, 1.0}, blockLine.Dashes)
+
+		circle := drawing.Primitives[2+2*i].(Path)
+		assert.Equal(t, core.TrueColor(0x00ff00), circle.Color)
+		for _, point := range circle.Polylines[0] {
+			assert.InDelta(t, 2.0, point.Distance(core.Point{X: offset}), 0.01+1e-9)
+		}
+	}
+
+	extents := drawing.Extents()
+	assert.True(t, core.Point{X: 0.0, Y: -2.0}.Equals(extents.Min), "%+v", extents.Min)
+	assert.True(t, core.Point{X: 32.0, Y: 2.0}.Equals(extents.Max), "%+v", extents.Max)
+}
+
+func TestFromDocumentDefaultTolerance(t *testing.T) {
+	doc := plotTestDocument()
+	drawing := FromDocument(doc, Options{})
+	circle := drawing.Primitives[2].(Path)
+	// the drawing is about 32 units wide.
+	for _, point := range circle.Polylines[0] {
+		assert.InDelta(t, 2.0, point.Distance(core.Point{X: 20.0}), 0.04)
+	}
+	assert.True(t, len(circle.Polylines[0]) < 100)
+}
+
+func TestFromBlock(t *testing.T) {
+	drawing, err := FromBlock(plotTestDocument(), "B", Options{Tolerance: 0.01})
+	assert.Nil(t, err)
+	// the nested self reference is skipped.
+	assert.Len(t, drawing.Primitives, 2)
+	line := drawing.Primitives[0].(Path)
+	assert.Equal(t, []core.PointSlice{{{}, {Y: 1.0}}}, line.Polylines)
+	assert.Equal(t, "0", line.Layer)
+	assert.Nil(t, line.Dashes)
+
+	_, err = FromBlock(plotTestDocument(), "MISSING", Options{})
+	assert.NotNil(t, err)
+}
+
+func TestPlotWidePolyline(t *testing.T) {
+	doc := &document.DxfDocument{Entities: &sections.EntitiesSection{
+		Entities: entities.EntitySlice{
+			&entities.LWPolyline{
+				BaseEntity: entities.BaseEntity{Color: 1, On: true, Visible: true,
+					LineTypeName: "DOT", LineWeight: 50},
+				ConstantWidth: 1.0,
+				Points: entities.LWPolyLinePointSlice{
+					{Point: core.Point{X: 0.0}}, {Point: core.Point{X: 10.0}},
+				},
+				ExtrusionDirection: core.Point{Z: 1.0},
+			},
+			&entities.LWPolyline{
+				BaseEntity: entities.BaseEntity{Color: 1, On: true, Visible: true,
+					LineWeight: 50},
+				Points: entities.LWPolyLinePointSlice{
+					{Point: core.Point{X: 0.0}}, {Point: core.Point{X: 10.0}},
+				},
+				ExtrusionDirection: core.Point{Z: 1.0},
+			},
+		},
+	}}
+
+	drawing := FromDocument(doc, Options{DarkBackground: true})
+	assert.Len(t, drawing.Primitives, 2)
+	wide := drawing.Primitives[0].(Path)
+	assert.True(t, wide.Filled)
+	assert.Len(t, wide.Polylines, 1)
+	assert.Len(t, wide.Polylines[0], 5)
+
+	thin := drawing.Primitives[1].(Path)
+	assert.False(t, thin.Filled)
+	assert.Equal(t, 0.5, thin.LineWeight)
+}
+
+func TestPlotText(t *testing.T) {
+	doc := plotTestDocument()
+	text := &entities.Text{
+		BaseEntity:              entities.BaseEntity{LayerName: "RED", Color: 256, On: true, Visible: true},
+		Value:                   "%%c10 %%%",
+		Height:                  2.0,
+		Rotation:                90.0,
+		RelativeXScale:          1.0,
+		StyleName:               "standard",
+		HorizontalJustification: entities.HTEXT_RIGHT,
+		VerticalJustification:   entities.VTEXT_BOTTOM,
+		FirstAlignmentPoint:     core.Point{X: 1.0},
+		SecondAlignmentPoint:    core.Point{X: 5.0, Y: 5.0},
+		ExtrusionDirection:      core.Point{Z: 1.0},
+	}
+	doc.Entities.Entities = entities.EntitySlice{text}
+
+	label := FromDocument(doc, Options{}).Primitives[0].(Label)
+	assert.Equal(t, "⌀10 %", label.Value)
+	assert.Equal(t, "arial.ttf", label.Font)
+	assert.Equal(t, core.TrueColor(0xff0000), label.Color)
+	assert.Equal(t, entities.HTEXT_RIGHT, label.HorizontalJustification)
+	assert.True(t, core.Point{X: 5.0, Y: 5.0}.Equals(label.Transform.Apply(core.Point{})))
+	assert.True(t, core.Point{X: 5.0, Y: 6.0}.Equals(label.Transform.Apply(core.Point{X: 1.0})))
+
+	// fit text is stretched on the baseline.
+	text.HorizontalJustification = entities.HTEXT_FIT
+	label = FromDocument(doc, Options{}).Primitives[0].(Label)
+	assert.Equal(t, entities.HTEXT_LEFT, label.HorizontalJustification)
+	assert.InDelta(t, math.Hypot(4.0, 5.0), label.Length, 1e-12)
+	assert.True(t, core.Point{X: 1.0}.Equals(label.Transform.Apply(core.Point{})))
+	assert.Equal(t, 2.0, label.Height)
+
+	text.HorizontalJustification = entities.HTEXT_ALIGNED
+	label = FromDocument(doc, Options{}).Primitives[0].(Label)
+	assert.InDelta(t, math.Hypot(4.0, 5.0), label.Length, 1e-12)
+	assert.InDelta(t, 2.0*label.Length/(5.0*2.0*entities.TextCharWidthFactor), label.Height, 1e-12)
+}
+
+func TestPlotSolid(t *testing.T) {
+	doc := &document.DxfDocument{Entities: &sections.EntitiesSection{
+		Entities: entities.EntitySlice{
+			&entities.Solid{
+				BaseEntity:         entities.BaseEntity{Color: 2, On: true, Visible: true},
+				FirstCorner:        core.Point{},
+				SecondCorner:       core.Point{X: 1.0},
+				ThirdCorner:        core.Point{Y: 1.0},
+				FourthCorner:       core.Point{X: 1.0, Y: 1.0},
+				ExtrusionDirection: core.Point{Z: 1.0},
+			},
+		},
+	}}
+
+	drawing := FromDocument(doc, Options{})
+	assert.Len(t, drawing.Primitives, 1)
+	solid := drawing.Primitives[0].(Path)
+	assert.True(t, solid.Filled)
+	assert.Equal(t, core.TrueColor(0xffff00), solid.Color)
+	assert.Equal(t, []core.PointSlice{{{}, {X: 1.0}, {X: 1.0, Y: 1.0}, {Y: 1.0}, {}}}, solid.Polylines)
+}
+
+func TestFromLayout(t *testing.T) {
+	doc := plotTestDocument()
+	paper := entities.BaseEntity{Space: entities.PAPER, LayoutTabName: "Sheet",
+		LayerName: "0", Color: 256, On: true, Visible: true}
+	doc.Header.Values["$PSLTSCALE"] = core.TagSlice{core.NewTag(70, core.NewIntegerValue(1))}
+	doc.Entities.Entities = append(doc.Entities.Entities,
+		&entities.Viewport{BaseEntity: paper, ID: 1, Status: 1,
+			Center: core.Point{X: 150.0, Y: 100.0}, Width: 300.0, Height: 200.0,
+			ViewHeight: 200.0, ViewDirection: core.Point{Z: 1.0}},
+		// shows x in [-0.5, 19.5] and y in [-5, 5] ten times larger.
+		&entities.Viewport{BaseEntity: paper, ID: 2, Status: 1,
+			Center: core.Point{X: 100.0, Y: 100.0}, Width: 200.0, Height: 100.0,
+			ViewCenter: core.Point{X: 9.5}, ViewHeight: 10.0, ViewDirection: core.Point{Z: 1.0}},
+		&entities.Line{BaseEntity: paper, End: core.Point{X: 300.0}},
+	)
+
+	// paper space entities are not in the model space.
+	assert.Len(t, FromDocument(doc, Options{Tolerance: 0.01}).Primitives, 5)
+
+	drawing, err := FromLayout(doc, "Sheet", Options{Tolerance: 0.01})
+	assert.Nil(t, err)
+	// the RED line and the border of the first circle, then the paper line.
+	assert.Len(t, drawing.Primitives, 3)
+
+	line := drawing.Primitives[0].(Path)
+	assert.True(t, core.PointSlice{{X: 5.0, Y: 100.0}, {X: 105.0, Y: 100.0}}.Equals(
+		line.Polylines[0]), "%+v", line.Polylines)
+	// the paper space linetype scale ignores the viewport scale.
+	assert.Equal(t, []float64{1.0, 0.5}, line.Dashes)
+
+	// only the left part of the circle at x = 20 is in the window.
+	circle := drawing.Primitives[1].(Path)
+	assert.Len(t, circle.Polylines, 1)
+	for _, point := range circle.Polylines[0] {
+		assert.True(t, point.X <= 200.0+1e-9 && point.X >= 185.0-1e-9, "%+v", point)
+	}
+
+	paperLine := drawing.Primitives[2].(Path)
+	assert.Equal(t, []core.PointSlice{{{}, {X: 300.0}}}, paperLine.Polylines)
+
+	_, err = FromLayout(doc, "Missing", Options{})
+	assert.NotNil(t, err)
+}
+
+func TestPlotStyles(t *testing.T) {
+	doc := plotTestDocument()
+	doc.Entities.Entities = append(doc.Entities.Entities, &entities.Line{
+		BaseEntity: entities.BaseEntity{LayerName: "0", Color: 1, TrueColor: core.TrueColor(0x123456),
+			On: true, Visible: true},
+		End: core.Point{X: 1.0},
+	})
+
+	styles := MonochromePlotStyles()
+	styles[3] = PlotStyle{UseObjectColor: true, LineWeight: 0.7}
+	drawing := FromDocument(doc, Options{Tolerance: 0.01, PlotStyles: styles})
+	assert.Len(t, drawing.Primitives, 6)
+
+	// mapped by layer and by block colors.
+	line := drawing.Primitives[0].(Path)
+	assert.Equal(t, core.TrueColor(0), line.Color)
+	blockLine := drawing.Primitives[1].(Path)
+	assert.Equal(t, core.TrueColor(0), blockLine.Color)
+	assert.Equal(t, 0.0, blockLine.LineWeight)
+
+	circle := drawing.Primitives[2].(Path)
+	assert.Equal(t, core.TrueColor(0x00ff00), circle.Color)
+	assert.Equal(t, 0.7, circle.LineWeight)
+
+	// true colors are not mapped.
+	trueColor := drawing.Primitives[5].(Path)
+	assert.Equal(t, core.TrueColor(0x123456), trueColor.Color)
+}
@@ -0,0 +1,138 @@
+package plot
+
+import "github.com/rpaloschi/dxf-go/core"
+
+// clipPrimitive clips the primitive to the window in the XY plane. Paths are
+// cut at the window border, Labels are kept whole if they touch it. It
+// returns false if nothing is left.
+func clipPrimitive(primitive Primitive, window core.Extents) (Primitive, bool) {
+	switch p := primitive.(type) {
+	case Path:
+		var polylines []core.PointSlice
+		for _, polyline := range p.Polylines {
+			if p.Filled {
+				if polygon := clipPolygon(polyline, window); len(polygon) > 0 {
+					polylines = append(polylines, polygon)
+				}
+			} else {
+				polylines = append(polylines, clipPolyline(polyline, window)...)
+			}
+		}
+		p.Polylines = polylines
+		return p, len(polylines) > 0
+	case Label:
+		extents := p.Extents()
+		return p, extents.Min.X <= window.Max.X && extents.Max.X >= window.Min.X &&
+			extents.Min.Y <= window.Max.Y && extents.Max.Y >= window.Min.Y
+	}
+	return primitive, true
+}
+
+// clipPolyline returns the pieces of the polyline inside the window, with
+// the Liang-Barsky algorithm on every segment.
+func clipPolyline(polyline core.PointSlice, window core.Extents) []core.PointSlice {
+	var pieces []core.PointSlice
+	var current core.PointSlice
+
+	for i := 1; i < len(polyline); i++ {
+		start, end, ok := clipSegment(polyline[i-1], polyline[i], window)
+		if !ok {
+			if len(current) > 1 {
+				pieces = append(pieces, current)
+			}
+			current = nil
+			continue
+		}
+
+		if len(current) == 0 || !current[len(current)-1].Equals(start) {
+			if len(current) > 1 {
+				pieces = append(pieces, current)
+			}
+			current = core.PointSlice{start}
+		}
+		current = append(current, end)
+	}
+
+	if len(current) > 1 {
+		pieces = append(pieces, current)
+	}
+	return pieces
+}
+
+// clipSegment returns the part of the segment inside the window.
+func clipSegment(start, end core.Point, window core.Extents) (core.Point, core.Point, bool) {
+	delta := end.Sub(start)
+	t0, t1 := 0.0, 1.0
+	for _, edge := range [4][2]float64{
+		{-delta.X, start.X - window.Min.X},
+		{delta.X, window.Max.X - start.X},
+		{-delta.Y, start.Y - window.Min.Y},
+		{delta.Y, window.Max.Y - start.Y},
+	} {
+		p, q := edge[0], edge[1]
+		if p == 0.0 {
+			if q < 0.0 {
+				return start, end, false
+			}
+			continue
+		}
+		t := q / p
+		if p < 0.0 && t > t0 {
+			t0 = t
+		} else if p > 0.0 && t < t1 {
+			t1 = t
+		}
+	}
+	if t0 > t1 {
+		return start, end, false
+	}
+	return start.Add(delta.Scale(t0)), start.Add(delta.Scale(t1)), true
+}
+
+// clipPolygon returns the part of the closed polygon inside the window,
+// closed, with the Sutherland-Hodgman algorithm.
+func clipPolygon(polygon core.PointSlice, window core.Extents) core.PointSlice {
+	if len(polygon) > 1 && polygon[0].Equals(polygon[len(polygon)-1]) {
+		polygon = polygon[:len(polygon)-1]
+	}
+
+	edges := []struct {
+		inside   func(core.Point) bool
+		distance func(core.Point) float64
+	}{
+		{func(p core.Point) bool { return p.X >= window.Min.X },
+			func(p core.Point) float64 { return p.X - window.Min.X }},
+		{func(p core.Point) bool { return p.X <= window.Max.X },
+			func(p core.Point) float64 { return window.Max.X - p.X }},
+		{func(p core.Point) bool { return p.Y >= window.Min.Y },
+			func(p core.Point) float64 { return p.Y - window.Min.Y }},
+		{func(p core.Point) bool { return p.Y <= window.Max.Y },
+			func(p core.Point) float64 { return window.Max.Y - p.Y }},
+	}
+
+	for _, edge := range edges {
+		if len(polygon) == 0 {
+			return nil
+		}
+		var result core.PointSlice
+		previous := polygon[len(polygon)-1]
+		for _, current := range polygon {
+			currentInside, previousInside := edge.inside(current), edge.inside(previous)
+			if currentInside != previousInside {
+				// the signed distances to the edge locate the crossing.
+				d0, d1 := edge.distance(previous), edge.distance(current)
+				result = append(result, previous.Add(current.Sub(previous).Scale(d0/(d0-d1))))
+			}
+			if currentInside {
+				result = append(result, current)
+			}
+			previous = current
+		}
+		polygon = result
+	}
+
+	if len(polygon) < 3 {
+		return nil
+	}
+	return append(polygon, polygon[0])
+}
@@ -0,0 +1,55 @@
+package plot
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var clipWindow = core.Extents{Max: core.Point{X: 10.0, Y: 10.0}}
+
+func TestClipPolyline(t *testing.T) {
+	testCases := []struct {
+		polyline core.PointSlice
+		expected []core.PointSlice
+	}{
+		{core.PointSlice{{X: 1.0, Y: 1.0}, {X: 9.0, Y: 1.0}, {X: 9.0, Y: 9.0}},
+			[]core.PointSlice{{{X: 1.0, Y: 1.0}, {X: 9.0, Y: 1.0}, {X: 9.0, Y: 9.0}}}},
+		{core.PointSlice{{X: -5.0, Y: 5.0}, {X: 15.0, Y: 5.0}},
+			[]core.PointSlice{{{X: 0.0, Y: 5.0}, {X: 10.0, Y: 5.0}}}},
+		// leaves and enters again.
+		{core.PointSlice{{X: 5.0, Y: 5.0}, {X: 5.0, Y: 15.0}, {X: 8.0, Y: 15.0}, {X: 8.0, Y: 5.0}},
+			[]core.PointSlice{{{X: 5.0, Y: 5.0}, {X: 5.0, Y: 10.0}}, {{X: 8.0, Y: 10.0}, {X: 8.0, Y: 5.0}}}},
+		{core.PointSlice{{X: -5.0, Y: -5.0}, {X: -5.0, Y: 15.0}}, nil},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, clipPolyline(test.polyline, clipWindow), "%v", test.polyline)
+	}
+}
+
+func TestClipPolygon(t *testing.T) {
+	square := core.PointSlice{{X: 5.0, Y: 5.0}, {X: 15.0, Y: 5.0}, {X: 15.0, Y: 15.0},
+		{X: 5.0, Y: 15.0}, {X: 5.0, Y: 5.0}}
+	assert.Equal(t, core.PointSlice{{X: 5.0, Y: 10.0}, {X: 5.0, Y: 5.0}, {X: 10.0, Y: 5.0},
+		{X: 10.0, Y: 10.0}, {X: 5.0, Y: 10.0}}, clipPolygon(square, clipWindow))
+
+	outside := core.PointSlice{{X: 20.0}, {X: 30.0}, {X: 30.0, Y: 5.0}}
+	assert.Nil(t, clipPolygon(outside, clipWindow))
+}
+
+func TestClipPrimitive(t *testing.T) {
+	path := Path{Polylines: []core.PointSlice{{{X: 20.0}, {X: 30.0}}}}
+	_, ok := clipPrimitive(path, clipWindow)
+	assert.False(t, ok)
+
+	label := Label{Value: "A", Height: 1.0, Transform: core.TranslationMatrix(core.Point{X: 9.5})}
+	_, ok = clipPrimitive(label, clipWindow)
+	assert.True(t, ok)
+
+	label.HorizontalJustification = entities.HTEXT_LEFT
+	label.Transform = core.TranslationMatrix(core.Point{X: 10.5})
+	_, ok = clipPrimitive(label, clipWindow)
+	assert.False(t, ok)
+}
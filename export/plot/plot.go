@@ -0,0 +1,251 @@
+// Package plot converts DXF documents into a flat list of drawing primitives
+// in World Coordinates, resolving blocks, colors, layers and linetypes. It is
+// the common ground for the export formats.
+package plot
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+)
+
+// Options controls how a document is plotted.
+type Options struct {
+	// Tolerance is the chord tolerance used to flatten curves, in drawing
+	// units. If not positive, a thousandth of the drawing size is used.
+	Tolerance float64
+	// DarkBackground draws the ACI color 7 as white instead of black.
+	DarkBackground bool
+	// PlotStyles maps the ACI colors of the entities to the pens they are
+	// plotted with, as a color dependent plot style table (CTB) does.
+	PlotStyles PlotStyleTable
+}
+
+// PlotStyle a pen of a plot style table.
+type PlotStyle struct {
+	// Color replaces the color of the entities, unless UseObjectColor.
+	Color          core.TrueColor
+	UseObjectColor bool
+	// LineWeight in millimeters replaces the line weight of the entities if
+	// positive.
+	LineWeight float64
+}
+
+// PlotStyleTable maps ACI colors to plot styles. Entities with a true color
+// are plotted with their own pen.
+type PlotStyleTable map[int]PlotStyle
+
+// MonochromePlotStyles returns the table plotting every ACI color in black,
+// as monochrome.ctb.
+func MonochromePlotStyles() PlotStyleTable {
+	table := make(PlotStyleTable, len(core.DxfColors))
+	for index := 1; index < len(core.DxfColors); index++ {
+		table[index] = PlotStyle{Color: core.TrueColorFromRGB(0, 0, 0)}
+	}
+	return table
+}
+
+// Primitive is a drawing primitive: a Path or a Label.
+type Primitive interface {
+	// Extents returns the extents of the Primitive in WCS.
+	Extents() core.Extents
+}
+
+// Path a set of polylines drawn with the same pen. Closed polylines repeat the
+// first point at the end. Filled paths are polygons filled with the even-odd
+// rule and are not stroked.
+type Path struct {
+	Polylines []core.PointSlice
+	Filled    bool
+	Color     core.TrueColor
+	// LineWeight is the pen width in millimeters, 0 for the thinnest pen.
+	LineWeight float64
+	// Dashes is the linetype pattern in drawing units: the lengths of the
+	// dashes and the gaps between them, starting with a dash. Solid paths
+	// have no Dashes.
+	Dashes []float64
+	Layer  string
+}
+
+// Extents returns the extents of the Path polylines.
+func (p Path) Extents() core.Extents {
+	extents := core.NewExtents()
+	for _, polyline := range p.Polylines {
+		for _, point := range polyline {
+			extents.Add(point)
+		}
+	}
+	return extents
+}
+
+// maxDashesPerPolyline limits the number of dashes generated by Dashed,
+// denser patterns are drawn solid.
+const maxDashesPerPolyline = 10000
+
+// Dashed returns the pieces of the polylines drawn by the Dashes pattern.
+// The pattern restarts at each polyline. Solid paths return their polylines.
+func (p Path) Dashed() []core.PointSlice {
+	patternLength := 0.0
+	for _, length := range p.Dashes {
+		patternLength += length
+	}
+	if patternLength <= 0.0 || p.Filled {
+		return p.Polylines
+	}
+
+	var pieces []core.PointSlice
+	for _, polyline := range p.Polylines {
+		length := 0.0
+		for i := 1; i < len(polyline); i++ {
+			length += polyline[i-1].Distance(polyline[i])
+		}
+		if length/patternLength*float64(len(p.Dashes)) > maxDashesPerPolyline {
+			pieces = append(pieces, polyline)
+			continue
+		}
+		pieces = append(pieces, dashPolyline(polyline, p.Dashes)...)
+	}
+	return pieces
+}
+
+// dashPolyline splits the polyline into the dashes of the pattern.
+func dashPolyline(polyline core.PointSlice, pattern []float64) []core.PointSlice {
+	var pieces []core.PointSlice
+	var current core.PointSlice
+
+	index := 0
+	remaining := pattern[0]
+	for i := 1; i < len(polyline); i++ {
+		start, end := polyline[i-1], polyline[i]
+		segmentLength := start.Distance(end)
+		position := 0.0
+
+		for {
+			drawing := index%2 == 0
+			if drawing && len(current) == 0 {
+				current = core.PointSlice{pointAlong(start, end, position, segmentLength)}
+			}
+
+			if segmentLength-position <= remaining {
+				remaining -= segmentLength - position
+				if drawing {
+					current = append(current, end)
+				}
+				break
+			}
+
+			position += remaining
+			if drawing {
+				current = append(current, pointAlong(start, end, position, segmentLength))
+				pieces = append(pieces, current)
+				current = nil
+			}
+			index = (index + 1) % len(pattern)
+			remaining = pattern[index]
+		}
+	}
+
+	if len(current) > 1 {
+		pieces = append(pieces, current)
+	}
+	return pieces
+}
+
+// pointAlong returns the point at the distance from start towards end.
+func pointAlong(start, end core.Point, distance float64, length float64) core.Point {
+	if length == 0.0 {
+		return start
+	}
+	return start.Add(end.Sub(start).Scale(distance / length))
+}
+
+// Label a single line of text. The text is laid out in its own coordinates,
+// along the X axis with the Y axis up and the origin on the anchor, Transform
+// maps them to WCS.
+type Label struct {
+	Value  string
+	Font   string
+	Height float64
+	// HorizontalJustification is one of HTEXT_LEFT, HTEXT_CENTER or
+	// HTEXT_RIGHT, the horizontal position of the anchor in the text.
+	HorizontalJustification entities.HorizontalTextJustification
+	// VerticalJustification is the vertical position of the anchor.
+	VerticalJustification entities.VerticalTextJustification
+	// Length, if positive, is the length the text should be stretched to.
+	Length    float64
+	Transform core.Matrix
+	Color     core.TrueColor
+	Layer     string
+}
+
+// Width returns the approximate width of the Label in its own coordinates,
+// see entities.TextCharWidthFactor.
+func (l Label) Width() float64 {
+	if l.Length > 0.0 {
+		return l.Length
+	}
+	return float64(utf8.RuneCountInString(l.Value)) * l.Height * entities.TextCharWidthFactor
+}
+
+// Extents returns the approximate extents of the Label.
+func (l Label) Extents() core.Extents {
+	width := l.Width()
+	x0, y0 := 0.0, 0.0
+	switch l.HorizontalJustification {
+	case entities.HTEXT_CENTER:
+		x0 = -width / 2.0
+	case entities.HTEXT_RIGHT:
+		x0 = -width
+	}
+	switch l.VerticalJustification {
+	case entities.VTEXT_MIDDLE:
+		y0 = -l.Height / 2.0
+	case entities.VTEXT_TOP:
+		y0 = -l.Height
+	}
+
+	extents := core.NewExtents()
+	for _, corner := range []core.Point{
+		{X: x0, Y: y0}, {X: x0 + width, Y: y0},
+		{X: x0 + width, Y: y0 + l.Height}, {X: x0, Y: y0 + l.Height},
+	} {
+		extents.Add(l.Transform.Apply(corner))
+	}
+	return extents
+}
+
+// Drawing the primitives of a plotted document, in drawing order.
+type Drawing struct {
+	Primitives []Primitive
+}
+
+// Extents returns the extents of all the primitives.
+func (d Drawing) Extents() core.Extents {
+	extents := core.NewExtents()
+	for _, primitive := range d.Primitives {
+		extents.Merge(primitive.Extents())
+	}
+	return extents
+}
+
+// ColorFromACI returns the color of an AutoCAD Color Index. The color 7 is
+// black, or white on dark backgrounds. Invalid indexes are drawn as color 7.
+func ColorFromACI(index int, darkBackground bool) core.TrueColor {
+	if index == 7 || index <= 0 || index >= len(core.DxfColors) {
+		if darkBackground {
+			return core.TrueColorFromRGB(0xff, 0xff, 0xff)
+		}
+		return core.TrueColorFromRGB(0, 0, 0)
+	}
+	return core.DxfColors[index]
+}
+
+// matrixScale returns the average scale applied by the transformation to
+// lengths in the XY plane.
+func matrixScale(m core.Matrix) float64 {
+	x := m.ApplyVector(core.Point{X: 1.0})
+	y := m.ApplyVector(core.Point{Y: 1.0})
+	return math.Sqrt(math.Abs(x.X*y.Y - x.Y*y.X))
+}
@@ -0,0 +1,81 @@
+package plot
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestPathDashed(t *testing.T) {
+	path := Path{
+		Polylines: []core.PointSlice{{{X: 0.0}, {X: 4.0}, {X: 4.0, Y: 3.0}}},
+		Dashes:    []float64{2.0, 1.0},
+	}
+
+	assert.Equal(t, []core.PointSlice{
+		{{X: 0.0}, {X: 2.0}},
+		{{X: 3.0}, {X: 4.0}, {X: 4.0, Y: 1.0}},
+		{{X: 4.0, Y: 2.0}, {X: 4.0, Y: 3.0}},
+	}, path.Dashed())
+
+	// dots are dashes without length.
+	path.Dashes = []float64{0.0, 2.0}
+	dots := path.Dashed()
+	assert.Len(t, dots, 4)
+	assert.Equal(t, core.PointSlice{{X: 2.0}, {X: 2.0}}, dots[1])
+
+	path.Dashes = nil
+	assert.Equal(t, path.Polylines, path.Dashed())
+
+	// too dense to be drawn.
+	path.Dashes = []float64{1e-9, 1e-9}
+	assert.Equal(t, path.Polylines, path.Dashed())
+}
+
+func TestPathExtents(t *testing.T) {
+	path := Path{Polylines: []core.PointSlice{{{X: 1.0, Y: 5.0}}, {{X: -1.0, Y: 2.0}}}}
+	extents := path.Extents()
+	assert.True(t, core.Point{X: -1.0, Y: 2.0}.Equals(extents.Min))
+	assert.True(t, core.Point{X: 1.0, Y: 5.0}.Equals(extents.Max))
+	assert.True(t, Path{}.Extents().IsEmpty())
+}
+
+func TestLabelExtents(t *testing.T) {
+	label := Label{
+		Value:                   "ABCDE",
+		Height:                  2.0,
+		HorizontalJustification: entities.HTEXT_CENTER,
+		VerticalJustification:   entities.VTEXT_TOP,
+		Transform: core.TranslationMatrix(core.Point{X: 10.0, Y: 10.0}).
+			Multiply(core.RotationZMatrix(math.Pi / 2.0)),
+	}
+
+	assert.InDelta(t, 6.0, label.Width(), 1e-12)
+	extents := label.Extents()
+	assert.True(t, core.Point{X: 10.0, Y: 7.0}.Equals(extents.Min), "%+v", extents.Min)
+	assert.True(t, core.Point{X: 12.0, Y: 13.0}.Equals(extents.Max), "%+v", extents.Max)
+
+	label.Length = 10.0
+	assert.Equal(t, 10.0, label.Width())
+}
+
+func TestColorFromACI(t *testing.T) {
+	testCases := []struct {
+		index    int
+		dark     bool
+		expected core.TrueColor
+	}{
+		{1, false, core.TrueColor(0xff0000)},
+		{5, true, core.TrueColor(0x0000ff)},
+		{7, false, core.TrueColor(0x000000)},
+		{7, true, core.TrueColor(0xffffff)},
+		{0, false, core.TrueColor(0x000000)},
+		{300, true, core.TrueColor(0xffffff)},
+	}
+
+	for _, test := range testCases {
+		assert.Equal(t, test.expected, ColorFromACI(test.index, test.dark), "Test case: %+v", test)
+	}
+}
@@ -0,0 +1,200 @@
+package raster
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"golang.org/x/image/vector"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/export/plot"
+)
+
+// minStrokeWidth is the width in pixels of the thinnest pen.
+const minStrokeWidth = 1.0
+
+// roundJoinWidth is the stroke width in pixels from which joins and caps are
+// drawn round, thinner strokes have no visible joins.
+const roundJoinWidth = 1.5
+
+// polygon a closed ring in pixel coordinates, the last point is joined to
+// the first one.
+type polygon []point
+
+type point struct {
+	X, Y float64
+}
+
+type renderer struct {
+	img        *image.RGBA
+	rasterizer *vector.Rasterizer
+	toPixels   core.Matrix
+	dpi        float64
+}
+
+func (r *renderer) path(p plot.Path) {
+	if p.Filled {
+		polygons := make([]polygon, 0, len(p.Polylines))
+		for _, polyline := range p.Polylines {
+			polygons = append(polygons, r.pixels(polyline))
+		}
+		r.fill(polygons, p.Color)
+		return
+	}
+
+	width := p.LineWeight * r.dpi / 25.4
+	if width < minStrokeWidth {
+		width = minStrokeWidth
+	}
+
+	var polygons []polygon
+	for _, polyline := range p.Dashed() {
+		polygons = append(polygons, strokePolygons(r.pixels(polyline), width)...)
+	}
+	r.fill(polygons, p.Color)
+}
+
+// pixels transforms the WCS points to pixel coordinates.
+func (r *renderer) pixels(points core.PointSlice) polygon {
+	result := make(polygon, len(points))
+	for i, p := range points {
+		transformed := r.toPixels.Apply(p)
+		result[i] = point{X: transformed.X, Y: transformed.Y}
+	}
+	return result
+}
+
+// strokePolygons returns the polygons covering a polyline drawn with a pen of
+// the given width: a quad for every segment and discs on the vertices for
+// wide pens. All the polygons have the same orientation, so the overlaps
+// add up instead of cancelling each other.
+func strokePolygons(polyline polygon, width float64) []polygon {
+	var polygons []polygon
+	halfWidth := width / 2.0
+	for i := 1; i < len(polyline); i++ {
+		a, b := polyline[i-1], polyline[i]
+		length := math.Hypot(b.X-a.X, b.Y-a.Y)
+		if length == 0.0 {
+			continue
+		}
+		nx, ny := -(b.Y-a.Y)/length*halfWidth, (b.X-a.X)/length*halfWidth
+		polygons = append(polygons, polygon{
+			{a.X + nx, a.Y + ny}, {b.X + nx, b.Y + ny},
+			{b.X - nx, b.Y - ny}, {a.X - nx, a.Y - ny},
+		})
+	}
+
+	if width >= roundJoinWidth || len(polygons) == 0 {
+		for _, vertex := range polyline {
+			polygons = append(polygons, disc(vertex, halfWidth))
+		}
+	}
+	return polygons
+}
+
+// disc approximates a circle with the orientation of the stroke quads.
+func disc(center point, radius float64) polygon {
+	sides := int(math.Ceil(math.Pi * radius))
+	if sides < 8 {
+		sides = 8
+	} else if sides > 64 {
+		sides = 64
+	}
+
+	result := make(polygon, sides)
+	for i := range result {
+		angle := -2.0 * math.Pi * float64(i) / float64(sides)
+		result[i] = point{center.X + radius*math.Cos(angle), center.Y + radius*math.Sin(angle)}
+	}
+	return result
+}
+
+// fill rasterizes the polygons together and blends them on the image with
+// the color. The rasterizer covers only the bounds of the polygons.
+func (r *renderer) fill(polygons []polygon, c core.TrueColor) {
+	// a pixel of slack keeps the anti-aliasing of the image borders.
+	clip := r.img.Bounds().Inset(-1)
+
+	var clipped []polygon
+	bounds := image.Rectangle{}
+	for _, p := range polygons {
+		p = clipPolygon(p, clip)
+		if len(p) < 3 {
+			continue
+		}
+		clipped = append(clipped, p)
+		for _, vertex := range p {
+			bounds = bounds.Union(image.Rect(
+				int(math.Floor(vertex.X)), int(math.Floor(vertex.Y)),
+				int(math.Floor(vertex.X))+1, int(math.Floor(vertex.Y))+1))
+		}
+	}
+	bounds = bounds.Intersect(r.img.Bounds())
+	if bounds.Empty() {
+		return
+	}
+
+	offsetX, offsetY := float64(bounds.Min.X), float64(bounds.Min.Y)
+	r.rasterizer.Reset(bounds.Dx(), bounds.Dy())
+	for _, p := range clipped {
+		r.rasterizer.MoveTo(float32(p[0].X-offsetX), float32(p[0].Y-offsetY))
+		for _, vertex := range p[1:] {
+			r.rasterizer.LineTo(float32(vertex.X-offsetX), float32(vertex.Y-offsetY))
+		}
+		r.rasterizer.ClosePath()
+	}
+
+	red, green, blue := c.Rgb()
+	src := image.NewUniform(color.RGBA{R: uint8(red), G: uint8(green), B: uint8(blue), A: 0xff})
+	r.rasterizer.Draw(r.img, bounds, src, image.Point{})
+}
+
+// clipPolygon clips the polygon to the rectangle (Sutherland-Hodgman). The
+// rasterizer walks every row between the ends of an edge, so far away
+// points are clipped first.
+func clipPolygon(p polygon, rect image.Rectangle) polygon {
+	minX, minY := float64(rect.Min.X), float64(rect.Min.Y)
+	maxX, maxY := float64(rect.Max.X), float64(rect.Max.Y)
+
+	edges := []struct {
+		inside    func(point) bool
+		intersect func(point, point) point
+	}{
+		{func(q point) bool { return q.X >= minX }, func(a, b point) point { return atX(a, b, minX) }},
+		{func(q point) bool { return q.X <= maxX }, func(a, b point) point { return atX(a, b, maxX) }},
+		{func(q point) bool { return q.Y >= minY }, func(a, b point) point { return atY(a, b, minY) }},
+		{func(q point) bool { return q.Y <= maxY }, func(a, b point) point { return atY(a, b, maxY) }},
+	}
+
+	for _, edge := range edges {
+		if len(p) == 0 {
+			break
+		}
+		var result polygon
+		previous := p[len(p)-1]
+		for _, current := range p {
+			if edge.inside(current) {
+				if !edge.inside(previous) {
+					result = append(result, edge.intersect(previous, current))
+				}
+				result = append(result, current)
+			} else if edge.inside(previous) {
+				result = append(result, edge.intersect(previous, current))
+			}
+			previous = current
+		}
+		p = result
+	}
+	return p
+}
+
+// atX returns the point of the segment ab with the given X.
+func atX(a, b point, x float64) point {
+	return point{x, a.Y + (b.Y-a.Y)*(x-a.X)/(b.X-a.X)}
+}
+
+// atY returns the point of the segment ab with the given Y.
+func atY(a, b point, y float64) point {
+	return point{a.X + (b.X-a.X)*(y-a.Y)/(b.Y-a.Y), y}
+}
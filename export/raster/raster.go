@@ -0,0 +1,141 @@
+// Package raster renders DXF documents and blocks into images, with pure Go
+// anti-aliased vector rasterization.
+package raster
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"golang.org/x/image/vector"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/export/plot"
+)
+
+// DefaultWidth is the image width used when no size is given.
+const DefaultWidth = 512
+
+// Options controls the raster rendering.
+type Options struct {
+	plot.Options
+	// Width and Height of the image in pixels. If only one of them is set
+	// the other follows the viewport aspect ratio, if none is set the Width
+	// is DefaultWidth. The viewport is centered in the image.
+	Width  int
+	Height int
+	// Viewport is the region of the drawing to render, in WCS. If it has no
+	// area the extents of the drawing are rendered.
+	Viewport core.Extents
+	// Margin around the viewport, as a fraction of its size.
+	Margin float64
+	// Background color of the image. If nil, the image is white, or black
+	// with DarkBackground.
+	Background color.Color
+	// DPI converts line weights to pixels, 96 if not positive.
+	DPI float64
+}
+
+// Render draws the model space of the document.
+func Render(doc *document.DxfDocument, options Options) *image.RGBA {
+	return RenderDrawing(plot.FromDocument(doc, options.Options), options)
+}
+
+// RenderBlock draws the named Block of the document.
+func RenderBlock(doc *document.DxfDocument, name string, options Options) (*image.RGBA, error) {
+	drawing, err := plot.FromBlock(doc, name, options.Options)
+	if err != nil {
+		return nil, err
+	}
+	return RenderDrawing(drawing, options), nil
+}
+
+// RenderDrawing draws the plotted drawing. The drawing is seen from the top,
+// the Z coordinates are ignored. Filled paths made of rings with opposite
+// orientations leave holes, as the outlines of closed wide polylines.
+func RenderDrawing(drawing *plot.Drawing, options Options) *image.RGBA {
+	viewport := options.Viewport
+	if viewport.IsEmpty() || !hasArea(viewport) {
+		viewport = drawing.Extents()
+	}
+
+	minX, minY, width, height := 0.0, 0.0, 1.0, 1.0
+	if !viewport.IsEmpty() {
+		minX, minY = viewport.Min.X, viewport.Min.Y
+		width, height = viewport.Max.X-minX, viewport.Max.Y-minY
+		// degenerate viewports are centered in a square.
+		size := math.Max(math.Max(width, height), 1e-9)
+		if width <= 0.0 {
+			minX, width = minX-size/2.0, size
+		}
+		if height <= 0.0 {
+			minY, height = minY-size/2.0, size
+		}
+		margin := options.Margin * math.Max(width, height)
+		minX, minY = minX-margin, minY-margin
+		width, height = width+2.0*margin, height+2.0*margin
+	}
+
+	imageWidth, imageHeight := options.Width, options.Height
+	if imageWidth <= 0 && imageHeight <= 0 {
+		imageWidth = DefaultWidth
+	}
+	if imageWidth <= 0 {
+		imageWidth = maxInt(int(math.Round(float64(imageHeight)*width/height)), 1)
+	}
+	if imageHeight <= 0 {
+		imageHeight = maxInt(int(math.Round(float64(imageWidth)*height/width)), 1)
+	}
+
+	// fits the viewport in the image, with the Y axis down.
+	scale := math.Min(float64(imageWidth)/width, float64(imageHeight)/height)
+	toPixels := core.TranslationMatrix(core.Point{
+		X: float64(imageWidth) / 2.0, Y: float64(imageHeight) / 2.0,
+	}).Multiply(core.ScaleMatrix(scale, -scale, 1.0)).Multiply(core.TranslationMatrix(core.Point{
+		X: -(minX + width/2.0), Y: -(minY + height/2.0),
+	}))
+
+	dpi := options.DPI
+	if dpi <= 0.0 {
+		dpi = 96.0
+	}
+
+	r := renderer{
+		img:        image.NewRGBA(image.Rect(0, 0, imageWidth, imageHeight)),
+		rasterizer: vector.NewRasterizer(0, 0),
+		toPixels:   toPixels,
+		dpi:        dpi,
+	}
+
+	background := options.Background
+	if background == nil {
+		background = color.White
+		if options.DarkBackground {
+			background = color.Black
+		}
+	}
+	draw.Draw(r.img, r.img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	for _, primitive := range drawing.Primitives {
+		switch p := primitive.(type) {
+		case plot.Path:
+			r.path(p)
+		case plot.Label:
+			r.label(p)
+		}
+	}
+	return r.img
+}
+
+func hasArea(extents core.Extents) bool {
+	return extents.Max.X > extents.Min.X && extents.Max.Y > extents.Min.Y
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
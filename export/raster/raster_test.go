@@ -0,0 +1,187 @@
+package raster
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/export/plot"
+	"github.com/rpaloschi/dxf-go/sections"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"testing"
+)
+
+var (
+	white = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	black = color.RGBA{A: 0xff}
+	red   = color.RGBA{R: 0xff, A: 0xff}
+)
+
+func rasterTestDocument() *document.DxfDocument {
+	base := entities.BaseEntity{Color: 1, On: true, Visible: true, LineWeight: 100}
+	return &document.DxfDocument{
+		Entities: &sections.EntitiesSection{
+			Entities: entities.EntitySlice{
+				&entities.Line{BaseEntity: base, Start: core.Point{Y: 5.0},
+					End: core.Point{X: 10.0, Y: 5.0}},
+				&entities.Line{BaseEntity: base, Start: core.Point{X: 5.0},
+					End: core.Point{X: 5.0, Y: 10.0}},
+			},
+		},
+		Blocks: sections.BlocksSection{
+			"SQUARE": &sections.Block{
+				Name: "SQUARE",
+				Entities: entities.EntitySlice{
+					&entities.Solid{
+						BaseEntity:         entities.BaseEntity{Color: 7, On: true, Visible: true},
+						SecondCorner:       core.Point{X: 2.0},
+						ThirdCorner:        core.Point{Y: 1.0},
+						FourthCorner:       core.Point{X: 2.0, Y: 1.0},
+						ExtrusionDirection: core.Point{Z: 1.0},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRender(t *testing.T) {
+	img := Render(rasterTestDocument(), Options{Width: 100})
+	assert.Equal(t, image.Rect(0, 0, 100, 100), img.Bounds())
+
+	// 1mm at 96 DPI is about 4 pixels.
+	assert.Equal(t, red, img.RGBAAt(50, 50))
+	assert.Equal(t, red, img.RGBAAt(20, 49))
+	assert.Equal(t, red, img.RGBAAt(49, 80))
+	assert.Equal(t, white, img.RGBAAt(20, 40))
+	assert.Equal(t, white, img.RGBAAt(10, 10))
+
+	// anti-aliased edges are blended with the background.
+	edge := img.RGBAAt(20, 48)
+	assert.Equal(t, uint8(0xff), edge.R)
+	assert.True(t, edge.G > 0 && edge.G < 0xff, "%v", edge)
+}
+
+func TestRenderSizeAndViewport(t *testing.T) {
+	doc := rasterTestDocument()
+
+	img := Render(doc, Options{})
+	assert.Equal(t, image.Rect(0, 0, DefaultWidth, DefaultWidth), img.Bounds())
+
+	img = Render(doc, Options{Height: 50, Margin: 0.5})
+	assert.Equal(t, image.Rect(0, 0, 50, 50), img.Bounds())
+	assert.Equal(t, white, img.RGBAAt(5, 25))
+	assert.Equal(t, red, img.RGBAAt(25, 25))
+
+	// the viewport shows the bottom left quarter in a wide image.
+	img = Render(doc, Options{Width: 200, Height: 100,
+		Viewport: core.Extents{Max: core.Point{X: 5.0, Y: 5.0}}})
+	assert.Equal(t, image.Rect(0, 0, 200, 100), img.Bounds())
+	assert.Equal(t, red, img.RGBAAt(150, 50))
+	assert.Equal(t, red, img.RGBAAt(100, 0))
+	assert.Equal(t, white, img.RGBAAt(100, 50))
+	// outside the viewport, but in the image.
+	assert.Equal(t, red, img.RGBAAt(180, 0))
+	assert.Equal(t, white, img.RGBAAt(180, 50))
+}
+
+func TestRenderBackground(t *testing.T) {
+	doc := &document.DxfDocument{}
+
+	img := Render(doc, Options{Width: 10, Height: 10, Options: plot.Options{DarkBackground: true}})
+	assert.Equal(t, black, img.RGBAAt(5, 5))
+
+	blue := color.RGBA{B: 0xff, A: 0xff}
+	img = Render(doc, Options{Width: 10, Height: 10, Background: blue})
+	assert.Equal(t, blue, img.RGBAAt(5, 5))
+
+	transparent := Render(doc, Options{Width: 10, Height: 10, Background: color.Transparent})
+	assert.Equal(t, color.RGBA{}, transparent.RGBAAt(5, 5))
+}
+
+func TestRenderBlock(t *testing.T) {
+	img, err := RenderBlock(rasterTestDocument(), "SQUARE", Options{Width: 40,
+		Options: plot.Options{DarkBackground: true}})
+	assert.Nil(t, err)
+	assert.Equal(t, image.Rect(0, 0, 40, 20), img.Bounds())
+	// the solid covers the whole image, white on dark backgrounds.
+	for _, p := range []image.Point{{0, 0}, {20, 10}, {39, 19}} {
+		assert.Equal(t, white, img.RGBAAt(p.X, p.Y), "%v", p)
+	}
+
+	_, err = RenderBlock(rasterTestDocument(), "MISSING", Options{})
+	assert.NotNil(t, err)
+}
+
+func TestRenderLabel(t *testing.T) {
+	label := plot.Label{
+		Value:                   "HH",
+		Height:                  10.0,
+		HorizontalJustification: entities.HTEXT_CENTER,
+		VerticalJustification:   entities.VTEXT_MIDDLE,
+		Transform:               core.TranslationMatrix(core.Point{X: 50.0, Y: 50.0}),
+		Color:                   core.TrueColorFromRGB(0xff, 0, 0),
+	}
+	drawing := &plot.Drawing{Primitives: []plot.Primitive{label}}
+	options := Options{Width: 100, Height: 100,
+		Viewport: core.Extents{Max: core.Point{X: 100.0, Y: 100.0}}}
+
+	ink := inkBounds(RenderDrawing(drawing, options))
+	// capital letters are as high as the text and centered.
+	assert.InDelta(t, 45, ink.Min.Y, 1)
+	assert.InDelta(t, 55, ink.Max.Y, 1)
+	assert.InDelta(t, 100-ink.Max.X, ink.Min.X, 2)
+	assert.True(t, ink.Dx() > 10 && ink.Dx() < 40, "%v", ink)
+
+	// stretched to the length.
+	label.Length = 60.0
+	drawing.Primitives[0] = label
+	ink = inkBounds(RenderDrawing(drawing, options))
+	assert.InDelta(t, 100-ink.Max.X, ink.Min.X, 2)
+	assert.True(t, ink.Dx() > 50 && ink.Dx() <= 60, "%v", ink)
+}
+
+// inkBounds returns the bounds of the pixels that are not white.
+func inkBounds(img *image.RGBA) image.Rectangle {
+	ink := image.Rectangle{}
+	for y := img.Bounds().Min.Y; y < img.Bounds().Max.Y; y++ {
+		for x := img.Bounds().Min.X; x < img.Bounds().Max.X; x++ {
+			if img.RGBAAt(x, y) != white {
+				ink = ink.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return ink
+}
+
+func TestClipPolygon(t *testing.T) {
+	square := polygon{{-10, -10}, {10, -10}, {10, 10}, {-10, 10}}
+	clipped := clipPolygon(square, image.Rect(0, 0, 5, 5))
+	assert.ElementsMatch(t, polygon{{0, 0}, {5, 0}, {5, 5}, {0, 5}}, clipped)
+
+	assert.Len(t, clipPolygon(square, image.Rect(20, 20, 30, 30)), 0)
+}
+
+func TestStrokePolygons(t *testing.T) {
+	polygons := strokePolygons(polygon{{0, 0}, {10, 0}, {10, 10}}, 2.0)
+	// two quads and a disc per vertex.
+	assert.Len(t, polygons, 5)
+	for _, p := range polygons {
+		assert.True(t, signedArea(p) < 0.0, "%v", p)
+	}
+	assert.Equal(t, polygon{{0, 1}, {10, 1}, {10, -1}, {0, -1}}, polygons[0])
+
+	// thin strokes have no joins, but isolated points are still drawn.
+	assert.Len(t, strokePolygons(polygon{{0, 0}, {10, 0}, {10, 10}}, 1.0), 2)
+	assert.Len(t, strokePolygons(polygon{{0, 0}, {0, 0}}, 1.0), 2)
+}
+
+func signedArea(p polygon) float64 {
+	area := 0.0
+	for i := range p {
+		next := p[(i+1)%len(p)]
+		area += p[i].X*next.Y - next.X*p[i].Y
+	}
+	return area / 2.0
+}
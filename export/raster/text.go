@@ -0,0 +1,193 @@
+package raster
+
+import (
+	"math"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/export/plot"
+)
+
+// labelFont is the font used for all the labels, the text style fonts are
+// not available to the renderer.
+var labelFont struct {
+	once sync.Once
+	font *sfnt.Font
+	// capHeight, descent and the outlines are in font units.
+	capHeight float64
+	descent   float64
+	err       error
+}
+
+func loadLabelFont() (*sfnt.Font, error) {
+	labelFont.once.Do(func() {
+		f, err := sfnt.Parse(goregular.TTF)
+		if err != nil {
+			labelFont.err = err
+			return
+		}
+
+		var buffer sfnt.Buffer
+		metrics, err := f.Metrics(&buffer, fixed.Int26_6(f.UnitsPerEm()), font.HintingNone)
+		if err != nil {
+			labelFont.err = err
+			return
+		}
+		labelFont.font = f
+		labelFont.capHeight = float64(metrics.CapHeight)
+		labelFont.descent = float64(metrics.Descent)
+	})
+	return labelFont.font, labelFont.err
+}
+
+// label draws the glyph outlines of the Label. The text height is the height
+// of the capital letters.
+func (r *renderer) label(l plot.Label) {
+	f, err := loadLabelFont()
+	if err != nil || l.Height <= 0.0 || labelFont.capHeight <= 0.0 {
+		return
+	}
+
+	// outlines are loaded in font units: one unit per em.
+	var buffer sfnt.Buffer
+	ppem := fixed.Int26_6(f.UnitsPerEm())
+
+	type glyph struct {
+		index sfnt.GlyphIndex
+		x     float64
+	}
+	var glyphs []glyph
+	advance := 0.0
+	previous := sfnt.GlyphIndex(0)
+	for _, character := range l.Value {
+		index, err := f.GlyphIndex(&buffer, character)
+		if err != nil {
+			continue
+		}
+		if previous != 0 {
+			if kern, err := f.Kern(&buffer, previous, index, ppem, font.HintingNone); err == nil {
+				advance += float64(kern)
+			}
+		}
+		glyphs = append(glyphs, glyph{index, advance})
+		if width, err := f.GlyphAdvance(&buffer, index, ppem, font.HintingNone); err == nil {
+			advance += float64(width)
+		}
+		previous = index
+	}
+
+	scale := l.Height / labelFont.capHeight
+	scaleX := scale
+	if l.Length > 0.0 && advance > 0.0 {
+		scaleX = l.Length / advance
+	}
+	width := advance * scaleX
+
+	x0, y0 := 0.0, 0.0
+	switch l.HorizontalJustification {
+	case entities.HTEXT_CENTER:
+		x0 = -width / 2.0
+	case entities.HTEXT_RIGHT:
+		x0 = -width
+	}
+	switch l.VerticalJustification {
+	case entities.VTEXT_BOTTOM:
+		y0 = labelFont.descent * scale
+	case entities.VTEXT_MIDDLE:
+		y0 = -l.Height / 2.0
+	case entities.VTEXT_TOP:
+		y0 = -l.Height
+	}
+
+	// sfnt outlines have the Y axis down.
+	layout := core.TranslationMatrix(core.Point{X: x0, Y: y0}).Multiply(
+		core.ScaleMatrix(scaleX, -scale, 1.0))
+	toPixels := r.toPixels.Multiply(l.Transform).Multiply(layout)
+
+	var polygons []polygon
+	for _, g := range glyphs {
+		segments, err := f.LoadGlyph(&buffer, g.index, ppem, nil)
+		if err != nil {
+			continue
+		}
+		transform := toPixels.Multiply(core.TranslationMatrix(core.Point{X: g.x}))
+		polygons = append(polygons, glyphPolygons(segments, transform)...)
+	}
+	r.fill(polygons, l.Color)
+}
+
+// glyphPolygons flattens the glyph outline transformed to pixels.
+func glyphPolygons(segments sfnt.Segments, transform core.Matrix) []polygon {
+	var polygons []polygon
+	var current polygon
+
+	toPoint := func(p fixed.Point26_6) point {
+		transformed := transform.Apply(core.Point{X: float64(p.X), Y: float64(p.Y)})
+		return point{transformed.X, transformed.Y}
+	}
+
+	for _, segment := range segments {
+		switch segment.Op {
+		case sfnt.SegmentOpMoveTo:
+			if len(current) > 2 {
+				polygons = append(polygons, current)
+			}
+			current = polygon{toPoint(segment.Args[0])}
+		case sfnt.SegmentOpLineTo:
+			current = append(current, toPoint(segment.Args[0]))
+		case sfnt.SegmentOpQuadTo:
+			current = appendCurve(current, []point{
+				current[len(current)-1], toPoint(segment.Args[0]), toPoint(segment.Args[1]),
+			})
+		case sfnt.SegmentOpCubeTo:
+			current = appendCurve(current, []point{
+				current[len(current)-1], toPoint(segment.Args[0]),
+				toPoint(segment.Args[1]), toPoint(segment.Args[2]),
+			})
+		}
+	}
+	if len(current) > 2 {
+		polygons = append(polygons, current)
+	}
+	return polygons
+}
+
+// appendCurve appends the points of the Bezier curve after its start, with a
+// step of about two pixels.
+func appendCurve(points polygon, controls []point) polygon {
+	length := 0.0
+	for i := 1; i < len(controls); i++ {
+		length += math.Hypot(controls[i].X-controls[i-1].X, controls[i].Y-controls[i-1].Y)
+	}
+	steps := int(math.Ceil(length / 2.0))
+	if steps < 1 {
+		steps = 1
+	} else if steps > 32 {
+		steps = 32
+	}
+
+	for step := 1; step <= steps; step++ {
+		points = append(points, bezierPoint(controls, float64(step)/float64(steps)))
+	}
+	return points
+}
+
+// bezierPoint evaluates the Bezier curve at t with de Casteljau.
+func bezierPoint(controls []point, t float64) point {
+	work := append([]point(nil), controls...)
+	for n := len(work) - 1; n > 0; n-- {
+		for i := 0; i < n; i++ {
+			work[i] = point{
+				work[i].X + (work[i+1].X-work[i].X)*t,
+				work[i].Y + (work[i+1].Y-work[i].Y)*t,
+			}
+		}
+	}
+	return work[0]
+}
@@ -0,0 +1,192 @@
+// Package svg renders DXF documents and blocks as SVG images.
+package svg
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/export/plot"
+)
+
+// Options controls the SVG rendering.
+type Options struct {
+	plot.Options
+	// Width of the image in pixels, the height follows the drawing aspect
+	// ratio. If zero, the image has no size and fills its container.
+	Width int
+	// Margin around the drawing, as a fraction of its size.
+	Margin float64
+}
+
+// pixelsPerMillimeter converts line weights to SVG pixels.
+const pixelsPerMillimeter = 96.0 / 25.4
+
+// Write renders the model space of the document as SVG.
+func Write(w io.Writer, doc *document.DxfDocument, options Options) error {
+	return WriteDrawing(w, plot.FromDocument(doc, options.Options), options)
+}
+
+// WriteBlock renders the named Block of the document as SVG.
+func WriteBlock(w io.Writer, doc *document.DxfDocument, name string, options Options) error {
+	drawing, err := plot.FromBlock(doc, name, options.Options)
+	if err != nil {
+		return err
+	}
+	return WriteDrawing(w, drawing, options)
+}
+
+// WriteDrawing renders the plotted drawing as SVG. The drawing is seen from
+// the top, the Z coordinates are ignored.
+func WriteDrawing(w io.Writer, drawing *plot.Drawing, options Options) error {
+	out := bufio.NewWriter(w)
+
+	extents := drawing.Extents()
+	minX, minY, width, height := 0.0, 0.0, 1.0, 1.0
+	if !extents.IsEmpty() {
+		minX, minY = extents.Min.X, extents.Min.Y
+		width, height = extents.Max.X-minX, extents.Max.Y-minY
+		// degenerate drawings are centered in a square.
+		size := maxFloat(maxFloat(width, height), 1e-9)
+		if width <= 0.0 {
+			minX, width = minX-size/2.0, size
+		}
+		if height <= 0.0 {
+			minY, height = minY-size/2.0, size
+		}
+		margin := options.Margin * maxFloat(width, height)
+		minX, minY = minX-margin, minY-margin
+		width, height = width+2.0*margin, height+2.0*margin
+	}
+
+	fmt.Fprintln(out, `<?xml version="1.0" encoding="UTF-8"?>`)
+	fmt.Fprintf(out, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="%v %v %v %v"`,
+		number(minX), number(-minY-height), number(width), number(height))
+	if options.Width > 0 {
+		fmt.Fprintf(out, ` width="%v" height="%v"`,
+			options.Width, number(float64(options.Width)*height/width))
+	}
+	fmt.Fprintln(out, `>`)
+
+	if options.DarkBackground {
+		fmt.Fprintf(out, `<rect x="%v" y="%v" width="%v" height="%v" fill="#000000"/>`+"\n",
+			number(minX), number(-minY-height), number(width), number(height))
+	}
+
+	// DXF has the Y axis up.
+	fmt.Fprintln(out, `<g transform="scale(1,-1)" fill="none" stroke-linecap="round" stroke-linejoin="round">`)
+	for _, primitive := range drawing.Primitives {
+		switch p := primitive.(type) {
+		case plot.Path:
+			writePath(out, p)
+		case plot.Label:
+			writeLabel(out, p)
+		}
+	}
+	fmt.Fprintln(out, `</g>`)
+	fmt.Fprintln(out, `</svg>`)
+
+	return out.Flush()
+}
+
+func writePath(out *bufio.Writer, p plot.Path) {
+	polylines := p.Dashed()
+	if len(polylines) == 0 {
+		return
+	}
+
+	var data strings.Builder
+	for _, polyline := range polylines {
+		for i, point := range polyline {
+			command := "L"
+			if i == 0 {
+				command = "M"
+			}
+			fmt.Fprintf(&data, "%v%v %v", command, number(point.X), number(point.Y))
+		}
+		if p.Filled {
+			data.WriteString("Z")
+		}
+	}
+
+	if p.Filled {
+		fmt.Fprintf(out, `<path d="%v" fill="%v" fill-rule="evenodd" stroke="none"/>`+"\n",
+			data.String(), color(p.Color))
+		return
+	}
+
+	strokeWidth := 1.0
+	if p.LineWeight > 0.0 {
+		strokeWidth = p.LineWeight * pixelsPerMillimeter
+	}
+	fmt.Fprintf(out, `<path d="%v" stroke="%v" stroke-width="%v" vector-effect="non-scaling-stroke"/>`+"\n",
+		data.String(), color(p.Color), number(strokeWidth))
+}
+
+func writeLabel(out *bufio.Writer, l plot.Label) {
+	m := l.Transform
+	// the text is drawn with the Y axis down.
+	fmt.Fprintf(out, `<text transform="matrix(%v %v %v %v %v %v) scale(1,-1)"`,
+		number(m[0][0]), number(m[1][0]), number(m[0][1]), number(m[1][1]),
+		number(m[0][3]), number(m[1][3]))
+	fmt.Fprintf(out, ` font-family="%v" font-size="%v" fill="%v"`,
+		fontFamily(l.Font), number(l.Height), color(l.Color))
+
+	switch l.HorizontalJustification {
+	case entities.HTEXT_CENTER:
+		fmt.Fprint(out, ` text-anchor="middle"`)
+	case entities.HTEXT_RIGHT:
+		fmt.Fprint(out, ` text-anchor="end"`)
+	}
+	switch l.VerticalJustification {
+	case entities.VTEXT_BOTTOM:
+		fmt.Fprint(out, ` dominant-baseline="text-after-edge"`)
+	case entities.VTEXT_MIDDLE:
+		fmt.Fprint(out, ` dominant-baseline="central"`)
+	case entities.VTEXT_TOP:
+		fmt.Fprint(out, ` dominant-baseline="text-before-edge"`)
+	}
+	if l.Length > 0.0 {
+		fmt.Fprintf(out, ` textLength="%v" lengthAdjust="spacingAndGlyphs"`, number(l.Length))
+	}
+
+	fmt.Fprint(out, `>`)
+	xml.EscapeText(out, []byte(l.Value))
+	fmt.Fprintln(out, `</text>`)
+}
+
+// fontFamily returns the font family of a text style font file. SHX fonts
+// have no SVG counterpart and are drawn with the generic sans-serif font.
+func fontFamily(font string) string {
+	name := strings.TrimSuffix(path.Base(strings.Replace(font, "\\", "/", -1)), path.Ext(font))
+	if name == "" || name == "." || strings.EqualFold(path.Ext(font), ".shx") {
+		return "sans-serif"
+	}
+
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(name))
+	return fmt.Sprintf("'%v', sans-serif", escaped.String())
+}
+
+func color(c core.TrueColor) string {
+	r, g, b := c.Rgb()
+	return fmt.Sprintf("#%02x%02x%02x", r, g, b)
+}
+
+func number(value float64) string {
+	return strconv.FormatFloat(value, 'g', 10, 64)
+}
+
+func maxFloat(a float64, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
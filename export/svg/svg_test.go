@@ -0,0 +1,96 @@
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/export/plot"
+	"github.com/rpaloschi/dxf-go/sections"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func svgTestDocument() *document.DxfDocument {
+	base := entities.BaseEntity{LayerName: "0", Color: 1, On: true, Visible: true}
+	return &document.DxfDocument{
+		Entities: &sections.EntitiesSection{
+			Entities: entities.EntitySlice{
+				&entities.Line{BaseEntity: base, End: core.Point{X: 10.0, Y: 5.0}},
+				&entities.Text{BaseEntity: base, Value: "A<B", Height: 1.0, RelativeXScale: 1.0,
+					HorizontalJustification: entities.HTEXT_CENTER,
+					SecondAlignmentPoint:    core.Point{X: 5.0, Y: 2.0},
+					ExtrusionDirection:      core.Point{Z: 1.0}},
+			},
+		},
+		Blocks: sections.BlocksSection{
+			"B": &sections.Block{Name: "B", Entities: entities.EntitySlice{
+				&entities.Line{BaseEntity: base, End: core.Point{X: 1.0}},
+			}},
+		},
+	}
+}
+
+func TestWrite(t *testing.T) {
+	var buffer bytes.Buffer
+	err := Write(&buffer, svgTestDocument(), Options{Width: 200})
+	assert.Nil(t, err)
+
+	output := buffer.String()
+	assert.Nil(t, xml.Unmarshal(buffer.Bytes(), new(interface{})), output)
+	assert.Contains(t, output, `viewBox="0 -5 10 5" width="200" height="100"`)
+	assert.Contains(t, output, `<path d="M0 0L10 5" stroke="#ff0000" stroke-width="1"`)
+	assert.Contains(t, output, `text-anchor="middle"`)
+	assert.Contains(t, output, `>A&lt;B</text>`)
+	assert.Contains(t, output, `matrix(1 0 0 1 5 2) scale(1,-1)`)
+	assert.NotContains(t, output, "<rect")
+}
+
+func TestWriteBlock(t *testing.T) {
+	var buffer bytes.Buffer
+	err := WriteBlock(&buffer, svgTestDocument(), "B", Options{Margin: 0.1,
+		Options: plot.Options{DarkBackground: true}})
+	assert.Nil(t, err)
+
+	output := buffer.String()
+	assert.Contains(t, output, `viewBox="-0.1 -0.6 1.2 1.2"`)
+	assert.Contains(t, output, `<rect x="-0.1" y="-0.6" width="1.2" height="1.2" fill="#000000"/>`)
+	assert.Equal(t, 1, strings.Count(output, "<path"))
+
+	err = WriteBlock(&buffer, svgTestDocument(), "MISSING", Options{})
+	assert.NotNil(t, err)
+}
+
+func TestWriteDrawing(t *testing.T) {
+	drawing := &plot.Drawing{Primitives: []plot.Primitive{
+		plot.Path{
+			Polylines: []core.PointSlice{{{X: 0.0}, {X: 4.0}}},
+			Dashes:    []float64{1.0, 1.0},
+			Color:     core.TrueColor(0x00ff00),
+		},
+		plot.Path{
+			Polylines: []core.PointSlice{{{X: 0.0}, {X: 1.0}, {X: 1.0, Y: 1.0}, {X: 0.0}}},
+			Filled:    true,
+		},
+		plot.Label{Value: "T", Font: "C:\\Fonts\\times.ttf", Height: 1.0,
+			VerticalJustification: entities.VTEXT_MIDDLE, Length: 2.0,
+			Transform: core.IdentityMatrix()},
+		plot.Label{Value: "S", Font: "txt.shx", Height: 1.0, Transform: core.IdentityMatrix()},
+	}}
+
+	var buffer bytes.Buffer
+	assert.Nil(t, WriteDrawing(&buffer, drawing, Options{}))
+	output := buffer.String()
+	assert.Contains(t, output, `d="M0 0L1 0M2 0L3 0"`)
+	assert.Contains(t, output, `d="M0 0L1 0L1 1L0 0Z" fill="#000000" fill-rule="evenodd"`)
+	assert.Contains(t, output, `font-family="'times', sans-serif"`)
+	assert.Contains(t, output, `dominant-baseline="central"`)
+	assert.Contains(t, output, `textLength="2"`)
+	assert.Contains(t, output, `font-family="sans-serif"`)
+
+	buffer.Reset()
+	assert.Nil(t, WriteDrawing(&buffer, &plot.Drawing{}, Options{}))
+	assert.Contains(t, buffer.String(), `viewBox="0 -1 1 1"`)
+}
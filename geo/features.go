@@ -0,0 +1,295 @@
+package geo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+)
+
+// Options controls the conversion of entities to geometries.
+type Options struct {
+	// Tolerance is the chord tolerance used to densify arcs and curves, in
+	// drawing units. If not positive, entities.DefaultFlattenTolerance.
+	Tolerance float64
+	// HasZ keeps the Z coordinates of the entities.
+	HasZ bool
+}
+
+// Feature a GeoJSON feature: a Geometry and its properties.
+type Feature struct {
+	Geometry   Geometry
+	Properties map[string]interface{}
+}
+
+// MarshalJSON encodes the Feature as GeoJSON.
+func (f Feature) MarshalJSON() ([]byte, error) {
+	properties := f.Properties
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	return json.Marshal(struct {
+		Type       string                 `json:"type"`
+		Geometry   Geometry               `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}{"Feature", f.Geometry, properties})
+}
+
+// FeatureCollection a GeoJSON feature collection.
+type FeatureCollection struct {
+	Features []Feature
+}
+
+// MarshalJSON encodes the FeatureCollection as GeoJSON.
+func (c FeatureCollection) MarshalJSON() ([]byte, error) {
+	features := c.Features
+	if features == nil {
+		features = []Feature{}
+	}
+	return json.Marshal(struct {
+		Type     string    `json:"type"`
+		Features []Feature `json:"features"`
+	}{"FeatureCollection", features})
+}
+
+// WriteGeoJSON writes the model space entities of the document as a GeoJSON
+// FeatureCollection, see FromDocument.
+func WriteGeoJSON(w io.Writer, doc *document.DxfDocument, options Options) error {
+	return json.NewEncoder(w).Encode(FromDocument(doc, options))
+}
+
+// FromDocument converts the model space entities of the document to
+// features. Every entity with a geometry is a feature, Inserts are exploded
+// to the multi geometry of their Block entities. The properties hold the
+// layer, color, handle and XDATA of the entities.
+func FromDocument(doc *document.DxfDocument, options Options) FeatureCollection {
+	converter := newConverter(doc, options)
+
+	var collection FeatureCollection
+	if doc.Entities == nil {
+		return collection
+	}
+	for _, entity := range doc.Entities.Entities {
+		if base := entities.EntityBase(entity); base != nil && base.Space != entities.MODEL {
+			continue
+		}
+		if feature, ok := converter.feature(entity); ok {
+			collection.Features = append(collection.Features, feature)
+		}
+	}
+	return collection
+}
+
+// EntityFeature converts the entity to a Feature. Inserts are resolved with
+// the Blocks of the document. It returns false for entities without
+// geometry.
+func EntityFeature(doc *document.DxfDocument, entity entities.Entity, options Options) (Feature, bool) {
+	return newConverter(doc, options).feature(entity)
+}
+
+// EntityGeometry converts the entity to a Geometry in WCS: POINT for Points
+// and Texts, POLYGON for closed polylines, Circles, full Ellipses and Solids,
+// LINE_STRING for the other curves. Inserts need the document, see
+// EntityFeature. It returns false for entities without geometry.
+func EntityGeometry(entity entities.Entity, options Options) (Geometry, bool) {
+	return newConverter(nil, options).geometry(entity)
+}
+
+type converter struct {
+	doc       *document.DxfDocument
+	options   Options
+	tolerance float64
+	active    map[string]bool
+}
+
+func newConverter(doc *document.DxfDocument, options Options) *converter {
+	tolerance := options.Tolerance
+	if tolerance <= 0.0 {
+		tolerance = entities.DefaultFlattenTolerance
+	}
+	return &converter{doc: doc, options: options, tolerance: tolerance,
+		active: make(map[string]bool)}
+}
+
+func (c *converter) feature(entity entities.Entity) (Feature, bool) {
+	geometry, ok := c.geometry(entity)
+	if !ok {
+		return Feature{}, false
+	}
+	return Feature{Geometry: geometry, Properties: properties(entity)}, true
+}
+
+func (c *converter) geometry(entity entities.Entity) (Geometry, bool) {
+	var geometry Geometry
+
+	switch e := entity.(type) {
+	case *entities.Point:
+		geometry = NewPoint(e.Location)
+	case *entities.Text:
+		geometry = NewPoint(core.OCSToWCS(textAnchor(e), e.ExtrusionDirection))
+	case *entities.Line:
+		geometry = NewLineString(core.PointSlice{e.Start, e.End})
+	case *entities.Solid:
+		geometry = NewPolygon(e.Polygon())
+	case *entities.Insert:
+		var ok bool
+		if geometry, ok = c.insert(e); !ok {
+			return Geometry{}, false
+		}
+	case *entities.LWPolyline:
+		geometry = c.curve(e.Flatten(c.tolerance), e.Closed)
+	case *entities.Polyline:
+		if !e.Is2d() && !e.Is3dPolyline {
+			return Geometry{}, false
+		}
+		geometry = c.curve(e.Flatten(c.tolerance), e.Closed)
+	case *entities.Circle:
+		geometry = c.curve(e.Flatten(c.tolerance), true)
+	case *entities.Ellipse:
+		// only the full ellipses end on their start.
+		geometry = c.curve(e.Flatten(c.tolerance), true)
+	case entities.Flattener:
+		geometry = c.curve(e.Flatten(c.tolerance), false)
+	default:
+		return Geometry{}, false
+	}
+
+	if geometry.IsEmpty() {
+		return Geometry{}, false
+	}
+	geometry.HasZ = c.options.HasZ
+	return geometry, true
+}
+
+// curve returns the flattened curve as a LINE_STRING, or as a POLYGON if it
+// is closed.
+func (c *converter) curve(points core.PointSlice, closed bool) Geometry {
+	if len(points) < 2 {
+		return Geometry{Type: LINE_STRING}
+	}
+	if !closed || len(points) < 4 || !points[0].Equals(points[len(points)-1]) {
+		return NewLineString(points)
+	}
+	return oriented(NewPolygon(points))
+}
+
+// oriented returns the Geometry with the polygon rings oriented as GeoJSON
+// requires: counter-clockwise exteriors and clockwise holes.
+func oriented(g Geometry) Geometry {
+	for i, polygon := range g.Polygons {
+		for j, ring := range polygon {
+			if (j == 0) != (signedArea(ring) >= 0.0) {
+				reversed := make(core.PointSlice, len(ring))
+				for k, point := range ring {
+					reversed[len(ring)-1-k] = point
+				}
+				g.Polygons[i][j] = reversed
+			}
+		}
+	}
+	for i, geometry := range g.Geometries {
+		g.Geometries[i] = oriented(geometry)
+	}
+	return g
+}
+
+// insert returns the geometries of the Block entities placed by the Insert,
+// for every instance of its array. Blocks inserting themselves are skipped.
+func (c *converter) insert(insert *entities.Insert) (Geometry, bool) {
+	if c.doc == nil {
+		return Geometry{}, false
+	}
+	block, ok := c.doc.Blocks[insert.BlockName]
+	if !ok || c.active[block.Name] {
+		return Geometry{}, false
+	}
+
+	c.active[block.Name] = true
+	defer delete(c.active, block.Name)
+
+	var geometries []Geometry
+	for column := 0; column < maxInt(insert.ColumnCount, 1); column++ {
+		for row := 0; row < maxInt(insert.RowCount, 1); row++ {
+			transform := insert.Transform(block.BasePoint, column, row)
+			for _, entity := range block.Entities {
+				if geometry, ok := c.geometry(entity); ok {
+					// mirrored inserts reverse the rings.
+					geometries = append(geometries, oriented(geometry.Transform(transform)))
+				}
+			}
+		}
+	}
+	if len(geometries) == 0 {
+		return Geometry{}, false
+	}
+	return Collect(geometries), true
+}
+
+// textAnchor returns the OCS point the Text is justified on.
+func textAnchor(text *entities.Text) core.Point {
+	switch {
+	case text.HorizontalJustification == entities.HTEXT_ALIGNED,
+		text.HorizontalJustification == entities.HTEXT_FIT,
+		text.HorizontalJustification == entities.HTEXT_LEFT &&
+			text.VerticalJustification == entities.VTEXT_BASELINE:
+		return text.FirstAlignmentPoint
+	}
+	return text.SecondAlignmentPoint
+}
+
+// properties returns the GIS attributes of the entity.
+func properties(entity entities.Entity) map[string]interface{} {
+	result := make(map[string]interface{})
+	if base := entities.EntityBase(entity); base != nil {
+		result["layer"] = base.LayerName
+		result["color"] = base.Color
+		if base.TrueColor != 0 {
+			r, g, b := base.TrueColor.Rgb()
+			result["true_color"] = fmt.Sprintf("#%02x%02x%02x", r, g, b)
+		}
+		if base.Handle != "" {
+			result["handle"] = base.Handle
+		}
+		if len(base.XData) > 0 {
+			xData := make(map[string][]interface{})
+			for application, tags := range base.XData {
+				values := []interface{}{}
+				for _, tag := range tags {
+					values = append(values, tag.Value.Value())
+				}
+				xData[application] = values
+			}
+			result["xdata"] = xData
+		}
+	}
+
+	switch e := entity.(type) {
+	case *entities.Text:
+		result["text"] = e.Value
+		result["height"] = e.Height
+		result["rotation"] = e.Rotation
+	case *entities.Insert:
+		result["block"] = e.BlockName
+	}
+	return result
+}
+
+// signedArea returns the area of the ring in the XY plane, positive for
+// counter-clockwise rings.
+func signedArea(ring core.PointSlice) float64 {
+	area := 0.0
+	for i := 1; i < len(ring); i++ {
+		area += ring[i-1].X*ring[i].Y - ring[i].X*ring[i-1].Y
+	}
+	return area / 2.0
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,145 @@
+package geo
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/rpaloschi/dxf-go/sections"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func geoTestDocument() *document.DxfDocument {
+	base := entities.BaseEntity{LayerName: "ROADS", Color: 1, On: true, Visible: true}
+	extrusion := core.Point{Z: 1.0}
+	return &document.DxfDocument{
+		Entities: &sections.EntitiesSection{
+			Entities: entities.EntitySlice{
+				&entities.Point{BaseEntity: entities.BaseEntity{LayerName: "0", Handle: "1A",
+					TrueColor: core.TrueColor(0x0080ff), XData: map[string]core.TagSlice{
+						"GIS": {core.NewTag(1000, core.NewStringValue("WELL")),
+							core.NewTag(1070, core.NewIntegerValue(3))},
+					}}, Location: core.Point{X: 1.0, Y: 2.0, Z: 3.0}},
+				&entities.Line{BaseEntity: base, End: core.Point{X: 10.0, Y: 5.0}},
+				// clockwise, GeoJSON exteriors are counter-clockwise.
+				&entities.LWPolyline{BaseEntity: base, Closed: true, ExtrusionDirection: extrusion,
+					Points: entities.LWPolyLinePointSlice{
+						{Point: core.Point{X: 0.0}}, {Point: core.Point{Y: 1.0}},
+						{Point: core.Point{X: 1.0, Y: 1.0}}, {Point: core.Point{X: 1.0}},
+					}},
+				&entities.Circle{BaseEntity: base, Center: core.Point{X: 5.0}, Radius: 2.0,
+					ExtrusionDirection: extrusion},
+				&entities.Text{BaseEntity: base, Value: "Main St", Height: 2.5,
+					FirstAlignmentPoint: core.Point{X: 3.0, Y: 4.0}, ExtrusionDirection: extrusion},
+				&entities.Insert{BaseEntity: base, BlockName: "TREE", InsertionPoint: core.Point{X: 20.0},
+					ScaleFactorX: 1.0, ScaleFactorY: 1.0, ScaleFactorZ: 1.0,
+					ColumnCount: 2, ColumnSpacing: 10.0, ExtrusionDirection: extrusion},
+				&entities.Line{BaseEntity: entities.BaseEntity{LayerName: "0", Space: entities.PAPER},
+					End: core.Point{X: 1.0}},
+			},
+		},
+		Blocks: sections.BlocksSection{
+			"TREE": &sections.Block{Name: "TREE", Entities: entities.EntitySlice{
+				&entities.Line{BaseEntity: base, End: core.Point{X: 1.0}},
+				&entities.Insert{BaseEntity: base, BlockName: "TREE",
+					ScaleFactorX: 1.0, ScaleFactorY: 1.0, ScaleFactorZ: 1.0, ExtrusionDirection: extrusion},
+			}},
+		},
+	}
+}
+
+func TestFromDocument(t *testing.T) {
+	collection := FromDocument(geoTestDocument(), Options{})
+	assert.Len(t, collection.Features, 6)
+
+	point := collection.Features[0]
+	assert.Equal(t, "POINT (1 2)", point.Geometry.WKT())
+	assert.Equal(t, "0", point.Properties["layer"])
+	assert.Equal(t, "1A", point.Properties["handle"])
+	assert.Equal(t, "#0080ff", point.Properties["true_color"])
+	assert.Equal(t, map[string][]interface{}{"GIS": {"WELL", 3}}, point.Properties["xdata"])
+
+	line := collection.Features[1]
+	assert.Equal(t, "LINESTRING (0 0, 10 5)", line.Geometry.WKT())
+	assert.Equal(t, "ROADS", line.Properties["layer"])
+	assert.Equal(t, 1, line.Properties["color"])
+	assert.NotContains(t, line.Properties, "handle")
+	assert.NotContains(t, line.Properties, "xdata")
+
+	polygon := collection.Features[2]
+	assert.Equal(t, "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))", polygon.Geometry.WKT())
+
+	circle := collection.Features[3].Geometry
+	assert.Equal(t, POLYGON, circle.Type)
+	assert.True(t, signedArea(circle.Polygons[0][0]) > 0.0)
+
+	text := collection.Features[4]
+	assert.Equal(t, "POINT (3 4)", text.Geometry.WKT())
+	assert.Equal(t, "Main St", text.Properties["text"])
+	assert.Equal(t, 2.5, text.Properties["height"])
+
+	// the block inserting itself is skipped.
+	insert := collection.Features[5]
+	assert.Equal(t, "MULTILINESTRING ((20 0, 21 0), (30 0, 31 0))", insert.Geometry.WKT())
+	assert.Equal(t, "TREE", insert.Properties["block"])
+}
+
+func TestFromDocumentOptions(t *testing.T) {
+	coarse := FromDocument(geoTestDocument(), Options{Tolerance: 0.5})
+	fine := FromDocument(geoTestDocument(), Options{Tolerance: 0.001})
+	assert.True(t, len(coarse.Features[3].Geometry.Polygons[0][0]) <
+		len(fine.Features[3].Geometry.Polygons[0][0]))
+
+	withZ := FromDocument(geoTestDocument(), Options{HasZ: true})
+	assert.Equal(t, "POINT Z (1 2 3)", withZ.Features[0].Geometry.WKT())
+}
+
+func TestEntityGeometry(t *testing.T) {
+	solid := &entities.Solid{FirstCorner: core.Point{X: 0.0}, SecondCorner: core.Point{X: 2.0},
+		ThirdCorner: core.Point{Y: 2.0}, FourthCorner: core.Point{Y: 2.0}, ExtrusionDirection: core.Point{Z: 1.0}}
+	geometry, ok := EntityGeometry(solid, Options{})
+	assert.True(t, ok)
+	assert.Equal(t, POLYGON, geometry.Type)
+
+	arc := &entities.Arc{Center: core.Point{}, Radius: 1.0, StartAngle: 0.0, EndAngle: 90.0,
+		ExtrusionDirection: core.Point{Z: 1.0}}
+	geometry, ok = EntityGeometry(arc, Options{})
+	assert.True(t, ok)
+	assert.Equal(t, LINE_STRING, geometry.Type)
+
+	// inserts need the blocks of the document.
+	_, ok = EntityGeometry(&entities.Insert{BlockName: "TREE"}, Options{})
+	assert.False(t, ok)
+	_, ok = EntityFeature(geoTestDocument(), &entities.Insert{BlockName: "MISSING"}, Options{})
+	assert.False(t, ok)
+}
+
+func TestWriteGeoJSON(t *testing.T) {
+	var buffer bytes.Buffer
+	assert.Nil(t, WriteGeoJSON(&buffer, geoTestDocument(), Options{}))
+
+	var decoded struct {
+		Type     string
+		Features []struct {
+			Type     string
+			Geometry struct {
+				Type        string
+				Coordinates json.RawMessage
+			}
+			Properties map[string]interface{}
+		}
+	}
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &decoded), buffer.String())
+	assert.Equal(t, "FeatureCollection", decoded.Type)
+	assert.Len(t, decoded.Features, 6)
+	assert.Equal(t, "Feature", decoded.Features[1].Type)
+	assert.Equal(t, "LineString", decoded.Features[1].Geometry.Type)
+	assert.Equal(t, "[[0,0],[10,5]]", string(decoded.Features[1].Geometry.Coordinates))
+	assert.Equal(t, "ROADS", decoded.Features[1].Properties["layer"])
+
+	buffer.Reset()
+	assert.Nil(t, WriteGeoJSON(&buffer, &document.DxfDocument{}, Options{}))
+	assert.Equal(t, `{"type":"FeatureCollection","features":[]}`+"\n", buffer.String())
+}
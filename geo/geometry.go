@@ -0,0 +1,299 @@
+// Package geo converts DXF entities to and from GIS geometries: GeoJSON
+// features and WKT.
+package geo
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// GeometryType the type of a Geometry, named as in GeoJSON.
+type GeometryType string
+
+const (
+	POINT               GeometryType = "Point"
+	LINE_STRING         GeometryType = "LineString"
+	POLYGON             GeometryType = "Polygon"
+	MULTI_POINT         GeometryType = "MultiPoint"
+	MULTI_LINE_STRING   GeometryType = "MultiLineString"
+	MULTI_POLYGON       GeometryType = "MultiPolygon"
+	GEOMETRY_COLLECTION GeometryType = "GeometryCollection"
+)
+
+// Geometry a GIS geometry. Only the field of its Type is used: Points for
+// POINT (a single one) and MULTI_POINT, Lines for LINE_STRING (a single one)
+// and MULTI_LINE_STRING, Polygons for POLYGON (a single one) and
+// MULTI_POLYGON, Geometries for GEOMETRY_COLLECTION. Polygons are lists of
+// closed rings, the exterior one first.
+type Geometry struct {
+	Type       GeometryType
+	Points     core.PointSlice
+	Lines      []core.PointSlice
+	Polygons   [][]core.PointSlice
+	Geometries []Geometry
+	// HasZ writes the Z coordinates, otherwise the geometry is 2D.
+	HasZ bool
+}
+
+// NewPoint returns a POINT Geometry.
+func NewPoint(point core.Point) Geometry {
+	return Geometry{Type: POINT, Points: core.PointSlice{point}}
+}
+
+// NewLineString returns a LINE_STRING Geometry.
+func NewLineString(points core.PointSlice) Geometry {
+	return Geometry{Type: LINE_STRING, Lines: []core.PointSlice{points}}
+}
+
+// NewPolygon returns a POLYGON Geometry from its rings, the exterior one
+// first.
+func NewPolygon(rings ...core.PointSlice) Geometry {
+	return Geometry{Type: POLYGON, Polygons: [][]core.PointSlice{rings}}
+}
+
+// IsEmpty returns true if the Geometry has no coordinates.
+func (g Geometry) IsEmpty() bool {
+	switch g.Type {
+	case POINT, MULTI_POINT:
+		return len(g.Points) == 0
+	case LINE_STRING, MULTI_LINE_STRING:
+		return len(g.Lines) == 0
+	case POLYGON, MULTI_POLYGON:
+		return len(g.Polygons) == 0
+	}
+	return len(g.Geometries) == 0
+}
+
+// Transform returns the Geometry with all the points transformed by m.
+func (g Geometry) Transform(m core.Matrix) Geometry {
+	result := g
+	result.Points = nil
+	if g.Points != nil {
+		result.Points = m.ApplySlice(g.Points)
+	}
+	result.Lines = transformLines(g.Lines, m)
+	result.Polygons = nil
+	for _, polygon := range g.Polygons {
+		result.Polygons = append(result.Polygons, transformLines(polygon, m))
+	}
+	result.Geometries = nil
+	for _, geometry := range g.Geometries {
+		result.Geometries = append(result.Geometries, geometry.Transform(m))
+	}
+	return result
+}
+
+func transformLines(lines []core.PointSlice, m core.Matrix) []core.PointSlice {
+	var result []core.PointSlice
+	for _, line := range lines {
+		result = append(result, m.ApplySlice(line))
+	}
+	return result
+}
+
+// Collect returns the geometries as a single one: the multi geometry of
+// their type if they all have the same kind, a GEOMETRY_COLLECTION otherwise.
+func Collect(geometries []Geometry) Geometry {
+	if len(geometries) == 0 {
+		return Geometry{Type: GEOMETRY_COLLECTION}
+	}
+
+	kind := multiType(geometries[0].Type)
+	result := Geometry{Type: kind}
+	for _, geometry := range geometries {
+		result.HasZ = result.HasZ || geometry.HasZ
+		if multiType(geometry.Type) != kind || kind == GEOMETRY_COLLECTION {
+			return Geometry{Type: GEOMETRY_COLLECTION, Geometries: geometries, HasZ: result.HasZ}
+		}
+		result.Points = append(result.Points, geometry.Points...)
+		result.Lines = append(result.Lines, geometry.Lines...)
+		result.Polygons = append(result.Polygons, geometry.Polygons...)
+	}
+	return result
+}
+
+func multiType(kind GeometryType) GeometryType {
+	switch kind {
+	case POINT, MULTI_POINT:
+		return MULTI_POINT
+	case LINE_STRING, MULTI_LINE_STRING:
+		return MULTI_LINE_STRING
+	case POLYGON, MULTI_POLYGON:
+		return MULTI_POLYGON
+	}
+	return GEOMETRY_COLLECTION
+}
+
+// geoJSONGeometry is the GeoJSON encoding of a Geometry.
+type geoJSONGeometry struct {
+	Type        GeometryType    `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates,omitempty"`
+	Geometries  []*Geometry     `json:"geometries,omitempty"`
+}
+
+// MarshalJSON encodes the Geometry as a GeoJSON geometry.
+func (g Geometry) MarshalJSON() ([]byte, error) {
+	encoded := geoJSONGeometry{Type: g.Type}
+
+	var coordinates interface{}
+	switch g.Type {
+	case POINT:
+		if len(g.Points) > 0 {
+			coordinates = g.position(g.Points[0])
+		} else {
+			coordinates = []float64{}
+		}
+	case MULTI_POINT:
+		coordinates = g.positions(g.Points)
+	case LINE_STRING:
+		if len(g.Lines) > 0 {
+			coordinates = g.positions(g.Lines[0])
+		} else {
+			coordinates = [][]float64{}
+		}
+	case MULTI_LINE_STRING:
+		coordinates = g.lines(g.Lines)
+	case POLYGON:
+		if len(g.Polygons) > 0 {
+			coordinates = g.lines(g.Polygons[0])
+		} else {
+			coordinates = [][][]float64{}
+		}
+	case MULTI_POLYGON:
+		polygons := [][][][]float64{}
+		for _, polygon := range g.Polygons {
+			polygons = append(polygons, g.lines(polygon))
+		}
+		coordinates = polygons
+	default:
+		encoded.Geometries = []*Geometry{}
+		for i := range g.Geometries {
+			encoded.Geometries = append(encoded.Geometries, &g.Geometries[i])
+		}
+	}
+
+	if coordinates != nil {
+		raw, err := json.Marshal(coordinates)
+		if err != nil {
+			return nil, err
+		}
+		encoded.Coordinates = raw
+	}
+	return json.Marshal(encoded)
+}
+
+func (g Geometry) position(point core.Point) []float64 {
+	if g.HasZ {
+		return []float64{point.X, point.Y, point.Z}
+	}
+	return []float64{point.X, point.Y}
+}
+
+func (g Geometry) positions(points core.PointSlice) [][]float64 {
+	positions := [][]float64{}
+	for _, point := range points {
+		positions = append(positions, g.position(point))
+	}
+	return positions
+}
+
+func (g Geometry) lines(lines []core.PointSlice) [][][]float64 {
+	result := [][][]float64{}
+	for _, line := range lines {
+		result = append(result, g.positions(line))
+	}
+	return result
+}
+
+// WKT returns the Well Known Text of the Geometry, with the Z dimension if
+// it HasZ.
+func (g Geometry) WKT() string {
+	var wkt strings.Builder
+	g.writeWKT(&wkt)
+	return wkt.String()
+}
+
+func (g Geometry) writeWKT(wkt *strings.Builder) {
+	wkt.WriteString(strings.ToUpper(string(g.Type)))
+	if g.HasZ {
+		wkt.WriteString(" Z")
+	}
+	if g.IsEmpty() {
+		wkt.WriteString(" EMPTY")
+		return
+	}
+
+	wkt.WriteString(" ")
+	switch g.Type {
+	case POINT:
+		g.writeWKTPoints(wkt, g.Points[:1])
+	case MULTI_POINT:
+		wkt.WriteString("(")
+		for i, point := range g.Points {
+			if i > 0 {
+				wkt.WriteString(", ")
+			}
+			g.writeWKTPoints(wkt, core.PointSlice{point})
+		}
+		wkt.WriteString(")")
+	case LINE_STRING:
+		g.writeWKTPoints(wkt, g.Lines[0])
+	case MULTI_LINE_STRING:
+		g.writeWKTLines(wkt, g.Lines)
+	case POLYGON:
+		g.writeWKTLines(wkt, g.Polygons[0])
+	case MULTI_POLYGON:
+		wkt.WriteString("(")
+		for i, polygon := range g.Polygons {
+			if i > 0 {
+				wkt.WriteString(", ")
+			}
+			g.writeWKTLines(wkt, polygon)
+		}
+		wkt.WriteString(")")
+	default:
+		wkt.WriteString("(")
+		for i, geometry := range g.Geometries {
+			if i > 0 {
+				wkt.WriteString(", ")
+			}
+			geometry.writeWKT(wkt)
+		}
+		wkt.WriteString(")")
+	}
+}
+
+func (g Geometry) writeWKTPoints(wkt *strings.Builder, points core.PointSlice) {
+	wkt.WriteString("(")
+	for i, point := range points {
+		if i > 0 {
+			wkt.WriteString(", ")
+		}
+		wkt.WriteString(number(point.X))
+		wkt.WriteString(" ")
+		wkt.WriteString(number(point.Y))
+		if g.HasZ {
+			wkt.WriteString(" ")
+			wkt.WriteString(number(point.Z))
+		}
+	}
+	wkt.WriteString(")")
+}
+
+func (g Geometry) writeWKTLines(wkt *strings.Builder, lines []core.PointSlice) {
+	wkt.WriteString("(")
+	for i, line := range lines {
+		if i > 0 {
+			wkt.WriteString(", ")
+		}
+		g.writeWKTPoints(wkt, line)
+	}
+	wkt.WriteString(")")
+}
+
+func number(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
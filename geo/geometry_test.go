@@ -0,0 +1,87 @@
+package geo
+
+import (
+	"encoding/json"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var square = core.PointSlice{{X: 0.0}, {X: 1.0}, {X: 1.0, Y: 1.0}, {Y: 1.0}, {X: 0.0}}
+
+func TestWKT(t *testing.T) {
+	tests := []struct {
+		geometry Geometry
+		expected string
+	}{
+		{NewPoint(core.Point{X: 1.5, Y: -2.0, Z: 3.0}), "POINT (1.5 -2)"},
+		{Geometry{Type: POINT}, "POINT EMPTY"},
+		{Geometry{Type: MULTI_POINT, Points: core.PointSlice{{X: 1.0}, {Y: 1.0}}},
+			"MULTIPOINT ((1 0), (0 1))"},
+		{NewLineString(core.PointSlice{{X: 0.0}, {X: 2.0, Y: 1.0}}), "LINESTRING (0 0, 2 1)"},
+		{Geometry{Type: MULTI_LINE_STRING, Lines: []core.PointSlice{{{X: 0.0}, {X: 1.0}}, {{Y: 1.0}, {Y: 2.0}}}},
+			"MULTILINESTRING ((0 0, 1 0), (0 1, 0 2))"},
+		{NewPolygon(square), "POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0))"},
+		{Geometry{Type: MULTI_POLYGON, Polygons: [][]core.PointSlice{{square}}},
+			"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 1, 0 0)))"},
+		{Geometry{Type: GEOMETRY_COLLECTION, Geometries: []Geometry{
+			NewPoint(core.Point{X: 1.0}), {Type: LINE_STRING}}},
+			"GEOMETRYCOLLECTION (POINT (1 0), LINESTRING EMPTY)"},
+		{Geometry{Type: POINT, Points: core.PointSlice{{X: 1.0, Y: 2.0, Z: 3.0}}, HasZ: true},
+			"POINT Z (1 2 3)"},
+		{Geometry{Type: POLYGON, HasZ: true}, "POLYGON Z EMPTY"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.geometry.WKT())
+	}
+}
+
+func TestGeometryMarshalJSON(t *testing.T) {
+	tests := []struct {
+		geometry Geometry
+		expected string
+	}{
+		{NewPoint(core.Point{X: 1.5, Y: 2.0}), `{"type":"Point","coordinates":[1.5,2]}`},
+		{NewLineString(core.PointSlice{{X: 0.0}, {X: 1.0, Z: 2.0}}),
+			`{"type":"LineString","coordinates":[[0,0],[1,0]]}`},
+		{Geometry{Type: LINE_STRING, Lines: []core.PointSlice{{{X: 0.0}, {X: 1.0, Z: 2.0}}}, HasZ: true},
+			`{"type":"LineString","coordinates":[[0,0,0],[1,0,2]]}`},
+		{NewPolygon(square), `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`},
+		{Geometry{Type: MULTI_POLYGON}, `{"type":"MultiPolygon","coordinates":[]}`},
+		{Geometry{Type: GEOMETRY_COLLECTION, Geometries: []Geometry{NewPoint(core.Point{})}},
+			`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[0,0]}]}`},
+	}
+
+	for _, test := range tests {
+		encoded, err := json.Marshal(test.geometry)
+		assert.Nil(t, err)
+		assert.Equal(t, test.expected, string(encoded))
+	}
+}
+
+func TestCollect(t *testing.T) {
+	point := NewPoint(core.Point{X: 1.0})
+	line := NewLineString(core.PointSlice{{X: 0.0}, {X: 1.0}})
+
+	collected := Collect([]Geometry{line, line})
+	assert.Equal(t, MULTI_LINE_STRING, collected.Type)
+	assert.Len(t, collected.Lines, 2)
+
+	collected = Collect([]Geometry{point, collected})
+	assert.Equal(t, GEOMETRY_COLLECTION, collected.Type)
+	assert.Len(t, collected.Geometries, 2)
+
+	assert.True(t, Collect(nil).IsEmpty())
+}
+
+func TestGeometryTransform(t *testing.T) {
+	transform := core.TranslationMatrix(core.Point{X: 10.0, Y: 5.0})
+	transformed := NewPolygon(square).Transform(transform)
+	assert.Equal(t, "POLYGON ((10 5, 11 5, 11 6, 10 6, 10 5))", transformed.WKT())
+	// the original geometry is not changed.
+	assert.Equal(t, 0.0, square[0].X)
+
+	collection := Geometry{Type: GEOMETRY_COLLECTION, Geometries: []Geometry{NewPoint(core.Point{})}}
+	assert.Equal(t, "GEOMETRYCOLLECTION (POINT (10 5))", collection.Transform(transform).WKT())
+}
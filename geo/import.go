@@ -0,0 +1,244 @@
+package geo
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+)
+
+// DefaultApplication is the XDATA application name of the imported feature
+// properties.
+const DefaultApplication = "GEOJSON"
+
+// ImportOptions controls the conversion of features to a DxfDocument.
+type ImportOptions struct {
+	// LayerProperty is the feature property holding the layer name of its
+	// entities. If empty, "layer". Features without it go to layer "0".
+	LayerProperty string
+	// Application is the XDATA application name holding the other
+	// properties. If empty, DefaultApplication.
+	Application string
+}
+
+// maxXDataString is the maximum length in bytes of the XDATA strings.
+const maxXDataString = 255
+
+// ImportReport describes the properties changed to fit in the XDATA of the
+// entities.
+type ImportReport struct {
+	// Truncated has the property names longer than maxXDataString, cut to
+	// that length.
+	Truncated []string
+	// Split has the names of the properties with a string value longer
+	// than maxXDataString, written as several 1000 strings in a 1002 group.
+	Split []string
+}
+
+// ToDocument builds a DxfDocument with the features as model space
+// entities: POINTs for points, LWPOLYLINEs for linestrings and closed
+// LWPOLYLINEs for every ring of the polygons. Lines with varying Z are 3D
+// POLYLINEs. The layer property sets the layer, created in the LAYER table,
+// the "color" and "true_color" properties the entity colors and the other
+// properties are kept as XDATA of the application, registered in the APPID
+// table: a 1000 tag with the name followed by the value. The strings too
+// long for XDATA are reported.
+func ToDocument(collection FeatureCollection, options ImportOptions) (*document.DxfDocument, ImportReport) {
+	layerProperty := options.LayerProperty
+	if layerProperty == "" {
+		layerProperty = "layer"
+	}
+	application := options.Application
+	if application == "" {
+		application = DefaultApplication
+	}
+
+	doc := document.New(document.DefaultVersion)
+	doc.AddAppID(application)
+	space := doc.ModelSpace()
+	var report ImportReport
+
+	for _, feature := range collection.Features {
+		base := entities.BaseEntity{LayerName: "0", On: true, Visible: true}
+		var xData core.TagSlice
+
+		names := make([]string, 0, len(feature.Properties))
+		for name := range feature.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			value := feature.Properties[name]
+			switch {
+			case name == layerProperty:
+				if layer, ok := value.(string); ok && layer != "" {
+					base.LayerName = layer
+					continue
+				}
+			case name == "color":
+				if color, ok := value.(float64); ok && color == math.Trunc(color) {
+					base.Color = int(color)
+					continue
+				}
+			case name == "true_color":
+				if color, ok := parseHexColor(value); ok {
+					base.TrueColor = color
+					continue
+				}
+			}
+			if len(name) > maxXDataString {
+				report.Truncated = append(report.Truncated, name)
+			}
+			tags := xDataValue(value)
+			if len(tags) > 1 {
+				report.Split = append(report.Split, name)
+			}
+			xData = append(xData, core.NewStringTag(1000, splitXDataString(name)[0]))
+			xData = append(xData, tags...)
+		}
+		if len(xData) > 0 {
+			base.XData = map[string]core.TagSlice{application: xData}
+		}
+
+		if _, ok := doc.Tables.Layers[base.LayerName]; !ok {
+			doc.AddLayer(base.LayerName, 7)
+		}
+		for _, entity := range GeometryEntities(feature.Geometry, base) {
+			space.Add(entity)
+		}
+	}
+	return doc, report
+}
+
+// parseHexColor parses a "#rrggbb" color.
+func parseHexColor(value interface{}) (core.TrueColor, bool) {
+	text, ok := value.(string)
+	if !ok || len(text) != 7 || text[0] != '#' {
+		return 0, false
+	}
+	color, err := strconv.ParseUint(text[1:], 16, 32)
+	return core.TrueColor(color), err == nil
+}
+
+// xDataValue returns the XDATA tags of a property value: 1040 for numbers,
+// 1071 for integers, 1070 for booleans and 1000 strings for the others,
+// encoded as JSON if they are not strings. The strings longer than
+// maxXDataString are split in several 1000 strings, in a 1002 group.
+func xDataValue(value interface{}) core.TagSlice {
+	switch v := value.(type) {
+	case string:
+		return xDataString(v)
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= math.MaxInt32 {
+			return core.TagSlice{core.NewIntegerTag(1071, int(v))}
+		}
+		return core.TagSlice{core.NewFloatTag(1040, v)}
+	case bool:
+		if v {
+			return core.TagSlice{core.NewIntegerTag(1070, 1)}
+		}
+		return core.TagSlice{core.NewIntegerTag(1070, 0)}
+	case nil:
+		return core.TagSlice{core.NewStringTag(1000, "")}
+	}
+	encoded, _ := json.Marshal(value)
+	return xDataString(string(encoded))
+}
+
+// xDataString returns the 1000 tag of the string, or the 1002 group of the
+// 1000 tags of its parts if it is longer than maxXDataString.
+func xDataString(value string) core.TagSlice {
+	parts := splitXDataString(value)
+	if len(parts) == 1 {
+		return core.TagSlice{core.NewStringTag(1000, value)}
+	}
+	tags := core.TagSlice{core.NewStringTag(1002, "{")}
+	for _, part := range parts {
+		tags = append(tags, core.NewStringTag(1000, part))
+	}
+	return append(tags, core.NewStringTag(1002, "}"))
+}
+
+// splitXDataString splits the string in parts of up to maxXDataString
+// bytes, without splitting its UTF-8 characters.
+func splitXDataString(value string) []string {
+	var parts []string
+	for len(value) > maxXDataString {
+		end := maxXDataString
+		for end > 0 && !utf8.RuneStart(value[end]) {
+			end--
+		}
+		parts = append(parts, value[:end])
+		value = value[end:]
+	}
+	return append(parts, value)
+}
+
+// GeometryEntities returns the entities drawing the geometry, all of them
+// with the attributes of base.
+func GeometryEntities(geometry Geometry, base entities.BaseEntity) entities.EntitySlice {
+	var result entities.EntitySlice
+	for _, point := range geometry.Points {
+		result = append(result, &entities.Point{BaseEntity: base, Location: point,
+			ExtrusionDirection: core.Point{Z: 1.0}})
+	}
+	for _, line := range geometry.Lines {
+		if entity := polylineEntity(line, false, base); entity != nil {
+			result = append(result, entity)
+		}
+	}
+	for _, polygon := range geometry.Polygons {
+		for _, ring := range polygon {
+			if len(ring) > 1 && ring[0].Equals(ring[len(ring)-1]) {
+				ring = ring[:len(ring)-1]
+			}
+			if entity := polylineEntity(ring, true, base); entity != nil {
+				result = append(result, entity)
+			}
+		}
+	}
+	for _, member := range geometry.Geometries {
+		result = append(result, GeometryEntities(member, base)...)
+	}
+	return result
+}
+
+// polylineEntity returns a LWPolyline through the points, or a 3D Polyline
+// if their Z varies.
+func polylineEntity(points core.PointSlice, closed bool, base entities.BaseEntity) entities.Entity {
+	if len(points) < 2 {
+		return nil
+	}
+
+	planar := true
+	for _, point := range points {
+		planar = planar && core.FloatEquals(point.Z, points[0].Z)
+	}
+
+	if !planar {
+		polyline := &entities.Polyline{BaseEntity: base, Closed: closed, Is3dPolyline: true,
+			ExtrusionDirection: core.Point{Z: 1.0}}
+		// the XDATA belongs to the polyline only.
+		vertexBase := base
+		vertexBase.XData = nil
+		for _, point := range points {
+			polyline.Vertices = append(polyline.Vertices, &entities.Vertex{
+				BaseEntity: vertexBase, Location: point, Is3dPolylineVertex: true})
+		}
+		return polyline
+	}
+
+	polyline := &entities.LWPolyline{BaseEntity: base, Closed: closed, Elevation: points[0].Z,
+		ExtrusionDirection: core.Point{Z: 1.0}}
+	for _, point := range points {
+		polyline.Points = append(polyline.Points, entities.LWPolyLinePoint{
+			Point: core.Point{X: point.X, Y: point.Y}})
+	}
+	return polyline
+}
@@ -0,0 +1,126 @@
+package geo
+
+import (
+	"bytes"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/document"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+const testFeatures = `{"type":"FeatureCollection","features":[
+	{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},
+		"properties":{"layer":"WELLS","name":"W1","depth":12.5,"count":3,"active":true,"tags":["a"]}},
+	{"type":"Feature","geometry":{"type":"LineString","coordinates":[[0,0],[10,5]]},
+		"properties":{"layer":"ROADS","color":1,"true_color":"#0080ff"}},
+	{"type":"Feature","geometry":{"type":"Polygon","coordinates":[
+		[[0,0],[4,0],[4,4],[0,4],[0,0]],[[1,1],[1,2],[2,2],[1,1]]]},"properties":{}},
+	{"type":"Feature","geometry":{"type":"LineString","coordinates":[[0,0,1],[1,0,2]]},"properties":null}
+]}`
+
+func TestToDocument(t *testing.T) {
+	collection, err := ReadGeoJSON(strings.NewReader(testFeatures))
+	assert.Nil(t, err)
+
+	doc, report := ToDocument(collection, ImportOptions{})
+	assert.Equal(t, ImportReport{}, report)
+	assert.Len(t, doc.Tables.Layers, 3)
+	assert.Contains(t, doc.Tables.Layers, "WELLS")
+	assert.Contains(t, doc.Tables.Layers, "ROADS")
+
+	list := doc.Entities.Entities
+	assert.Len(t, list, 5)
+
+	point := list[0].(*entities.Point)
+	assert.Equal(t, "WELLS", point.LayerName)
+	assert.True(t, core.TagSlice{
+		core.NewStringTag(1000, "active"), core.NewIntegerTag(1070, 1),
+		core.NewStringTag(1000, "count"), core.NewIntegerTag(1071, 3),
+		core.NewStringTag(1000, "depth"), core.NewFloatTag(1040, 12.5),
+		core.NewStringTag(1000, "name"), core.NewStringTag(1000, "W1"),
+		core.NewStringTag(1000, "tags"), core.NewStringTag(1000, `["a"]`),
+	}.Equals(point.XData[DefaultApplication]))
+
+	line := list[1].(*entities.LWPolyline)
+	assert.Equal(t, "ROADS", line.LayerName)
+	assert.Equal(t, 1, line.Color)
+	assert.Equal(t, core.TrueColor(0x0080ff), line.TrueColor)
+	assert.Nil(t, line.XData)
+	assert.False(t, line.Closed)
+
+	// every ring is a closed polyline, without the repeated point.
+	exterior, hole := list[2].(*entities.LWPolyline), list[3].(*entities.LWPolyline)
+	assert.True(t, exterior.Closed)
+	assert.Len(t, exterior.Points, 4)
+	assert.True(t, hole.Closed)
+	assert.Len(t, hole.Points, 3)
+	assert.Equal(t, "0", hole.LayerName)
+
+	polyline := list[4].(*entities.Polyline)
+	assert.True(t, polyline.Is3dPolyline)
+	assert.Len(t, polyline.Vertices, 2)
+}
+
+func TestToDocumentOptions(t *testing.T) {
+	collection := FeatureCollection{Features: []Feature{{
+		Geometry:   NewPoint(core.Point{}),
+		Properties: map[string]interface{}{"layer": "L", "class": "C"},
+	}}}
+	doc, _ := ToDocument(collection, ImportOptions{LayerProperty: "class", Application: "GIS"})
+
+	point := doc.Entities.Entities[0].(*entities.Point)
+	assert.Equal(t, "C", point.LayerName)
+	assert.True(t, core.TagSlice{
+		core.NewStringTag(1000, "layer"), core.NewStringTag(1000, "L"),
+	}.Equals(point.XData["GIS"]))
+
+	// the application is registered.
+	assert.NotNil(t, doc.AddAppID("GIS"))
+	assert.Nil(t, doc.AddAppID(DefaultApplication))
+}
+
+func TestToDocumentLongStrings(t *testing.T) {
+	long := strings.Repeat("é", 200)
+	collection := FeatureCollection{Features: []Feature{{
+		Geometry:   NewPoint(core.Point{}),
+		Properties: map[string]interface{}{"note": long, strings.Repeat("n", 300): 1.0},
+	}}}
+	doc, report := ToDocument(collection, ImportOptions{})
+	assert.Equal(t, ImportReport{Truncated: []string{strings.Repeat("n", 300)}, Split: []string{"note"}}, report)
+
+	// the strings are split at the characters.
+	point := doc.Entities.Entities[0].(*entities.Point)
+	assert.True(t, core.TagSlice{
+		core.NewStringTag(1000, strings.Repeat("n", 255)), core.NewIntegerTag(1071, 1),
+		core.NewStringTag(1000, "note"),
+		core.NewStringTag(1002, "{"),
+		core.NewStringTag(1000, strings.Repeat("é", 127)),
+		core.NewStringTag(1000, strings.Repeat("é", 73)),
+		core.NewStringTag(1002, "}"),
+	}.Equals(point.XData[DefaultApplication]))
+}
+
+func TestToDocumentRoundTrip(t *testing.T) {
+	collection, err := ReadGeoJSON(strings.NewReader(testFeatures))
+	assert.Nil(t, err)
+
+	var buffer bytes.Buffer
+	imported, _ := ToDocument(collection, ImportOptions{})
+	assert.Nil(t, document.DxfDocumentToStream(&buffer, imported))
+
+	doc, err := document.DxfDocumentFromStream(&buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, "AC1015", doc.Header.Get("$ACADVER")[0].Value.ToString())
+
+	features := FromDocument(doc, Options{}).Features
+	assert.Len(t, features, 5)
+	assert.Equal(t, "POINT (1 2)", features[0].Geometry.WKT())
+	assert.Equal(t, "WELLS", features[0].Properties["layer"])
+	assert.Contains(t, features[0].Properties, "xdata")
+	assert.Equal(t, "LINESTRING (0 0, 10 5)", features[1].Geometry.WKT())
+	assert.Equal(t, "#0080ff", features[1].Properties["true_color"])
+	assert.Equal(t, "POLYGON ((0 0, 4 0, 4 4, 0 4, 0 0))", features[2].Geometry.WKT())
+	assert.Equal(t, "LINESTRING (0 0, 1 0)", features[4].Geometry.WKT())
+}
@@ -0,0 +1,398 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// UnmarshalJSON decodes a GeoJSON geometry. HasZ is set if any position has
+// a Z coordinate.
+func (g *Geometry) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		Type        GeometryType    `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+		Geometries  []Geometry      `json:"geometries"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*g = Geometry{Type: decoded.Type}
+	var err error
+	switch decoded.Type {
+	case POINT:
+		var position []float64
+		if err = unmarshalCoordinates(decoded.Coordinates, &position); err == nil && len(position) > 0 {
+			g.Points = core.PointSlice{g.decodePoint(position)}
+		}
+	case MULTI_POINT, LINE_STRING:
+		var positions [][]float64
+		if err = unmarshalCoordinates(decoded.Coordinates, &positions); err == nil {
+			points := g.decodePoints(positions)
+			if decoded.Type == MULTI_POINT {
+				g.Points = points
+			} else if len(points) > 0 {
+				g.Lines = []core.PointSlice{points}
+			}
+		}
+	case MULTI_LINE_STRING, POLYGON:
+		var lines [][][]float64
+		if err = unmarshalCoordinates(decoded.Coordinates, &lines); err == nil {
+			rings := g.decodeLines(lines)
+			if decoded.Type == MULTI_LINE_STRING {
+				g.Lines = rings
+			} else if len(rings) > 0 {
+				g.Polygons = [][]core.PointSlice{rings}
+			}
+		}
+	case MULTI_POLYGON:
+		var polygons [][][][]float64
+		if err = unmarshalCoordinates(decoded.Coordinates, &polygons); err == nil {
+			for _, polygon := range polygons {
+				g.Polygons = append(g.Polygons, g.decodeLines(polygon))
+			}
+		}
+	case GEOMETRY_COLLECTION:
+		g.Geometries = decoded.Geometries
+		for _, geometry := range g.Geometries {
+			g.HasZ = g.HasZ || geometry.HasZ
+		}
+	default:
+		err = fmt.Errorf("Unsupported GeoJSON geometry type: %v", decoded.Type)
+	}
+	return err
+}
+
+func unmarshalCoordinates(data json.RawMessage, coordinates interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, coordinates)
+}
+
+// decodePoint returns the point of the GeoJSON position, recording its Z.
+func (g *Geometry) decodePoint(position []float64) core.Point {
+	point := core.Point{}
+	if len(position) > 0 {
+		point.X = position[0]
+	}
+	if len(position) > 1 {
+		point.Y = position[1]
+	}
+	if len(position) > 2 {
+		point.Z = position[2]
+		g.HasZ = true
+	}
+	return point
+}
+
+func (g *Geometry) decodePoints(positions [][]float64) core.PointSlice {
+	points := make(core.PointSlice, 0, len(positions))
+	for _, position := range positions {
+		points = append(points, g.decodePoint(position))
+	}
+	return points
+}
+
+func (g *Geometry) decodeLines(lines [][][]float64) []core.PointSlice {
+	result := make([]core.PointSlice, 0, len(lines))
+	for _, line := range lines {
+		result = append(result, g.decodePoints(line))
+	}
+	return result
+}
+
+// UnmarshalJSON decodes a GeoJSON feature.
+func (f *Feature) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		Type       string                 `json:"type"`
+		Geometry   *Geometry              `json:"geometry"`
+		Properties map[string]interface{} `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.Type != "Feature" {
+		return fmt.Errorf("Expected a GeoJSON Feature, found: %v", decoded.Type)
+	}
+
+	*f = Feature{Properties: decoded.Properties}
+	if decoded.Geometry != nil {
+		f.Geometry = *decoded.Geometry
+	}
+	return nil
+}
+
+// ReadGeoJSON reads the features of a GeoJSON FeatureCollection. A single
+// Feature or geometry is read as a collection of one feature.
+func ReadGeoJSON(r io.Reader) (FeatureCollection, error) {
+	var data json.RawMessage
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return FeatureCollection{}, err
+	}
+
+	var object struct {
+		Type     string    `json:"type"`
+		Features []Feature `json:"features"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return FeatureCollection{}, err
+	}
+
+	switch object.Type {
+	case "FeatureCollection":
+		return FeatureCollection{Features: object.Features}, nil
+	case "Feature":
+		var feature Feature
+		err := json.Unmarshal(data, &feature)
+		return FeatureCollection{Features: []Feature{feature}}, err
+	}
+
+	var geometry Geometry
+	if err := json.Unmarshal(data, &geometry); err != nil {
+		return FeatureCollection{}, err
+	}
+	return FeatureCollection{Features: []Feature{{Geometry: geometry}}}, nil
+}
+
+// ParseWKT parses the Well Known Text of a geometry. The Z coordinates are
+// kept, the M ones are dropped and an EWKT SRID prefix is ignored.
+func ParseWKT(wkt string) (Geometry, error) {
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(wkt)), "SRID=") {
+		if index := strings.Index(wkt, ";"); index >= 0 {
+			wkt = wkt[index+1:]
+		}
+	}
+
+	parser := &wktParser{tokens: wktTokens(wkt)}
+	geometry, err := parser.geometry()
+	if err == nil && parser.position < len(parser.tokens) {
+		err = fmt.Errorf("Unexpected WKT token: %v", parser.tokens[parser.position])
+	}
+	return geometry, err
+}
+
+// wktTypes maps the WKT geometry names to their GeometryType.
+var wktTypes = map[string]GeometryType{
+	"POINT":              POINT,
+	"LINESTRING":         LINE_STRING,
+	"POLYGON":            POLYGON,
+	"MULTIPOINT":         MULTI_POINT,
+	"MULTILINESTRING":    MULTI_LINE_STRING,
+	"MULTIPOLYGON":       MULTI_POLYGON,
+	"GEOMETRYCOLLECTION": GEOMETRY_COLLECTION,
+}
+
+func wktTokens(wkt string) []string {
+	var tokens []string
+	token := strings.Builder{}
+	flush := func() {
+		if token.Len() > 0 {
+			tokens = append(tokens, token.String())
+			token.Reset()
+		}
+	}
+
+	for _, character := range wkt {
+		switch character {
+		case '(', ')', ',':
+			flush()
+			tokens = append(tokens, string(character))
+		case ' ', '\t', '\r', '\n':
+			flush()
+		default:
+			token.WriteRune(character)
+		}
+	}
+	flush()
+	return tokens
+}
+
+type wktParser struct {
+	tokens   []string
+	position int
+	// dimensions of the positions of the current geometry: 2 if unknown,
+	// 3 for Z or M and 4 for ZM.
+	dimensions int
+	hasM       bool
+}
+
+func (p *wktParser) peek() string {
+	if p.position < len(p.tokens) {
+		return strings.ToUpper(p.tokens[p.position])
+	}
+	return ""
+}
+
+func (p *wktParser) next() string {
+	token := p.peek()
+	p.position++
+	return token
+}
+
+func (p *wktParser) expect(token string) error {
+	if found := p.next(); found != token {
+		if found == "" {
+			return fmt.Errorf("Invalid WKT, expected %v at the end", token)
+		}
+		return fmt.Errorf("Invalid WKT, expected %v, found: %v", token, found)
+	}
+	return nil
+}
+
+func (p *wktParser) geometry() (Geometry, error) {
+	name := p.next()
+	kind, ok := wktTypes[name]
+	if !ok {
+		return Geometry{}, fmt.Errorf("Unsupported WKT geometry type: %v", name)
+	}
+	geometry := Geometry{Type: kind}
+
+	p.dimensions, p.hasM = 2, false
+	switch p.peek() {
+	case "Z":
+		p.next()
+		p.dimensions, geometry.HasZ = 3, true
+	case "M":
+		p.next()
+		p.dimensions, p.hasM = 3, true
+	case "ZM":
+		p.next()
+		p.dimensions, p.hasM, geometry.HasZ = 4, true, true
+	}
+
+	if p.peek() == "EMPTY" {
+		p.next()
+		return geometry, nil
+	}
+
+	var err error
+	switch kind {
+	case POINT:
+		var points core.PointSlice
+		if points, err = p.positions(&geometry); err == nil {
+			if len(points) != 1 {
+				return geometry, errors.New("Invalid WKT, a POINT has a single position")
+			}
+			geometry.Points = points
+		}
+	case LINE_STRING:
+		var points core.PointSlice
+		if points, err = p.positions(&geometry); err == nil {
+			geometry.Lines = []core.PointSlice{points}
+		}
+	case MULTI_POINT:
+		geometry.Points, err = p.multiPoint(&geometry)
+	case MULTI_LINE_STRING:
+		geometry.Lines, err = p.lines(&geometry)
+	case POLYGON:
+		var rings []core.PointSlice
+		if rings, err = p.lines(&geometry); err == nil {
+			geometry.Polygons = [][]core.PointSlice{rings}
+		}
+	case MULTI_POLYGON:
+		err = p.list(func() error {
+			rings, err := p.lines(&geometry)
+			geometry.Polygons = append(geometry.Polygons, rings)
+			return err
+		})
+	case GEOMETRY_COLLECTION:
+		err = p.list(func() error {
+			member, err := p.geometry()
+			geometry.Geometries = append(geometry.Geometries, member)
+			geometry.HasZ = geometry.HasZ || member.HasZ
+			return err
+		})
+	}
+	return geometry, err
+}
+
+// list parses a parenthesized, comma separated list calling item for every
+// element.
+func (p *wktParser) list(item func() error) error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		if p.peek() != "," {
+			return p.expect(")")
+		}
+		p.next()
+	}
+}
+
+func (p *wktParser) positions(geometry *Geometry) (core.PointSlice, error) {
+	var points core.PointSlice
+	err := p.list(func() error {
+		point, err := p.coordinates(geometry)
+		points = append(points, point)
+		return err
+	})
+	return points, err
+}
+
+func (p *wktParser) lines(geometry *Geometry) ([]core.PointSlice, error) {
+	var lines []core.PointSlice
+	err := p.list(func() error {
+		line, err := p.positions(geometry)
+		lines = append(lines, line)
+		return err
+	})
+	return lines, err
+}
+
+// multiPoint parses the points of a MULTIPOINT, with or without parentheses
+// around every point.
+func (p *wktParser) multiPoint(geometry *Geometry) (core.PointSlice, error) {
+	var points core.PointSlice
+	err := p.list(func() error {
+		var point core.Point
+		var err error
+		if p.peek() == "(" {
+			var single core.PointSlice
+			if single, err = p.positions(geometry); err == nil && len(single) > 0 {
+				point = single[0]
+			}
+		} else {
+			point, err = p.coordinates(geometry)
+		}
+		points = append(points, point)
+		return err
+	})
+	return points, err
+}
+
+// coordinates parses the coordinates of a point, setting HasZ if it has a Z.
+func (p *wktParser) coordinates(geometry *Geometry) (core.Point, error) {
+	var values []float64
+	for p.position < len(p.tokens) {
+		token := p.peek()
+		if token == "," || token == ")" || token == "(" {
+			break
+		}
+		value, err := strconv.ParseFloat(p.next(), 64)
+		if err != nil {
+			return core.Point{}, fmt.Errorf("Invalid WKT coordinate: %v", token)
+		}
+		values = append(values, value)
+	}
+
+	if len(values) < 2 || len(values) > 4 || (p.dimensions > 2 && len(values) != p.dimensions) {
+		return core.Point{}, fmt.Errorf("Invalid WKT position with %v coordinates", len(values))
+	}
+	point := core.Point{X: values[0], Y: values[1]}
+	if len(values) > 2 && !(p.hasM && p.dimensions == 3) {
+		point.Z = values[2]
+		geometry.HasZ = true
+	}
+	return point, nil
+}
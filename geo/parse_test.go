@@ -0,0 +1,112 @@
+package geo
+
+import (
+	"encoding/json"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestParseWKT(t *testing.T) {
+	for _, wkt := range []string{
+		"POINT (1.5 -2)",
+		"POINT Z (1 2 3)",
+		"POINT EMPTY",
+		"LINESTRING (0 0, 2 1)",
+		"POLYGON ((0 0, 1 0, 1 1, 0 1, 0 0), (0.2 0.2, 0.2 0.4, 0.4 0.4, 0.2 0.2))",
+		"MULTIPOINT ((1 0), (0 1))",
+		"MULTILINESTRING ((0 0, 1 0), (0 1, 0 2))",
+		"MULTIPOLYGON (((0 0, 1 0, 1 1, 0 0)), ((5 5, 6 5, 6 6, 5 5)))",
+		"GEOMETRYCOLLECTION (POINT (1 0), LINESTRING (0 0, 1 1))",
+		"GEOMETRYCOLLECTION EMPTY",
+	} {
+		geometry, err := ParseWKT(wkt)
+		assert.Nil(t, err, wkt)
+		assert.Equal(t, wkt, geometry.WKT())
+	}
+
+	tests := []struct {
+		wkt      string
+		expected string
+	}{
+		{"point(1 2)", "POINT (1 2)"},
+		{"SRID=4326;POINT (1 2)", "POINT (1 2)"},
+		{"MULTIPOINT (1 0, 0 1)", "MULTIPOINT ((1 0), (0 1))"},
+		{"LINESTRING (0 0 1, 1 1 2)", "LINESTRING Z (0 0 1, 1 1 2)"},
+		{"LINESTRING M (0 0 7, 1 1 8)", "LINESTRING (0 0, 1 1)"},
+		{"POINT ZM (1 2 3 4)", "POINT Z (1 2 3)"},
+	}
+	for _, test := range tests {
+		geometry, err := ParseWKT(test.wkt)
+		assert.Nil(t, err, test.wkt)
+		assert.Equal(t, test.expected, geometry.WKT())
+	}
+}
+
+func TestParseWKTErrors(t *testing.T) {
+	for _, wkt := range []string{
+		"",
+		"CIRCLE (0 0)",
+		"POINT (1)",
+		"POINT (1 2, 3 4)",
+		"POINT Z (1 2)",
+		"LINESTRING (0 0, 1 a)",
+		"LINESTRING (0 0, 1 1",
+		"POINT (1 2) POINT (3 4)",
+	} {
+		_, err := ParseWKT(wkt)
+		assert.NotNil(t, err, wkt)
+	}
+}
+
+func TestGeometryUnmarshalJSON(t *testing.T) {
+	geometries := []Geometry{
+		NewPoint(core.Point{X: 1.5, Y: 2.0}),
+		{Type: POINT, Points: core.PointSlice{{X: 1.0, Y: 2.0, Z: 3.0}}, HasZ: true},
+		NewLineString(core.PointSlice{{X: 0.0}, {X: 1.0, Y: 2.0}}),
+		NewPolygon(square),
+		{Type: MULTI_POINT, Points: core.PointSlice{{X: 1.0}, {Y: 1.0}}},
+		{Type: MULTI_POLYGON, Polygons: [][]core.PointSlice{{square}, {square}}},
+		{Type: GEOMETRY_COLLECTION, Geometries: []Geometry{NewPoint(core.Point{X: 1.0})}},
+	}
+
+	for _, geometry := range geometries {
+		encoded, err := json.Marshal(geometry)
+		assert.Nil(t, err)
+
+		var decoded Geometry
+		assert.Nil(t, json.Unmarshal(encoded, &decoded), string(encoded))
+		assert.Equal(t, geometry.WKT(), decoded.WKT())
+	}
+
+	var decoded Geometry
+	assert.NotNil(t, json.Unmarshal([]byte(`{"type":"Circle"}`), &decoded))
+	assert.NotNil(t, json.Unmarshal([]byte(`{"type":"Point","coordinates":"a"}`), &decoded))
+}
+
+func TestReadGeoJSON(t *testing.T) {
+	collection, err := ReadGeoJSON(strings.NewReader(`{"type":"FeatureCollection","features":[
+		{"type":"Feature","geometry":{"type":"Point","coordinates":[1,2]},"properties":{"name":"A"}},
+		{"type":"Feature","geometry":null,"properties":null}]}`))
+	assert.Nil(t, err)
+	assert.Len(t, collection.Features, 2)
+	assert.Equal(t, "POINT (1 2)", collection.Features[0].Geometry.WKT())
+	assert.Equal(t, "A", collection.Features[0].Properties["name"])
+	assert.True(t, collection.Features[1].Geometry.IsEmpty())
+
+	collection, err = ReadGeoJSON(strings.NewReader(
+		`{"type":"Feature","geometry":{"type":"LineString","coordinates":[[0,0],[1,1]]}}`))
+	assert.Nil(t, err)
+	assert.Len(t, collection.Features, 1)
+	assert.Equal(t, "LINESTRING (0 0, 1 1)", collection.Features[0].Geometry.WKT())
+
+	collection, err = ReadGeoJSON(strings.NewReader(`{"type":"Point","coordinates":[3,4]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "POINT (3 4)", collection.Features[0].Geometry.WKT())
+
+	_, err = ReadGeoJSON(strings.NewReader(`{"type":"FeatureCollection","features":[{"type":"Point"}]}`))
+	assert.NotNil(t, err)
+	_, err = ReadGeoJSON(strings.NewReader(`not json`))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,53 @@
+package nurbs
+
+import (
+	"math"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// closestPointSamples number of samples per span used to find the initial
+// guess for the closest point search.
+const closestPointSamples = 8
+
+// ClosestPoint returns the parameter and the point of the curve closest to p.
+func (c *Curve) ClosestPoint(p core.Point) (float64, core.Point) {
+	spans := c.Spans()
+
+	best := spans[0]
+	bestDistance := math.Inf(1)
+	for i := 1; i < len(spans); i++ {
+		samples := closestPointSamples * c.Degree
+		step := (spans[i] - spans[i-1]) / float64(samples)
+		for j := 0; j <= samples; j++ {
+			t := spans[i-1] + float64(j)*step
+			if distance := c.PointAt(t).Distance(p); distance < bestDistance {
+				best, bestDistance = t, distance
+			}
+		}
+	}
+
+	// newton iterations on the derivative of the squared distance.
+	start, end := c.Domain()
+	t := best
+	for i := 0; i < 32; i++ {
+		derivatives := c.Derivatives(t, 2)
+		difference := derivatives[0].Sub(p)
+		f := derivatives[1].Dot(difference)
+		df := derivatives[2].Dot(difference) + derivatives[1].Dot(derivatives[1])
+		if df == 0.0 {
+			break
+		}
+		next := math.Max(start, math.Min(end, t-f/df))
+		if math.Abs(next-t) < 1e-14*math.Max(1.0, end-start) {
+			t = next
+			break
+		}
+		t = next
+	}
+
+	if c.PointAt(t).Distance(p) > bestDistance {
+		t = best
+	}
+	return t, c.PointAt(t)
+}
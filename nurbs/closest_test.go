@@ -0,0 +1,39 @@
+package nurbs
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCurveClosestPoint(t *testing.T) {
+	circle := quarterCircle()
+
+	testCases := []struct {
+		point    core.Point
+		expected core.Point
+	}{
+		{core.Point{X: 2.0, Y: 2.0}, core.Point{X: math.Sqrt2 / 2.0, Y: math.Sqrt2 / 2.0}},
+		{core.Point{X: 0.1, Y: 0.2}, core.Point{X: 1.0 / math.Sqrt(5.0), Y: 2.0 / math.Sqrt(5.0)}},
+		{core.Point{X: 3.0, Y: -1.0}, core.Point{X: 1.0, Y: 0.0}},
+		{core.Point{X: -1.0, Y: 5.0, Z: 1.0}, core.Point{X: 0.0, Y: 1.0}},
+	}
+
+	for _, test := range testCases {
+		u, point := circle.ClosestPoint(test.point)
+		assert.True(t, test.expected.Equals(point), "Test case: %+v, got %+v", test, point)
+		assert.True(t, circle.PointAt(u).Equals(point))
+	}
+}
+
+func TestCubicCurveClosestPoint(t *testing.T) {
+	curve := cubicCurve()
+	for _, u := range []float64{0.3, 1.2, 2.7} {
+		// a point away from the curve along its normal.
+		derivatives := curve.Derivatives(u, 1)
+		normal := derivatives[1].Cross(core.Point{Z: 1.0}).Normalize().Scale(0.01)
+		found, _ := curve.ClosestPoint(derivatives[0].Add(normal))
+		assert.InDelta(t, u, found, 1e-3)
+	}
+}
@@ -0,0 +1,182 @@
+// Package nurbs provides Non Uniform Rational B-Spline curves, used to
+// evaluate the SPLINE entities of a DXF file.
+package nurbs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// Curve is a NURBS curve defined by its degree, knot vector, control points
+// and weights. A Curve with all weights equal to 1.0 is a (non rational)
+// B-Spline.
+type Curve struct {
+	Degree        int
+	Knots         []float64
+	ControlPoints core.PointSlice
+	Weights       []float64
+}
+
+// NewCurve creates a new Curve validating the consistency of its data.
+// If weights is empty, all control points get the weight 1.0.
+func NewCurve(degree int, knots []float64, controlPoints core.PointSlice,
+	weights []float64) (*Curve, error) {
+
+	count := len(controlPoints)
+	if degree < 1 {
+		return nil, fmt.Errorf("Invalid curve degree %v", degree)
+	}
+	if count <= degree {
+		return nil, fmt.Errorf(
+			"A curve of degree %v needs at least %v control points, got %v",
+			degree, degree+1, count)
+	}
+	if len(knots) != count+degree+1 {
+		return nil, fmt.Errorf("Expected %v knots, got %v", count+degree+1, len(knots))
+	}
+	for i := 1; i < len(knots); i++ {
+		if knots[i] < knots[i-1] {
+			return nil, errors.New("The knot vector should be non decreasing")
+		}
+	}
+	if knots[degree] >= knots[count] {
+		return nil, errors.New("The curve domain is empty")
+	}
+
+	if len(weights) == 0 {
+		weights = make([]float64, count)
+		for i := range weights {
+			weights[i] = 1.0
+		}
+	} else if len(weights) != count {
+		return nil, fmt.Errorf("Expected %v weights, got %v", count, len(weights))
+	}
+	for _, weight := range weights {
+		if weight <= 0.0 {
+			return nil, errors.New("Weights should be positive")
+		}
+	}
+
+	curve := new(Curve)
+	curve.Degree = degree
+	curve.Knots = knots
+	curve.ControlPoints = controlPoints
+	curve.Weights = weights
+	return curve, nil
+}
+
+// Domain returns the first and last valid parameters of the curve.
+func (c *Curve) Domain() (float64, float64) {
+	return c.Knots[c.Degree], c.Knots[len(c.ControlPoints)]
+}
+
+// Spans returns the parameters that delimit the non empty knot spans of the
+// curve domain, in increasing order, including the domain limits.
+func (c *Curve) Spans() []float64 {
+	start, end := c.Domain()
+	spans := []float64{start}
+	for _, knot := range c.Knots[c.Degree+1 : len(c.ControlPoints)+1] {
+		if knot > spans[len(spans)-1] && knot <= end {
+			spans = append(spans, knot)
+		}
+	}
+	return spans
+}
+
+// PointAt evaluates the curve at the parameter t. Parameters outside the
+// domain are clamped to it.
+func (c *Curve) PointAt(t float64) core.Point {
+	t = c.clamp(t)
+	span := c.findSpan(t)
+	basis := c.basisFunctions(span, t)
+
+	point := core.Point{}
+	weight := 0.0
+	for i, value := range basis {
+		index := span - c.Degree + i
+		w := value * c.Weights[index]
+		point = point.Add(c.ControlPoints[index].Scale(w))
+		weight += w
+	}
+	return point.Scale(1.0 / weight)
+}
+
+func (c *Curve) clamp(t float64) float64 {
+	start, end := c.Domain()
+	if t < start {
+		return start
+	}
+	if t > end {
+		return end
+	}
+	return t
+}
+
+// findSpan returns the index of the knot span containing t.
+func (c *Curve) findSpan(t float64) int {
+	return findSpan(c.Knots, c.Degree, len(c.ControlPoints), t)
+}
+
+// findSpan returns the index of the knot span containing t for a curve with
+// count control points.
+func findSpan(knots []float64, degree int, count int, t float64) int {
+	last := count - 1
+	if t >= knots[last+1] {
+		// the end of the domain belongs to the last non empty span.
+		span := last
+		for span > degree && knots[span] >= knots[last+1] {
+			span--
+		}
+		return span
+	}
+	if t <= knots[degree] {
+		span := degree
+		for span < last && knots[span+1] <= t {
+			span++
+		}
+		return span
+	}
+
+	low, high := degree, last+1
+	mid := (low + high) / 2
+	for t < knots[mid] || t >= knots[mid+1] {
+		if t < knots[mid] {
+			high = mid
+		} else {
+			low = mid
+		}
+		mid = (low + high) / 2
+	}
+	return mid
+}
+
+// basisFunctions computes the Degree+1 non vanishing B-Spline basis functions
+// at t, for the given span.
+func (c *Curve) basisFunctions(span int, t float64) []float64 {
+	return basisFunctions(c.Knots, c.Degree, span, t)
+}
+
+func basisFunctions(knots []float64, degree int, span int, t float64) []float64 {
+	basis := make([]float64, degree+1)
+	left := make([]float64, degree+1)
+	right := make([]float64, degree+1)
+
+	basis[0] = 1.0
+	for j := 1; j <= degree; j++ {
+		left[j] = t - knots[span+1-j]
+		right[j] = knots[span+j] - t
+		saved := 0.0
+		for r := 0; r < j; r++ {
+			temp := 0.0
+			if denominator := right[r+1] + left[j-r]; denominator != 0.0 {
+				temp = basis[r] / denominator
+			}
+			basis[r] = saved + right[r+1]*temp
+			saved = left[j-r] * temp
+		}
+		basis[j] = saved
+	}
+	return basis
+}
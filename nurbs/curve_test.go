@@ -0,0 +1,88 @@
+package nurbs
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func quarterCircle() *Curve {
+	curve, _ := NewCurve(2, []float64{0.0, 0.0, 0.0, 1.0, 1.0, 1.0},
+		core.PointSlice{{X: 1.0, Y: 0.0}, {X: 1.0, Y: 1.0}, {X: 0.0, Y: 1.0}},
+		[]float64{1.0, math.Sqrt2 / 2.0, 1.0})
+	return curve
+}
+
+func TestNewCurveValidation(t *testing.T) {
+	points := core.PointSlice{{X: 0.0}, {X: 1.0}, {X: 2.0}}
+	testCases := []struct {
+		degree  int
+		knots   []float64
+		weights []float64
+		valid   bool
+	}{
+		{2, []float64{0, 0, 0, 1, 1, 1}, nil, true},
+		{2, []float64{0, 0, 0, 1, 1, 1}, []float64{1, 2, 1}, true},
+		{0, []float64{0, 0, 0, 1, 1, 1}, nil, false},
+		{3, []float64{0, 0, 0, 0, 1, 1, 1}, nil, false},
+		{2, []float64{0, 0, 1, 1, 1}, nil, false},
+		{2, []float64{0, 0, 1, 0, 1, 1}, nil, false},
+		{2, []float64{1, 1, 1, 1, 1, 1}, nil, false},
+		{2, []float64{0, 0, 0, 1, 1, 1}, []float64{1, 1}, false},
+		{2, []float64{0, 0, 0, 1, 1, 1}, []float64{1, 0, 1}, false},
+	}
+
+	for _, test := range testCases {
+		curve, err := NewCurve(test.degree, test.knots, points, test.weights)
+		assert.Equal(t, test.valid, err == nil, "Test case: %+v, error: %v", test, err)
+		assert.Equal(t, test.valid, curve != nil, "Test case: %+v", test)
+	}
+}
+
+func TestCurvePointAt(t *testing.T) {
+	bezier, err := NewCurve(2, []float64{0.0, 0.0, 0.0, 1.0, 1.0, 1.0},
+		core.PointSlice{{X: 0.0, Y: 0.0}, {X: 1.0, Y: 2.0}, {X: 2.0, Y: 0.0}}, nil)
+	assert.Nil(t, err)
+
+	assert.True(t, core.Point{X: 0.0, Y: 0.0}.Equals(bezier.PointAt(0.0)))
+	assert.True(t, core.Point{X: 1.0, Y: 1.0}.Equals(bezier.PointAt(0.5)))
+	assert.True(t, core.Point{X: 2.0, Y: 0.0}.Equals(bezier.PointAt(1.0)))
+	// clamped to the domain
+	assert.True(t, core.Point{X: 2.0, Y: 0.0}.Equals(bezier.PointAt(3.0)))
+
+	circle := quarterCircle()
+	for _, u := range []float64{0.0, 0.1, 0.25, 0.5, 0.9, 1.0} {
+		assert.InDelta(t, 1.0, circle.PointAt(u).Length(), 1e-12, "u: %v", u)
+	}
+}
+
+func TestCurveDomainAndSpans(t *testing.T) {
+	curve, err := NewCurve(2, []float64{0.0, 0.0, 1.0, 2.0, 2.0, 3.0, 4.0, 4.0},
+		core.PointSlice{{X: 0.0}, {X: 1.0}, {X: 2.0}, {X: 3.0}, {X: 4.0}}, nil)
+	assert.Nil(t, err)
+
+	start, end := curve.Domain()
+	assert.Equal(t, 1.0, start)
+	assert.Equal(t, 3.0, end)
+	assert.Equal(t, []float64{1.0, 2.0, 3.0}, curve.Spans())
+}
+
+func TestCurveFlatten(t *testing.T) {
+	circle := quarterCircle()
+
+	for _, tolerance := range []float64{0.1, 0.01, 0.0001} {
+		points := circle.Flatten(tolerance)
+
+		assert.True(t, core.Point{X: 1.0, Y: 0.0}.Equals(points[0]))
+		assert.True(t, core.Point{X: 0.0, Y: 1.0}.Equals(points[len(points)-1]))
+		for i := 1; i < len(points); i++ {
+			assert.InDelta(t, 1.0, points[i].Length(), 1e-12)
+			middle := points[i].Add(points[i-1]).Scale(0.5)
+			assert.True(t, 1.0-middle.Length() <= tolerance,
+				"tolerance %v, deviation %v", tolerance, 1.0-middle.Length())
+		}
+	}
+
+	assert.True(t, len(circle.Flatten(0.0001)) > len(circle.Flatten(0.1)))
+}
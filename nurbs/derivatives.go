@@ -0,0 +1,147 @@
+package nurbs
+
+import "github.com/rpaloschi/dxf-go/core"
+
+// Derivatives returns the curve point and its derivatives at t, up to order.
+// The result has order+1 elements: the point, the first derivative, the
+// second derivative and so on. Derivatives higher than the curve degree are
+// zero. Parameters outside the domain are clamped to it.
+func (c *Curve) Derivatives(t float64, order int) core.PointSlice {
+	t = c.clamp(t)
+	span := c.findSpan(t)
+	basis := basisFunctionDerivatives(c.Knots, c.Degree, span, t, order)
+
+	// derivatives of the homogeneous curve: the weighted points and the weight.
+	points := make(core.PointSlice, order+1)
+	weights := make([]float64, order+1)
+	for k := 0; k <= order && k <= c.Degree; k++ {
+		for j := 0; j <= c.Degree; j++ {
+			index := span - c.Degree + j
+			value := basis[k][j] * c.Weights[index]
+			points[k] = points[k].Add(c.ControlPoints[index].Scale(value))
+			weights[k] += value
+		}
+	}
+
+	// rational curve derivatives from the homogeneous ones.
+	derivatives := make(core.PointSlice, order+1)
+	for k := 0; k <= order; k++ {
+		value := points[k]
+		for i := 1; i <= k; i++ {
+			value = value.Sub(derivatives[k-i].Scale(binomial(k, i) * weights[i]))
+		}
+		derivatives[k] = value.Scale(1.0 / weights[0])
+	}
+	return derivatives
+}
+
+// FirstDerivative returns the first derivative of the curve at t.
+func (c *Curve) FirstDerivative(t float64) core.Point {
+	return c.Derivatives(t, 1)[1]
+}
+
+// SecondDerivative returns the second derivative of the curve at t.
+func (c *Curve) SecondDerivative(t float64) core.Point {
+	return c.Derivatives(t, 2)[2]
+}
+
+// Tangent returns the unit tangent vector of the curve at t.
+func (c *Curve) Tangent(t float64) core.Point {
+	return c.FirstDerivative(t).Normalize()
+}
+
+// basisFunctionDerivatives computes the non vanishing basis functions and
+// their derivatives up to order at t. result[k][j] is the k-th derivative of
+// the basis function span-degree+j.
+func basisFunctionDerivatives(knots []float64, degree int, span int, t float64,
+	order int) [][]float64 {
+
+	ndu := make([][]float64, degree+1)
+	for i := range ndu {
+		ndu[i] = make([]float64, degree+1)
+	}
+	left := make([]float64, degree+1)
+	right := make([]float64, degree+1)
+
+	ndu[0][0] = 1.0
+	for j := 1; j <= degree; j++ {
+		left[j] = t - knots[span+1-j]
+		right[j] = knots[span+j] - t
+		saved := 0.0
+		for r := 0; r < j; r++ {
+			// lower triangle, knot differences
+			ndu[j][r] = right[r+1] + left[j-r]
+			temp := 0.0
+			if ndu[j][r] != 0.0 {
+				temp = ndu[r][j-1] / ndu[j][r]
+			}
+			// upper triangle, basis functions
+			ndu[r][j] = saved + right[r+1]*temp
+			saved = left[j-r] * temp
+		}
+		ndu[j][j] = saved
+	}
+
+	result := make([][]float64, order+1)
+	for k := range result {
+		result[k] = make([]float64, degree+1)
+	}
+	for j := 0; j <= degree; j++ {
+		result[0][j] = ndu[j][degree]
+	}
+
+	a := [2][]float64{make([]float64, degree+1), make([]float64, degree+1)}
+	for r := 0; r <= degree; r++ {
+		s1, s2 := 0, 1
+		a[0][0] = 1.0
+		for k := 1; k <= order && k <= degree; k++ {
+			d := 0.0
+			rk, pk := r-k, degree-k
+			if r >= k {
+				a[s2][0] = safeDivide(a[s1][0], ndu[pk+1][rk])
+				d = a[s2][0] * ndu[rk][pk]
+			}
+			j1, j2 := 1, k-1
+			if rk < -1 {
+				j1 = -rk
+			}
+			if r-1 > pk {
+				j2 = degree - r
+			}
+			for j := j1; j <= j2; j++ {
+				a[s2][j] = safeDivide(a[s1][j]-a[s1][j-1], ndu[pk+1][rk+j])
+				d += a[s2][j] * ndu[rk+j][pk]
+			}
+			if r <= pk {
+				a[s2][k] = safeDivide(-a[s1][k-1], ndu[pk+1][r])
+				d += a[s2][k] * ndu[r][pk]
+			}
+			result[k][r] = d
+			s1, s2 = s2, s1
+		}
+	}
+
+	factor := float64(degree)
+	for k := 1; k <= order && k <= degree; k++ {
+		for j := 0; j <= degree; j++ {
+			result[k][j] *= factor
+		}
+		factor *= float64(degree - k)
+	}
+	return result
+}
+
+func safeDivide(a float64, b float64) float64 {
+	if b == 0.0 {
+		return 0.0
+	}
+	return a / b
+}
+
+func binomial(n int, k int) float64 {
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package nurbs
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func cubicCurve() *Curve {
+	curve, _ := NewCurve(3, []float64{0, 0, 0, 0, 1, 2.5, 3, 3, 3, 3},
+		core.PointSlice{
+			{X: 0.0, Y: 0.0, Z: 0.0}, {X: 1.0, Y: 2.0, Z: 1.0}, {X: 3.0, Y: 3.0, Z: 0.0},
+			{X: 4.0, Y: 0.0, Z: -1.0}, {X: 6.0, Y: 1.0, Z: 0.0}, {X: 7.0, Y: 3.0, Z: 2.0},
+		},
+		[]float64{1.0, 2.0, 0.5, 1.0, 3.0, 1.0})
+	return curve
+}
+
+func assertPointInDelta(t *testing.T, expected core.Point, actual core.Point, delta float64,
+	msgAndArgs ...interface{}) {
+
+	assert.True(t, expected.Distance(actual) <= delta,
+		append([]interface{}{"expected %+v got %+v", expected, actual}, msgAndArgs...)...)
+}
+
+func TestCurveDerivatives(t *testing.T) {
+	h := 1e-5
+	for _, curve := range []*Curve{cubicCurve(), quarterCircle()} {
+		start, end := curve.Domain()
+		for _, fraction := range []float64{0.1, 0.3, 0.5, 0.8, 0.95} {
+			u := start + (end-start)*fraction
+			derivatives := curve.Derivatives(u, 3)
+			assert.Len(t, derivatives, 4)
+			assert.True(t, curve.PointAt(u).Equals(derivatives[0]))
+
+			// central differences
+			first := curve.PointAt(u + h).Sub(curve.PointAt(u - h)).Scale(1.0 / (2 * h))
+			assertPointInDelta(t, first, derivatives[1], 1e-6, "u: %v", u)
+			assertPointInDelta(t, first, curve.FirstDerivative(u), 1e-6)
+
+			second := curve.FirstDerivative(u + h).Sub(curve.FirstDerivative(u - h)).
+				Scale(1.0 / (2 * h))
+			assertPointInDelta(t, second, derivatives[2], 1e-4, "u: %v", u)
+			assertPointInDelta(t, second, curve.SecondDerivative(u), 1e-4)
+
+			assert.InDelta(t, 1.0, curve.Tangent(u).Length(), 1e-12)
+		}
+	}
+}
+
+func TestCurveDerivativesAboveDegree(t *testing.T) {
+	line, _ := NewCurve(1, []float64{0, 0, 1, 1},
+		core.PointSlice{{X: 0.0}, {X: 2.0, Y: 2.0}}, nil)
+
+	derivatives := line.Derivatives(0.5, 2)
+	assert.True(t, core.Point{X: 2.0, Y: 2.0}.Equals(derivatives[1]))
+	assert.True(t, core.Point{}.Equals(derivatives[2]))
+}
+
+func TestCircleDerivativeIsPerpendicularToRadius(t *testing.T) {
+	circle := quarterCircle()
+	for _, u := range []float64{0.0, 0.25, 0.5, 1.0} {
+		derivatives := circle.Derivatives(u, 1)
+		assert.InDelta(t, 0.0, derivatives[0].Dot(derivatives[1]), 1e-12)
+	}
+}
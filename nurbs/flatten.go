@@ -0,0 +1,61 @@
+package nurbs
+
+import "github.com/rpaloschi/dxf-go/core"
+
+// maxFlattenDepth limits the recursive subdivision of a knot span.
+const maxFlattenDepth = 24
+
+// Flatten approximates the curve by a polyline whose chords deviate less
+// than tolerance from the curve. Each knot span is recursively subdivided
+// until its chords are within the tolerance.
+func (c *Curve) Flatten(tolerance float64) core.PointSlice {
+	spans := c.Spans()
+	points := core.PointSlice{c.PointAt(spans[0])}
+
+	// a span of a curve with high degree can turn back on itself, so it is
+	// always split at least once per degree.
+	for i := 1; i < len(spans); i++ {
+		step := (spans[i] - spans[i-1]) / float64(c.Degree)
+		for j := 0; j < c.Degree; j++ {
+			start := spans[i-1] + float64(j)*step
+			end := start + step
+			if j == c.Degree-1 {
+				end = spans[i]
+			}
+			points = c.subdivide(start, end, points[len(points)-1], c.PointAt(end),
+				tolerance, 0, points)
+		}
+	}
+	return points
+}
+
+func (c *Curve) subdivide(start, end float64, startPoint, endPoint core.Point,
+	tolerance float64, depth int, points core.PointSlice) core.PointSlice {
+
+	middle := (start + end) / 2.0
+	middlePoint := c.PointAt(middle)
+
+	if depth >= maxFlattenDepth || c.isFlat(start, end, startPoint, endPoint, middlePoint, tolerance) {
+		return append(points, endPoint)
+	}
+
+	points = c.subdivide(start, middle, startPoint, middlePoint, tolerance, depth+1, points)
+	return c.subdivide(middle, end, middlePoint, endPoint, tolerance, depth+1, points)
+}
+
+// isFlat checks the deviation of the curve to the chord in the middle and in
+// the quarters of the interval.
+func (c *Curve) isFlat(start, end float64, startPoint, endPoint, middlePoint core.Point,
+	tolerance float64) bool {
+
+	if middlePoint.DistanceToSegment(startPoint, endPoint) > tolerance {
+		return false
+	}
+	quarter := (end - start) / 4.0
+	for _, t := range []float64{start + quarter, end - quarter} {
+		if c.PointAt(t).DistanceToSegment(startPoint, endPoint) > tolerance {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,261 @@
+package nurbs
+
+import (
+	"errors"
+	"math"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// Interpolate computes a Curve of the given degree that passes through all
+// the points (global interpolation with chord length parameters and averaged
+// knots). The degree is reduced if there are not enough points for it.
+func Interpolate(points core.PointSlice, degree int) (*Curve, error) {
+	points = withoutRepeatedPoints(points)
+	if len(points) < 2 {
+		return nil, errors.New("At least two distinct points are needed to interpolate a curve")
+	}
+	if degree < 1 {
+		return nil, errors.New("The interpolation degree should be at least 1")
+	}
+	if degree > len(points)-1 {
+		degree = len(points) - 1
+	}
+
+	parameters := chordLengthParameters(points)
+	knots := averagedKnots(parameters, degree)
+
+	rows := make([]bandRow, len(points))
+	for k, t := range parameters {
+		span := findSpan(knots, degree, len(points), t)
+		rows[k] = bandRow{
+			start:  span - degree,
+			values: basisFunctions(knots, degree, span, t),
+		}
+	}
+
+	controlPoints, err := solveBanded(rows, points)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCurve(degree, knots, controlPoints, nil)
+}
+
+func withoutRepeatedPoints(points core.PointSlice) core.PointSlice {
+	result := make(core.PointSlice, 0, len(points))
+	for _, point := range points {
+		if len(result) == 0 || !point.Equals(result[len(result)-1]) {
+			result = append(result, point)
+		}
+	}
+	return result
+}
+
+// chordLengthParameters assigns a parameter in [0, 1] to each point,
+// proportional to the distance along the polygon through the points.
+func chordLengthParameters(points core.PointSlice) []float64 {
+	parameters := make([]float64, len(points))
+	total := 0.0
+	for i := 1; i < len(points); i++ {
+		total += points[i].Distance(points[i-1])
+	}
+
+	for i := 1; i < len(points); i++ {
+		parameters[i] = parameters[i-1] + points[i].Distance(points[i-1])/total
+	}
+	parameters[len(points)-1] = 1.0
+	return parameters
+}
+
+// averagedKnots computes a clamped knot vector for the parameters where
+// each internal knot is the average of degree consecutive parameters.
+func averagedKnots(parameters []float64, degree int) []float64 {
+	count := len(parameters)
+	knots := make([]float64, count+degree+1)
+	for i := count; i < len(knots); i++ {
+		knots[i] = 1.0
+	}
+	for j := 1; j < count-degree; j++ {
+		sum := 0.0
+		for i := j; i < j+degree; i++ {
+			sum += parameters[i]
+		}
+		knots[j+degree] = sum / float64(degree)
+	}
+	return knots
+}
+
+// bandRow a row of a banded matrix, values[i] is the value of the column
+// start+i, all other columns are zero.
+type bandRow struct {
+	start  int
+	values []float64
+}
+
+func (row bandRow) get(column int) float64 {
+	index := column - row.start
+	if index < 0 || index >= len(row.values) {
+		return 0.0
+	}
+	return row.values[index]
+}
+
+// subtract sets row = row - factor*other, growing the row band as needed.
+func (row *bandRow) subtract(other bandRow, factor float64) {
+	start := row.start
+	if other.start < start {
+		start = other.start
+	}
+	end := row.start + len(row.values)
+	if otherEnd := other.start + len(other.values); otherEnd > end {
+		end = otherEnd
+	}
+
+	values := make([]float64, end-start)
+	for column := start; column < end; column++ {
+		values[column-start] = row.get(column) - factor*other.get(column)
+	}
+	row.start = start
+	row.values = values
+}
+
+// solveBanded solves the linear system rows * x = rhs using gaussian
+// elimination without pivoting, which is stable for the totally positive
+// B-Spline collocation matrices. Rows should be sorted by their start column.
+func solveBanded(rows []bandRow, rhs core.PointSlice) (core.PointSlice, error) {
+	count := len(rows)
+	rows = append([]bandRow{}, rows...)
+	rhs = append(core.PointSlice{}, rhs...)
+
+	for k := 0; k < count; k++ {
+		pivot := rows[k].get(k)
+		if math.Abs(pivot) < core.MinFloatDelta {
+			return nil, errors.New("Singular interpolation matrix")
+		}
+		for r := k + 1; r < count && rows[r].start <= k; r++ {
+			factor := rows[r].get(k) / pivot
+			if factor == 0.0 {
+				continue
+			}
+			rows[r].subtract(rows[k], factor)
+			rhs[r] = rhs[r].Sub(rhs[k].Scale(factor))
+		}
+	}
+
+	solution := make(core.PointSlice, count)
+	for k := count - 1; k >= 0; k-- {
+		value := rhs[k]
+		for column := k + 1; column < rows[k].start+len(rows[k].values); column++ {
+			value = value.Sub(solution[column].Scale(rows[k].get(column)))
+		}
+		solution[k] = value.Scale(1.0 / rows[k].get(k))
+	}
+	return solution, nil
+}
+
+// InterpolateWithTangents computes a Curve of the given degree that passes
+// through all the points and whose first derivatives at the ends have the
+// directions of startTangent and endTangent. The tangents are scaled to the
+// length of the polygon through the points. A zero tangent is estimated from
+// the first (or last) chord.
+func InterpolateWithTangents(points core.PointSlice, degree int,
+	startTangent core.Point, endTangent core.Point) (*Curve, error) {
+
+	points = withoutRepeatedPoints(points)
+	if len(points) < 2 {
+		return nil, errors.New("At least two distinct points are needed to interpolate a curve")
+	}
+	if degree < 1 {
+		return nil, errors.New("The interpolation degree should be at least 1")
+	}
+
+	last := len(points) - 1
+	count := len(points) + 2
+	if degree > count-1 {
+		degree = count - 1
+	}
+
+	chordLength := 0.0
+	for i := 1; i < len(points); i++ {
+		chordLength += points[i].Distance(points[i-1])
+	}
+	if startTangent.Length() == 0.0 {
+		startTangent = points[1].Sub(points[0])
+	}
+	if endTangent.Length() == 0.0 {
+		endTangent = points[last].Sub(points[last-1])
+	}
+	startTangent = startTangent.Normalize().Scale(chordLength)
+	endTangent = endTangent.Normalize().Scale(chordLength)
+
+	parameters := chordLengthParameters(points)
+
+	// clamped knots with the internal ones averaged from the parameters.
+	knots := make([]float64, count+degree+1)
+	for i := count; i < len(knots); i++ {
+		knots[i] = 1.0
+	}
+	for j := 0; j < count-degree-1; j++ {
+		sum := 0.0
+		for i := j; i < j+degree; i++ {
+			sum += parameters[minInt(i, last)]
+		}
+		knots[j+degree+1] = sum / float64(degree)
+	}
+
+	// the first two and last two control points are defined by the end
+	// points and tangents.
+	controlPoints := make(core.PointSlice, count)
+	controlPoints[0] = points[0]
+	controlPoints[1] = points[0].Add(startTangent.Scale(knots[degree+1] / float64(degree)))
+	controlPoints[count-2] = points[last].Sub(
+		endTangent.Scale((1.0 - knots[count-1]) / float64(degree)))
+	controlPoints[count-1] = points[last]
+
+	// the internal control points interpolate the internal points.
+	unknowns := count - 4
+	if unknowns > 0 {
+		rows := make([]bandRow, unknowns)
+		rhs := make(core.PointSlice, unknowns)
+		for k := 1; k < last; k++ {
+			t := parameters[k]
+			span := findSpan(knots, degree, count, t)
+			basis := basisFunctions(knots, degree, span, t)
+
+			row := bandRow{start: -1}
+			value := points[k]
+			for j, b := range basis {
+				column := span - degree + j
+				if column < 2 || column > count-3 {
+					value = value.Sub(controlPoints[column].Scale(b))
+					continue
+				}
+				if row.start < 0 {
+					row.start = column - 2
+				}
+				row.values = append(row.values, b)
+			}
+			if row.start < 0 {
+				row.start = 0
+			}
+			rows[k-1] = row
+			rhs[k-1] = value
+		}
+
+		internal, err := solveBanded(rows, rhs)
+		if err != nil {
+			return nil, err
+		}
+		copy(controlPoints[2:], internal)
+	}
+
+	return NewCurve(degree, knots, controlPoints, nil)
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
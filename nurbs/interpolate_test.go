@@ -0,0 +1,71 @@
+package nurbs
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	points := core.PointSlice{
+		{X: 0.0, Y: 0.0}, {X: 1.0, Y: 2.0}, {X: 3.0, Y: -1.0},
+		{X: 4.0, Y: 0.0}, {X: 4.0, Y: 0.0}, {X: 6.0, Y: 3.0, Z: 1.0},
+	}
+
+	for _, degree := range []int{1, 2, 3, 5} {
+		curve, err := Interpolate(points, degree)
+		assert.Nil(t, err)
+
+		expectedDegree := degree
+		if degree > 4 {
+			// only 5 distinct points
+			expectedDegree = 4
+		}
+		assert.Equal(t, expectedDegree, curve.Degree)
+
+		parameters := chordLengthParameters(withoutRepeatedPoints(points))
+		for i, point := range withoutRepeatedPoints(points) {
+			assert.True(t, point.Equals(curve.PointAt(parameters[i])),
+				"degree %v, expected %+v got %+v", degree, point, curve.PointAt(parameters[i]))
+		}
+	}
+}
+
+func TestInterpolateErrors(t *testing.T) {
+	_, err := Interpolate(core.PointSlice{{X: 1.0}, {X: 1.0}}, 3)
+	assert.NotNil(t, err)
+
+	_, err = Interpolate(core.PointSlice{{X: 1.0}, {X: 2.0}}, 0)
+	assert.NotNil(t, err)
+}
+
+func TestInterpolateWithTangents(t *testing.T) {
+	points := core.PointSlice{
+		{X: 0.0, Y: 0.0}, {X: 1.0, Y: 2.0}, {X: 3.0, Y: -1.0}, {X: 4.0, Y: 0.0}, {X: 6.0, Y: 3.0},
+	}
+	startTangent := core.Point{X: 0.0, Y: 1.0}
+	endTangent := core.Point{X: 1.0, Y: 1.0}
+
+	for _, count := range []int{2, 3, 5} {
+		curve, err := InterpolateWithTangents(points[:count], 3, startTangent, endTangent)
+		assert.Nil(t, err)
+
+		parameters := chordLengthParameters(points[:count])
+		for i, point := range points[:count] {
+			assert.True(t, point.Equals(curve.PointAt(parameters[i])),
+				"count %v, expected %+v got %+v", count, point, curve.PointAt(parameters[i]))
+		}
+
+		start, end := curve.Domain()
+		assert.True(t, startTangent.Normalize().Equals(curve.Tangent(start)))
+		assert.True(t, endTangent.Normalize().Equals(curve.Tangent(end)))
+	}
+
+	// zero tangents are estimated from the chords.
+	curve, err := InterpolateWithTangents(points, 3, core.Point{}, core.Point{})
+	assert.Nil(t, err)
+	assert.True(t, points[1].Sub(points[0]).Normalize().Equals(curve.Tangent(0.0)))
+
+	_, err = InterpolateWithTangents(points[:1], 3, startTangent, endTangent)
+	assert.NotNil(t, err)
+}
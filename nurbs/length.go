@@ -0,0 +1,94 @@
+package nurbs
+
+import "math"
+
+// gaussLegendre nodes and weights of the 8 point quadrature on [-1, 1].
+var gaussLegendre = [8][2]float64{
+	{-0.9602898564975363, 0.1012285362903763},
+	{-0.7966664774136267, 0.2223810344533745},
+	{-0.5255324099163290, 0.3137066458778873},
+	{-0.1834346424956498, 0.3626837833783620},
+	{0.1834346424956498, 0.3626837833783620},
+	{0.5255324099163290, 0.3137066458778873},
+	{0.7966664774136267, 0.2223810344533745},
+	{0.9602898564975363, 0.1012285362903763},
+}
+
+// Length returns the arc length of the whole curve.
+func (c *Curve) Length() float64 {
+	start, end := c.Domain()
+	return c.ArcLength(start, end)
+}
+
+// ArcLength returns the arc length of the curve between the parameters start
+// and end. It is negative if end < start.
+func (c *Curve) ArcLength(start float64, end float64) float64 {
+	if end < start {
+		return -c.ArcLength(end, start)
+	}
+	start, end = c.clamp(start), c.clamp(end)
+
+	// the integrand is only smooth inside the knot spans.
+	limits := []float64{start}
+	for _, knot := range c.Spans() {
+		if knot > start && knot < end {
+			limits = append(limits, knot)
+		}
+	}
+	limits = append(limits, end)
+
+	length := 0.0
+	for i := 1; i < len(limits); i++ {
+		step := (limits[i] - limits[i-1]) / float64(c.Degree)
+		for j := 0; j < c.Degree; j++ {
+			a := limits[i-1] + float64(j)*step
+			length += c.integrateSpeed(a, a+step)
+		}
+	}
+	return length
+}
+
+func (c *Curve) integrateSpeed(a float64, b float64) float64 {
+	half := (b - a) / 2.0
+	middle := (a + b) / 2.0
+	sum := 0.0
+	for _, node := range gaussLegendre {
+		sum += node[1] * c.FirstDerivative(middle+half*node[0]).Length()
+	}
+	return sum * half
+}
+
+// ParameterAtLength returns the parameter of the point at the given arc
+// length from the start of the curve. Lengths out of the curve are clamped.
+func (c *Curve) ParameterAtLength(length float64) float64 {
+	start, end := c.Domain()
+	total := c.ArcLength(start, end)
+	if length <= 0.0 {
+		return start
+	}
+	if length >= total {
+		return end
+	}
+
+	// newton iterations safeguarded by bisection.
+	low, high := start, end
+	t := start + (end-start)*length/total
+	for i := 0; i < 50; i++ {
+		difference := c.ArcLength(start, t) - length
+		if math.Abs(difference) < 1e-12*math.Max(1.0, total) {
+			break
+		}
+		if difference > 0 {
+			high = t
+		} else {
+			low = t
+		}
+		speed := c.FirstDerivative(t).Length()
+		next := t - difference/speed
+		if speed == 0.0 || next <= low || next >= high {
+			next = (low + high) / 2.0
+		}
+		t = next
+	}
+	return t
+}
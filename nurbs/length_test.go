@@ -0,0 +1,30 @@
+package nurbs
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+func TestCurveLength(t *testing.T) {
+	assert.InDelta(t, math.Pi/2.0, quarterCircle().Length(), 1e-9)
+
+	line, _ := NewCurve(2, []float64{0, 0, 0, 1, 2, 2, 2},
+		core.PointSlice{{X: 0.0}, {X: 1.0}, {X: 3.0}, {X: 4.0}}, nil)
+	assert.InDelta(t, 4.0, line.Length(), 1e-12)
+	assert.InDelta(t, line.PointAt(1.5).X-line.PointAt(0.5).X, line.ArcLength(0.5, 1.5), 1e-12)
+	assert.InDelta(t, -line.ArcLength(0.5, 1.5), line.ArcLength(1.5, 0.5), 1e-12)
+}
+
+func TestCurveParameterAtLength(t *testing.T) {
+	circle := quarterCircle()
+	for _, angle := range []float64{0.1, 0.5, 1.0, 1.5} {
+		u := circle.ParameterAtLength(angle)
+		point := circle.PointAt(u)
+		assert.InDelta(t, angle, math.Atan2(point.Y, point.X), 1e-9)
+	}
+
+	assert.Equal(t, 0.0, circle.ParameterAtLength(-1.0))
+	assert.Equal(t, 1.0, circle.ParameterAtLength(10.0))
+}
@@ -0,0 +1,59 @@
+package nurbs
+
+import (
+	"fmt"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// NewPeriodicCurve creates a closed periodic Curve from control points that
+// are not wrapped, the first degree control points are appended to close it.
+// knots can be empty, for a uniform knot vector, or have one knot more than
+// control points, defining the spans of one period. Weights follow the same
+// rules as in NewCurve.
+func NewPeriodicCurve(degree int, knots []float64, controlPoints core.PointSlice,
+	weights []float64) (*Curve, error) {
+
+	count := len(controlPoints)
+	if degree < 1 || count <= degree {
+		return nil, fmt.Errorf(
+			"A periodic curve of degree %v needs more than %v control points, got %v",
+			degree, degree, count)
+	}
+
+	if len(knots) == 0 {
+		knots = make([]float64, count+1)
+		for i := range knots {
+			knots[i] = float64(i)
+		}
+	} else if len(knots) != count+1 {
+		return nil, fmt.Errorf("Expected %v periodic knots, got %v", count+1, len(knots))
+	}
+
+	// the spans of one period are repeated before and after it.
+	periodic := make([]float64, count+2*degree+1)
+	for i, knot := range knots {
+		periodic[degree+i] = knot
+	}
+	for j := 1; j <= degree; j++ {
+		periodic[degree-j] = periodic[degree-j+1] - (knots[count-j+1] - knots[count-j])
+		periodic[degree+count+j] = periodic[degree+count+j-1] + (knots[j] - knots[j-1])
+	}
+
+	wrappedPoints := append(append(core.PointSlice{}, controlPoints...), controlPoints[:degree]...)
+	var wrappedWeights []float64
+	if len(weights) > 0 {
+		if len(weights) != count {
+			return nil, fmt.Errorf("Expected %v weights, got %v", count, len(weights))
+		}
+		wrappedWeights = append(append([]float64{}, weights...), weights[:degree]...)
+	}
+
+	return NewCurve(degree, periodic, wrappedPoints, wrappedWeights)
+}
+
+// IsClosed returns true if the curve starts and ends on the same point.
+func (c *Curve) IsClosed() bool {
+	start, end := c.Domain()
+	return c.PointAt(start).Equals(c.PointAt(end))
+}
@@ -0,0 +1,33 @@
+package nurbs
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewPeriodicCurve(t *testing.T) {
+	square := core.PointSlice{{X: 0.0, Y: 0.0}, {X: 2.0, Y: 0.0}, {X: 2.0, Y: 2.0}, {X: 0.0, Y: 2.0}}
+
+	for _, knots := range [][]float64{nil, {0.0, 1.0, 3.0, 4.0, 5.0}} {
+		curve, err := NewPeriodicCurve(3, knots, square, nil)
+		assert.Nil(t, err)
+		assert.True(t, curve.IsClosed())
+
+		// the curve is smooth on the seam.
+		start, end := curve.Domain()
+		assertPointInDelta(t, curve.FirstDerivative(start), curve.FirstDerivative(end), 1e-9)
+		assertPointInDelta(t, curve.SecondDerivative(start), curve.SecondDerivative(end), 1e-9)
+	}
+
+	_, err := NewPeriodicCurve(3, []float64{0.0, 1.0}, square, nil)
+	assert.NotNil(t, err)
+	_, err = NewPeriodicCurve(3, nil, square[:3], nil)
+	assert.NotNil(t, err)
+	_, err = NewPeriodicCurve(3, nil, square, []float64{1.0})
+	assert.NotNil(t, err)
+}
+
+func TestClampedCurveIsNotClosed(t *testing.T) {
+	assert.False(t, quarterCircle().IsClosed())
+}
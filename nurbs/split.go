@@ -0,0 +1,133 @@
+package nurbs
+
+import (
+	"fmt"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// InsertKnot returns a new Curve with the knot t inserted times times. The
+// shape of the curve does not change. The multiplicity of the knot is
+// limited to the curve degree.
+func (c *Curve) InsertKnot(t float64, times int) (*Curve, error) {
+	start, end := c.Domain()
+	if t < start || t > end {
+		return nil, fmt.Errorf("Parameter %v out of the curve domain [%v, %v]", t, start, end)
+	}
+	if c.multiplicity(t)+times > c.Degree {
+		return nil, fmt.Errorf("Knot %v would exceed the multiplicity %v", t, c.Degree)
+	}
+
+	knots := append([]float64{}, c.Knots...)
+	points, weights := c.homogeneousPoints()
+	for i := 0; i < times; i++ {
+		knots, points, weights = insertKnot(knots, c.Degree, points, weights, t)
+	}
+
+	return c.fromHomogeneous(knots, points, weights), nil
+}
+
+// Split splits the curve at the parameter t, returning the curve before and
+// after it.
+func (c *Curve) Split(t float64) (*Curve, *Curve, error) {
+	start, end := c.Domain()
+	if t <= start || t >= end {
+		return nil, nil, fmt.Errorf(
+			"Split parameter %v should be inside the curve domain (%v, %v)", t, start, end)
+	}
+
+	refined, err := c.InsertKnot(t, c.Degree-c.multiplicity(t))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// index of the first occurrence of t, the control point before it is
+	// the point of the curve at t.
+	first := 0
+	for refined.Knots[first] < t {
+		first++
+	}
+
+	leftKnots := append(append([]float64{}, refined.Knots[:first+c.Degree]...), t)
+	left := &Curve{
+		Degree:        c.Degree,
+		Knots:         leftKnots,
+		ControlPoints: append(core.PointSlice{}, refined.ControlPoints[:first]...),
+		Weights:       append([]float64{}, refined.Weights[:first]...),
+	}
+
+	rightKnots := append([]float64{t}, refined.Knots[first:]...)
+	right := &Curve{
+		Degree:        c.Degree,
+		Knots:         rightKnots,
+		ControlPoints: append(core.PointSlice{}, refined.ControlPoints[first-1:]...),
+		Weights:       append([]float64{}, refined.Weights[first-1:]...),
+	}
+
+	return left, right, nil
+}
+
+// multiplicity returns how many times t is in the knot vector.
+func (c *Curve) multiplicity(t float64) int {
+	count := 0
+	for _, knot := range c.Knots {
+		if knot == t {
+			count++
+		}
+	}
+	return count
+}
+
+// homogeneousPoints returns the control points multiplied by their weights.
+func (c *Curve) homogeneousPoints() (core.PointSlice, []float64) {
+	points := make(core.PointSlice, len(c.ControlPoints))
+	for i, point := range c.ControlPoints {
+		points[i] = point.Scale(c.Weights[i])
+	}
+	return points, append([]float64{}, c.Weights...)
+}
+
+func (c *Curve) fromHomogeneous(knots []float64, points core.PointSlice,
+	weights []float64) *Curve {
+
+	controlPoints := make(core.PointSlice, len(points))
+	for i, point := range points {
+		controlPoints[i] = point.Scale(1.0 / weights[i])
+	}
+	return &Curve{
+		Degree:        c.Degree,
+		Knots:         knots,
+		ControlPoints: controlPoints,
+		Weights:       weights,
+	}
+}
+
+// insertKnot inserts t once in the knot vector using Boehm's algorithm on
+// the homogeneous control points.
+func insertKnot(knots []float64, degree int, points core.PointSlice,
+	weights []float64, t float64) ([]float64, core.PointSlice, []float64) {
+
+	span := findSpan(knots, degree, len(points), t)
+
+	newPoints := make(core.PointSlice, len(points)+1)
+	newWeights := make([]float64, len(points)+1)
+	for i := 0; i <= len(points); i++ {
+		switch {
+		case i <= span-degree:
+			newPoints[i], newWeights[i] = points[i], weights[i]
+		case i > span:
+			newPoints[i], newWeights[i] = points[i-1], weights[i-1]
+		default:
+			alpha := (t - knots[i]) / (knots[i+degree] - knots[i])
+			newPoints[i] = points[i].Scale(alpha).Add(points[i-1].Scale(1.0 - alpha))
+			newWeights[i] = alpha*weights[i] + (1.0-alpha)*weights[i-1]
+		}
+	}
+
+	newKnots := make([]float64, 0, len(knots)+1)
+	newKnots = append(newKnots, knots[:span+1]...)
+	newKnots = append(newKnots, t)
+	newKnots = append(newKnots, knots[span+1:]...)
+
+	return newKnots, newPoints, newWeights
+}
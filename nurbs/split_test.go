@@ -0,0 +1,59 @@
+package nurbs
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCurveInsertKnot(t *testing.T) {
+	curve := cubicCurve()
+	refined, err := curve.InsertKnot(1.7, 2)
+	assert.Nil(t, err)
+	assert.Len(t, refined.ControlPoints, len(curve.ControlPoints)+2)
+	assert.Len(t, refined.Knots, len(curve.Knots)+2)
+
+	for _, u := range []float64{0.0, 0.5, 1.7, 2.2, 3.0} {
+		assert.True(t, curve.PointAt(u).Equals(refined.PointAt(u)), "u: %v", u)
+	}
+
+	_, err = curve.InsertKnot(1.0, 3)
+	assert.NotNil(t, err)
+	_, err = curve.InsertKnot(5.0, 1)
+	assert.NotNil(t, err)
+}
+
+func TestCurveSplit(t *testing.T) {
+	for _, curve := range []*Curve{cubicCurve(), quarterCircle()} {
+		start, end := curve.Domain()
+		for _, fraction := range []float64{0.2, 0.5, 0.9} {
+			middle := start + (end-start)*fraction
+			left, right, err := curve.Split(middle)
+			assert.Nil(t, err)
+
+			leftStart, leftEnd := left.Domain()
+			rightStart, rightEnd := right.Domain()
+			assert.Equal(t, start, leftStart)
+			assert.Equal(t, middle, leftEnd)
+			assert.Equal(t, middle, rightStart)
+			assert.Equal(t, end, rightEnd)
+
+			for i := 0; i <= 10; i++ {
+				u := start + (middle-start)*float64(i)/10.0
+				assert.True(t, curve.PointAt(u).Equals(left.PointAt(u)), "u: %v", u)
+				u = middle + (end-middle)*float64(i)/10.0
+				assert.True(t, curve.PointAt(u).Equals(right.PointAt(u)), "u: %v", u)
+			}
+			assert.InDelta(t, curve.Length(), left.Length()+right.Length(), 1e-6)
+		}
+	}
+
+	// splitting on an existing knot.
+	curve := cubicCurve()
+	left, right, err := curve.Split(1.0)
+	assert.Nil(t, err)
+	assert.True(t, curve.PointAt(1.0).Equals(left.ControlPoints[len(left.ControlPoints)-1]))
+	assert.True(t, curve.PointAt(1.0).Equals(right.ControlPoints[0]))
+
+	_, _, err = curve.Split(0.0)
+	assert.NotNil(t, err)
+}
@@ -1,6 +1,8 @@
 package sections
 
 import (
+	"sort"
+
 	"github.com/rpaloschi/dxf-go/core"
 	"github.com/rpaloschi/dxf-go/entities"
 )
@@ -16,6 +18,11 @@ type Block struct {
 	XrefPathName string
 	Description  string
 	Entities     entities.EntitySlice
+	// Owner is the handle of the BLOCK_RECORD of the Block, which owns its
+	// entities too.
+	Owner string
+	// EndHandle is the handle of the ENDBLK closing the Block.
+	EndHandle string
 }
 
 // Equals compare with the other block for equality.
@@ -23,6 +30,8 @@ func (b Block) Equals(other core.DxfElement) bool {
 	if otherBlock, ok := other.(*Block); ok {
 		return b.Name == otherBlock.Name &&
 			b.Handle == otherBlock.Handle &&
+			b.Owner == otherBlock.Owner &&
+			b.EndHandle == otherBlock.EndHandle &&
 			b.LayerName == otherBlock.LayerName &&
 			b.SecondName == otherBlock.SecondName &&
 			b.BasePoint.Equals(otherBlock.BasePoint) &&
@@ -38,21 +47,72 @@ func NewBlock(tags core.TagSlice) (*Block, error) {
 	block := new(Block)
 
 	block.Init(map[int]core.TypeParser{
-		1:  core.NewStringTypeParserToVar(&block.XrefPathName),
-		2:  core.NewStringTypeParserToVar(&block.Name),
-		3:  core.NewStringTypeParserToVar(&block.SecondName),
-		4:  core.NewStringTypeParserToVar(&block.Description),
-		5:  core.NewStringTypeParserToVar(&block.Handle),
-		8:  core.NewStringTypeParserToVar(&block.LayerName),
-		10: core.NewFloatTypeParserToVar(&block.BasePoint.X),
-		20: core.NewFloatTypeParserToVar(&block.BasePoint.Y),
-		30: core.NewFloatTypeParserToVar(&block.BasePoint.Z),
+		1:   core.NewStringTypeParserToVar(&block.XrefPathName),
+		2:   core.NewStringTypeParserToVar(&block.Name),
+		3:   core.NewStringTypeParserToVar(&block.SecondName),
+		4:   core.NewStringTypeParserToVar(&block.Description),
+		5:   core.NewStringTypeParserToVar(&block.Handle),
+		8:   core.NewStringTypeParserToVar(&block.LayerName),
+		10:  core.NewFloatTypeParserToVar(&block.BasePoint.X),
+		20:  core.NewFloatTypeParserToVar(&block.BasePoint.Y),
+		30:  core.NewFloatTypeParserToVar(&block.BasePoint.Z),
+		330: core.NewStringTypeParserToVar(&block.Owner),
 	})
 
 	err := block.Parse(tags)
 	return block, err
 }
 
+// Tags returns the tags of the Block: its BLOCK definition with the unparsed
+// tags, its entities and the closing ENDBLK.
+func (b Block) Tags() core.TagSlice {
+	return b.TagsOptions(core.WriteOptions{})
+}
+
+// TagsOptions returns the tags of the Block as Tags, logging the entities
+// skipped to the Logger of the options.
+func (b Block) TagsOptions(options core.WriteOptions) core.TagSlice {
+	tags := append(core.TagSlice{core.NewStringTag(0, "BLOCK")}, b.ownedTags(b.Handle)...)
+	tags = append(tags,
+		core.NewStringTag(100, "AcDbEntity"),
+		core.NewStringTag(8, b.LayerName),
+		core.NewStringTag(100, "AcDbBlockBegin"),
+		core.NewStringTag(2, b.Name),
+		core.NewIntegerTag(70, 0))
+	tags = append(tags, core.NewPointTags(10, b.BasePoint)...)
+	if b.SecondName != "" {
+		tags = append(tags, core.NewStringTag(3, b.SecondName))
+	}
+	if b.XrefPathName != "" {
+		tags = append(tags, core.NewStringTag(1, b.XrefPathName))
+	}
+	if b.Description != "" {
+		tags = append(tags, core.NewStringTag(4, b.Description))
+	}
+
+	tags = b.WithUnparsedTags(tags)
+	tags = append(tags, entityListTags(b.Entities, options)...)
+	tags = append(tags, core.NewStringTag(0, "ENDBLK"))
+	tags = append(tags, b.ownedTags(b.EndHandle)...)
+	return append(tags,
+		core.NewStringTag(100, "AcDbEntity"),
+		core.NewStringTag(8, b.LayerName),
+		core.NewStringTag(100, "AcDbBlockEnd"))
+}
+
+// ownedTags returns the handle tag of the BLOCK or ENDBLK with the handle
+// and the owner one, for the ones they have.
+func (b Block) ownedTags(handle string) core.TagSlice {
+	var tags core.TagSlice
+	if handle != "" {
+		tags = append(tags, core.NewStringTag(5, handle))
+	}
+	if b.Owner != "" {
+		tags = append(tags, core.NewHandleTag(330, b.Owner))
+	}
+	return tags
+}
+
 // BlocksSection BLOCKS section representation.
 type BlocksSection map[string]*Block
 
@@ -75,32 +135,120 @@ func (b BlocksSection) Equals(other BlocksSection) bool {
 
 // NewBlocksSection creates a new BlocksSection from a slice of tags.
 func NewBlocksSection(tags core.TagSlice) (BlocksSection, error) {
+	return Parser{}.BlocksSection(tags)
+}
+
+// BlocksSection parses the BlocksSection from a slice of tags, skipping the
+// blocks and entities it recovers from.
+func (p Parser) BlocksSection(tags core.TagSlice) (BlocksSection, error) {
 	blocks := make(BlocksSection)
 
-	if len(tags) > 3 {
-		groups := make([]core.TagSlice, 0)
-		tagGroups := core.TagGroups(tags[2:len(tags)-1], 0)
-		for _, group := range tagGroups {
-			if group[0].Value.ToString() == "ENDBLK" {
-				block, err := NewBlock(groups[0])
-				if err != nil {
-					return nil, err
-				}
-
-				allEntitites, err := NewEntityList(groups[1:])
-				if err != nil {
-					return nil, err
-				}
-
-				block.Entities = allEntitites
-				blocks[block.Name] = block
-				groups = make([]core.TagSlice, 0)
-			} else {
-				groups = append(groups, group)
+	if len(tags) <= 3 {
+		return blocks, nil
+	}
+
+	// the entities of all the blocks are built at once, by the Workers.
+	blockList := splitBlocks(core.TagGroups(tags[2:len(tags)-1], 0))
+	var entityGroups []core.TagSlice
+	for _, block := range blockList {
+		if block.isValid() {
+			entityGroups = append(entityGroups, block.groups[1:]...)
+		}
+	}
+	parsed, err := p.parseEntities(entityGroups)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blockTags := range blockList {
+		if blockTags.end == nil {
+			if err := p.Recover(blockTags.groups[0], core.ErrInvalidBlock); err != nil {
+				return nil, err
 			}
+			continue
+		}
+		if !blockTags.isValid() {
+			if err := p.Recover(blockTags.end, core.ErrInvalidBlock); err != nil {
+				return nil, err
+			}
+			continue
 		}
 
+		var blockParsed []parsedEntity
+		if parsed != nil {
+			blockParsed, parsed = parsed[:len(blockTags.groups)-1], parsed[len(blockTags.groups)-1:]
+		}
+
+		block, err := NewBlock(blockTags.groups[0])
+		if err != nil {
+			if err = p.Recover(blockTags.groups[0], err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		allEntitites, err := p.entityList(blockTags.groups[1:], blockParsed)
+		if err != nil {
+			return nil, err
+		}
+
+		block.Entities = allEntitites
+		if index := blockTags.end.TagIndex(5, 0, len(blockTags.end)); index >= 0 {
+			block.EndHandle = blockTags.end[index].Value.ToString()
+		}
+		blocks[block.Name] = block
 	}
 
 	return blocks, nil
 }
+
+// blockGroups has the tag groups of a block: its BLOCK and entities groups,
+// and its ENDBLK one, nil when it is missing.
+type blockGroups struct {
+	groups []core.TagSlice
+	end    core.TagSlice
+}
+
+// isValid tells if the block starts with a BLOCK and ends with an ENDBLK.
+func (b blockGroups) isValid() bool {
+	return b.end != nil && len(b.groups) > 0 && b.groups[0][0].Value.ToString() == "BLOCK"
+}
+
+// splitBlocks splits the tag groups of the BLOCKS section at their ENDBLK.
+func splitBlocks(tagGroups []core.TagSlice) []blockGroups {
+	var blockList []blockGroups
+	groups := make([]core.TagSlice, 0)
+	for _, group := range tagGroups {
+		if group[0].Value.ToString() == "ENDBLK" {
+			blockList = append(blockList, blockGroups{groups: groups, end: group})
+			groups = make([]core.TagSlice, 0)
+		} else {
+			groups = append(groups, group)
+		}
+	}
+	if len(groups) > 0 {
+		blockList = append(blockList, blockGroups{groups: groups})
+	}
+	return blockList
+}
+
+// Tags returns the tags of the BLOCKS section, the blocks sorted by name.
+func (b BlocksSection) Tags() core.TagSlice {
+	return b.TagsOptions(core.WriteOptions{})
+}
+
+// TagsOptions returns the tags of the BLOCKS section as Tags, logging the
+// entities skipped to the Logger of the options.
+func (b BlocksSection) TagsOptions(options core.WriteOptions) core.TagSlice {
+	names := make([]string, 0, len(b))
+	for name := range b {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var tags core.TagSlice
+	for _, name := range names {
+		tags = append(tags, b[name].TagsOptions(options)...)
+	}
+	return sectionTags("BLOCKS", tags)
+}
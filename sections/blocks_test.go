@@ -1,6 +1,7 @@
 package sections
 
 import (
+	"errors"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/rpaloschi/dxf-go/core"
 	"github.com/rpaloschi/dxf-go/entities"
@@ -206,6 +207,28 @@ func TestNewBlocksErrorParsingBlockEntities(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestNewBlocksSectionInvalidBlocks(t *testing.T) {
+	for _, blocks := range []string{
+		"  0\nENDBLK\n",
+		"  0\nBLOCK\n  2\nBLOCK_NAME\n",
+		"  0\nLINE\n  0\nENDBLK\n",
+	} {
+		next := core.Tagger(strings.NewReader("  0\nSECTION\n  2\nBLOCKS\n" + blocks + "  0\nENDSEC\n"))
+		tags := core.TagSlice(core.AllTags(next))
+
+		section, err := NewBlocksSection(tags)
+		assert.Nil(t, section, blocks)
+		assert.True(t, errors.Is(err, core.ErrInvalidBlock), blocks)
+
+		// the invalid block is skipped in lenient mode.
+		parser := Parser{Diagnostics: core.NewDiagnostics(core.ParseOptions{Mode: core.Lenient})}
+		section, err = parser.BlocksSection(tags)
+		assert.Nil(t, err, blocks)
+		assert.Len(t, section, 0, blocks)
+		assert.Len(t, parser.Diagnostics.List, 1, blocks)
+	}
+}
+
 func TestDifferentLengthBlocksSectionAreNotEquals(t *testing.T) {
 	s1 := BlocksSection{
 		"B": &Block{Name: "B"},
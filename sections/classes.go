@@ -0,0 +1,178 @@
+package sections
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// Class representation. It describes an application defined object or
+// entity type of the drawing.
+type Class struct {
+	core.DxfParseable
+	// RecordName is the DXF record name of the objects or entities, like
+	// "ACDBDICTIONARYWDFLT".
+	RecordName string
+	// ClassName is the C++ class name, like "AcDbDictionaryWithDefault".
+	ClassName string
+	// Application is the name of the application defining the class.
+	Application   string
+	ProxyFlags    int
+	InstanceCount int
+	// WasAProxy is true if the class was not loaded when the file was saved.
+	WasAProxy  bool
+	IsAnEntity bool
+}
+
+// Equals compares two Class objects for equality.
+func (c Class) Equals(other core.DxfElement) bool {
+	if otherClass, ok := other.(*Class); ok {
+		return c.RecordName == otherClass.RecordName &&
+			c.ClassName == otherClass.ClassName &&
+			c.Application == otherClass.Application &&
+			c.ProxyFlags == otherClass.ProxyFlags &&
+			c.InstanceCount == otherClass.InstanceCount &&
+			c.WasAProxy == otherClass.WasAProxy &&
+			c.IsAnEntity == otherClass.IsAnEntity
+	}
+	return false
+}
+
+// NewClass builds a new Class from a slice of tags.
+func NewClass(tags core.TagSlice) (*Class, error) {
+	class := new(Class)
+
+	class.Init(map[int]core.TypeParser{
+		1:  core.NewStringTypeParserToVar(&class.RecordName),
+		2:  core.NewStringTypeParserToVar(&class.ClassName),
+		3:  core.NewStringTypeParserToVar(&class.Application),
+		90: core.NewIntTypeParserToVar(&class.ProxyFlags),
+		91: core.NewIntTypeParserToVar(&class.InstanceCount),
+		280: core.NewIntTypeParser(func(value int) {
+			class.WasAProxy = value != 0
+		}),
+		281: core.NewIntTypeParser(func(value int) {
+			class.IsAnEntity = value != 0
+		}),
+	})
+
+	err := class.Parse(tags)
+	return class, err
+}
+
+// Tags returns the tags of the Class, with its unparsed tags.
+func (c Class) Tags() core.TagSlice {
+	wasAProxy, isAnEntity := 0, 0
+	if c.WasAProxy {
+		wasAProxy = 1
+	}
+	if c.IsAnEntity {
+		isAnEntity = 1
+	}
+
+	tags := core.TagSlice{
+		core.NewStringTag(0, "CLASS"),
+		core.NewStringTag(1, c.RecordName),
+		core.NewStringTag(2, c.ClassName),
+		core.NewStringTag(3, c.Application),
+		core.NewIntegerTag(90, c.ProxyFlags),
+		core.NewIntegerTag(91, c.InstanceCount),
+		core.NewIntegerTag(280, wasAProxy),
+		core.NewIntegerTag(281, isAnEntity),
+	}
+	return c.WithUnparsedTags(tags)
+}
+
+// ClassesSection CLASSES section representation, the classes in the file
+// order.
+type ClassesSection struct {
+	Classes []*Class
+}
+
+// Equals Compare two ClassesSection for equality.
+func (c ClassesSection) Equals(other core.DxfElement) bool {
+	if otherSection, ok := other.(*ClassesSection); ok {
+		if len(c.Classes) != len(otherSection.Classes) {
+			return false
+		}
+		for i, class := range c.Classes {
+			if !class.Equals(otherSection.Classes[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// NewClassesSection parses the ClassesSection from a slice of tags.
+func NewClassesSection(tags core.TagSlice) (*ClassesSection, error) {
+	return Parser{}.ClassesSection(tags)
+}
+
+// ClassesSection parses the ClassesSection from a slice of tags, skipping
+// the classes it recovers from.
+func (p Parser) ClassesSection(tags core.TagSlice) (*ClassesSection, error) {
+	section := new(ClassesSection)
+
+	if len(tags) <= 3 {
+		return section, nil
+	}
+
+	for _, group := range core.TagGroups(tags[2:len(tags)-1], 0) {
+		if group[0].Value.ToString() != "CLASS" {
+			err := errors.New("Invalid CLASSES section. Expected only CLASS records.")
+			if err = p.Recover(group, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		class, err := NewClass(group)
+		if err != nil {
+			if err = p.Recover(group, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		section.Classes = append(section.Classes, class)
+	}
+
+	return section, nil
+}
+
+// Class returns the Class with the DXF record name, like the type of an
+// entities.Unknown entity.
+func (c ClassesSection) Class(recordName string) (*Class, bool) {
+	for _, class := range c.Classes {
+		if class.RecordName == recordName {
+			return class, true
+		}
+	}
+	return nil, false
+}
+
+// Applications returns the sorted names of the applications defining the
+// classes, the object enablers the drawing depends on.
+func (c ClassesSection) Applications() []string {
+	found := make(map[string]bool)
+	applications := make([]string, 0)
+	for _, class := range c.Classes {
+		if !found[class.Application] {
+			found[class.Application] = true
+			applications = append(applications, class.Application)
+		}
+	}
+	sort.Strings(applications)
+	return applications
+}
+
+// Tags returns the tags of the CLASSES section.
+func (c ClassesSection) Tags() core.TagSlice {
+	var tags core.TagSlice
+	for _, class := range c.Classes {
+		tags = append(tags, class.Tags()...)
+	}
+	return sectionTags("CLASSES", tags)
+}
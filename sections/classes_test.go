@@ -0,0 +1,106 @@
+package sections
+
+import (
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+const dxfClassesSection = `  0
+SECTION
+  2
+CLASSES
+  0
+CLASS
+  1
+ACDBDICTIONARYWDFLT
+  2
+AcDbDictionaryWithDefault
+  3
+ObjectDBX Classes
+ 90
+0
+ 91
+1
+280
+0
+281
+0
+  0
+CLASS
+  1
+AEC_WALL
+  2
+AecDbWall
+  3
+AecArchBase
+ 90
+4095
+ 91
+12
+280
+1
+281
+1
+  0
+ENDSEC
+`
+
+func TestNewClassesSection(t *testing.T) {
+	section, err := NewClassesSection(parseTags(dxfClassesSection))
+	assert.Nil(t, err)
+
+	expected := ClassesSection{Classes: []*Class{
+		{
+			RecordName:    "ACDBDICTIONARYWDFLT",
+			ClassName:     "AcDbDictionaryWithDefault",
+			Application:   "ObjectDBX Classes",
+			InstanceCount: 1,
+		},
+		{
+			RecordName:    "AEC_WALL",
+			ClassName:     "AecDbWall",
+			Application:   "AecArchBase",
+			ProxyFlags:    4095,
+			InstanceCount: 12,
+			WasAProxy:     true,
+			IsAnEntity:    true,
+		},
+	}}
+	assert.True(t, expected.Equals(section))
+	assert.False(t, expected.Equals(&ClassesSection{}))
+	assert.False(t, expected.Equals(core.NewStringValue("CLASSES")))
+
+	class, ok := section.Class("AEC_WALL")
+	assert.True(t, ok)
+	assert.True(t, class.IsAnEntity)
+	_, ok = section.Class("MTEXT")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"AecArchBase", "ObjectDBX Classes"}, section.Applications())
+}
+
+func TestNewClassesSectionEmpty(t *testing.T) {
+	section, err := NewClassesSection(parseTags("  0\nSECTION\n  2\nCLASSES\n  0\nENDSEC\n"))
+	assert.Nil(t, err)
+	assert.Len(t, section.Classes, 0)
+	assert.Len(t, section.Applications(), 0)
+}
+
+func TestNewClassesSectionInvalidRecord(t *testing.T) {
+	_, err := NewClassesSection(parseTags(strings.Replace(dxfClassesSection, "CLASS\n  1\nAEC", "LINE\n  1\nAEC", 1)))
+	assert.NotNil(t, err)
+}
+
+func TestClassesSectionTags(t *testing.T) {
+	section, err := NewClassesSection(parseTags(dxfClassesSection))
+	assert.Nil(t, err)
+
+	tags := section.Tags()
+	assert.True(t, parseTags(dxfClassesSection).Equals(tags))
+
+	written, err := NewClassesSection(writeAndParseTags(t, tags))
+	assert.Nil(t, err)
+	assert.True(t, section.Equals(written))
+}
@@ -1,6 +1,8 @@
 package sections
 
 import (
+	"fmt"
+
 	"github.com/rpaloschi/dxf-go/core"
 	"github.com/rpaloschi/dxf-go/entities"
 )
@@ -28,13 +30,18 @@ func (e EntitiesSection) Equals(other core.DxfElement) bool {
 
 // NewEntitiesSection parses the EntitiesSection from a slice of tags.
 func NewEntitiesSection(tags core.TagSlice) (*EntitiesSection, error) {
+	return Parser{}.EntitiesSection(tags)
+}
+
+// EntitiesSection parses the EntitiesSection from a slice of tags.
+func (p Parser) EntitiesSection(tags core.TagSlice) (*EntitiesSection, error) {
 	section := new(EntitiesSection)
 
-	if len(tags) == 3 {
+	if len(tags) <= 3 {
 		return section, nil
 	}
 
-	entities, err := NewEntityList(core.TagGroups(tags[2:len(tags)-1], 0))
+	entities, err := p.EntityList(core.TagGroups(tags[2:len(tags)-1], 0))
 	if err != nil {
 		return nil, err
 	}
@@ -45,39 +52,111 @@ func NewEntitiesSection(tags core.TagSlice) (*EntitiesSection, error) {
 
 // NewEntityList Parses a list of tag slices into entities. returns an EntitySlice.
 func NewEntityList(tags []core.TagSlice) (entities.EntitySlice, error) {
+	return Parser{}.EntityList(tags)
+}
+
+// EntityList parses a list of tag slices into entities. The entities it
+// recovers from are skipped, with their VERTEX, ATTRIB and SEQEND. The
+// entities are built by the Workers of the Parser, see parseEntities.
+func (p Parser) EntityList(tags []core.TagSlice) (entities.EntitySlice, error) {
+	parsed, err := p.parseEntities(tags)
+	if err != nil {
+		return nil, err
+	}
+	return p.entityList(tags, parsed)
+}
+
+// entityList assembles the entities of the tags in their order, nesting the
+// VERTEX, ATTRIB and SEQEND ones in their POLYLINE or INSERT. The entities
+// are taken from parsed, or built as they come when it is nil.
+func (p Parser) entityList(tags []core.TagSlice, parsed []parsedEntity) (entities.EntitySlice, error) {
 	entityList := make(entities.EntitySlice, 0)
 
 	var accumulator *entityAccumulator
-	for _, group := range tags {
+	skipping := false
+	for i, group := range tags {
 		entityType := group[0].Value.ToString()
+		if skipping && nestedEntityTypes[entityType] {
+			continue
+		}
+		skipping = false
+
+		if err := p.Limiter.AddEntity(); err != nil {
+			return nil, err
+		}
+
+		if _, ok := entityFactory[entityType]; !ok {
+			p.Diagnostics.Report(core.SeverityInfo, group, "Keeping unsupported Entity Type: %v", entityType)
+		}
 
-		if factory, ok := entityFactory[entityType]; ok {
-			entity, err := factory(group)
-			if err != nil {
+		var entity entities.Entity
+		var err error
+		if parsed != nil {
+			entity, err = parsed[i].entity, parsed[i].err
+		} else {
+			entity, err = newEntity(group)
+		}
+		if err != nil {
+			if err = p.Recover(group, err); err != nil {
 				return nil, err
 			}
+			skipping = accumulator == nil
+			continue
+		}
 
-			if accumulator != nil {
-				if entity.IsSeqEnd() {
-					accumulator.Stop()
-					entityList = append(entityList, accumulator.parent)
-					accumulator = nil
-				} else {
-					accumulator.entities = append(accumulator.entities, entity)
+		if accumulator != nil {
+			if entity.IsSeqEnd() {
+				if parent := entities.EntityBase(accumulator.parent); parent != nil {
+					parent.SeqEndHandle = entities.EntityBase(entity).Handle
 				}
-			} else if entity.HasNestedEntities() {
-				accumulator = newEntityAccumulator(entity)
+				accumulator.Stop()
+				entityList = append(entityList, accumulator.parent)
+				accumulator = nil
+			} else if _, ok := entity.(*entities.Vertex); !ok && isPolyline(accumulator.parent) {
+				p.Diagnostics.Report(core.SeverityWarning, group, "Skipping entity, polylines can only contain Vertex entities")
 			} else {
-				entityList = append(entityList, entity)
+				accumulator.entities = append(accumulator.entities, entity)
 			}
+		} else if entity.HasNestedEntities() {
+			accumulator = newEntityAccumulator(entity)
 		} else {
-			core.Log.Printf("Unsupported Entity Type: %v", entityType)
+			entityList = append(entityList, entity)
 		}
 	}
 
 	return entityList, nil
 }
 
+// Tags returns the tags of the ENTITIES section.
+func (e EntitiesSection) Tags() core.TagSlice {
+	return e.TagsOptions(core.WriteOptions{})
+}
+
+// TagsOptions returns the tags of the ENTITIES section, logging the
+// entities skipped to the Logger of the options.
+func (e EntitiesSection) TagsOptions(options core.WriteOptions) core.TagSlice {
+	return sectionTags("ENTITIES", entityListTags(e.Entities, options))
+}
+
+// entityListTags returns the tags of the entities that can be written,
+// the others are skipped and logged to the Logger of the options.
+func entityListTags(list entities.EntitySlice, options core.WriteOptions) core.TagSlice {
+	var tags core.TagSlice
+	for _, entity := range list {
+		if writable, ok := entity.(entities.Writable); ok {
+			tags = append(tags, writable.Tags()...)
+		} else {
+			options.Warn("Skipping entity, it cannot be written", "type", fmt.Sprintf("%T", entity))
+		}
+	}
+	return tags
+}
+
+func isPolyline(entity entities.Entity) bool {
+	_, ok := entity.(*entities.Polyline)
+	return ok
+}
+
 type entityAccumulator struct {
 	parent   entities.Entity
 	entities entities.EntitySlice
@@ -96,10 +175,29 @@ func newEntityAccumulator(parent entities.Entity) *entityAccumulator {
 	return accumulator
 }
 
+// nestedEntityTypes has the types of the entities nested in a POLYLINE or an
+// INSERT, up to their SEQEND.
+var nestedEntityTypes = map[string]bool{"VERTEX": true, "ATTRIB": true, "SEQEND": true}
+
 type entityFactoryFunc func(tags core.TagSlice) (entities.Entity, error)
 
 var entityFactory map[string]entityFactoryFunc
 
+// unknownEntityFactory keeps the entities of the unsupported types.
+func unknownEntityFactory(tags core.TagSlice) (entities.Entity, error) {
+	return entities.NewUnknown(tags)
+}
+
+// newEntity builds the entity of the tags, an Unknown one for the
+// unsupported types.
+func newEntity(tags core.TagSlice) (entities.Entity, error) {
+	factory, ok := entityFactory[tags[0].Value.ToString()]
+	if !ok {
+		factory = unknownEntityFactory
+	}
+	return factory(tags)
+}
+
 func init() {
 	entityFactory = map[string]entityFactoryFunc{
 		"LINE": func(tags core.TagSlice) (entities.Entity, error) {
@@ -138,5 +236,11 @@ func init() {
 		"SPLINE": func(tags core.TagSlice) (entities.Entity, error) {
 			return entities.NewSpline(tags)
 		},
+		"SOLID": func(tags core.TagSlice) (entities.Entity, error) {
+			return entities.NewSolid(tags)
+		},
+		"VIEWPORT": func(tags core.TagSlice) (entities.Entity, error) {
+			return entities.NewViewport(tags)
+		},
 	}
 }
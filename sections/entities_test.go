@@ -115,6 +115,13 @@ func TestNewEntitiesSection(t *testing.T) {
 				ControlPointTolerance: 0.0000001,
 				FitTolerance:          0.0000000001,
 			},
+			&entities.Solid{
+				BaseEntity:         base,
+				SecondCorner:       core.Point{X: 1.0},
+				ThirdCorner:        core.Point{Y: 1.0},
+				FourthCorner:       core.Point{Y: 1.0},
+				ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0},
+			},
 		},
 	}
 
@@ -129,7 +136,7 @@ func TestNewEntitiesSection(t *testing.T) {
 		spew.Sdump(expected), spew.Sdump(section))
 }
 
-func TestNewEntitiesSectionInvalidEntityIsIgnored(t *testing.T) {
+func TestNewEntitiesSectionInvalidEntityIsKept(t *testing.T) {
 	expected := EntitiesSection{
 		Entities: entities.EntitySlice{
 			&entities.Line{
@@ -143,6 +150,11 @@ func TestNewEntitiesSectionInvalidEntityIsIgnored(t *testing.T) {
 				End:                core.Point{X: 2.0, Y: 5.0, Z: 7.0},
 				ExtrusionDirection: core.Point{X: 0.0, Y: 0.0, Z: 1.0},
 			},
+			&entities.Unknown{
+				BaseEntity: entities.BaseEntity{On: true, Visible: true},
+				Type:       "BLABLA",
+				Raw:        core.TagSlice{core.NewStringTag(0, "BLABLA")},
+			},
 		},
 	}
 	next := core.Tagger(strings.NewReader(dxfLineAndInvalidEntitiesSection))
@@ -151,6 +163,7 @@ func TestNewEntitiesSectionInvalidEntityIsIgnored(t *testing.T) {
 	section, err := NewEntitiesSection(tags)
 
 	assert.Equal(t, nil, err)
+	assert.Len(t, section.Entities, 2)
 	assert.True(t, expected.Equals(section),
 		"Expected %+v and %+v to be equals",
 		spew.Sdump(expected), spew.Sdump(section))
@@ -180,6 +193,53 @@ func TestNewEntitiesSectionParsingError(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestEntitiesSectionLenientParsing(t *testing.T) {
+	tags := core.TagSlice(core.AllTags(core.Tagger(strings.NewReader(dxfEntitiesSection))))
+	all, err := NewEntitiesSection(tags)
+	assert.Nil(t, err)
+
+	insert := tags.TagIndex(0, 0, len(tags))
+	for tags[insert].Value.ToString() != "INSERT" {
+		insert = tags.TagIndex(0, insert+1, len(tags))
+	}
+	point := tags.TagIndex(10, insert, len(tags))
+	tags[point] = &core.Tag{Code: 10, Value: core.NewStringValue("ERROR"), Line: 7}
+
+	strict := Parser{Diagnostics: core.NewDiagnostics(core.ParseOptions{Mode: core.Strict})}
+	_, err = strict.EntitiesSection(tags)
+	assert.NotNil(t, err)
+
+	// the INSERT is skipped with its VERTEXes and SEQEND.
+	lenient := Parser{Diagnostics: core.NewDiagnostics(core.ParseOptions{Mode: core.Lenient})}
+	section, err := lenient.EntitiesSection(tags)
+	assert.Nil(t, err)
+	assert.Len(t, section.Entities, len(all.Entities)-1)
+	for _, entity := range section.Entities {
+		_, isInsert := entity.(*entities.Insert)
+		assert.False(t, isInsert)
+	}
+	assert.Len(t, lenient.Diagnostics.List, 1)
+	assert.Equal(t, core.Diagnostic{
+		Severity: core.SeverityError, Line: 7, Code: 10, EntityType: "INSERT", Handle: "3E5",
+		Message: "Error parsing type of String \"ERROR\" as a Float",
+	}, lenient.Diagnostics.List[0])
+}
+
+func TestEntitiesSectionPolylineDiagnostics(t *testing.T) {
+	tags := core.TagSlice(core.AllTags(core.Tagger(strings.NewReader(
+		"  0\nPOLYLINE\n  5\n1A\n 66\n1\n  0\nVERTEX\n 10\n1.0\n  0\nLINE\n  5\n1B\n  0\nSEQEND\n"))))
+	parser := Parser{Diagnostics: core.NewDiagnostics(core.ParseOptions{})}
+	list, err := parser.EntityList(core.TagGroups(tags, 0))
+	assert.Nil(t, err)
+
+	// the LINE is skipped, the POLYLINE keeps its VERTEX.
+	assert.Len(t, list, 1)
+	assert.Len(t, list[0].(*entities.Polyline).Vertices, 1)
+	assert.Equal(t, []core.Diagnostic{{Severity: core.SeverityWarning, Line: 11, Code: 0, EntityType: "LINE",
+		Handle: "1B", Message: "Skipping entity, polylines can only contain Vertex entities"}},
+		parser.Diagnostics.List)
+}
+
 func TestEntitiesSectionEquality(t *testing.T) {
 	testCases := []struct {
 		es1    EntitiesSection
@@ -362,6 +422,16 @@ SPLINE
   8
 0
   0
+SOLID
+  5
+3E5
+  8
+0
+ 11
+1.0
+ 22
+1.0
+  0
 ENDSEC
 `
 
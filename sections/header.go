@@ -1,6 +1,10 @@
 package sections
 
-import "github.com/rpaloschi/dxf-go/core"
+import (
+	"sort"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
 
 const tagACADVER = "$ACADVER"
 const tagDWGCODEPAGE = "$DWGCODEPAGE"
@@ -76,3 +80,28 @@ func (section *HeaderSection) Get(key string) core.TagSlice {
 	}
 	return core.TagSlice{}
 }
+
+// Tags returns the tags of the HEADER section, the variables sorted by name
+// after $ACADVER and $DWGCODEPAGE, which give the encoding of the strings
+// that follow them (see core.Tagger).
+func (section HeaderSection) Tags() core.TagSlice {
+	keys := make([]string, 0, len(section.Values))
+	for key := range section.Values {
+		if key != tagACADVER && key != tagDWGCODEPAGE {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range []string{tagDWGCODEPAGE, tagACADVER} {
+		if _, ok := section.Values[key]; ok {
+			keys = append([]string{key}, keys...)
+		}
+	}
+
+	var tags core.TagSlice
+	for _, key := range keys {
+		tags = append(tags, core.NewStringTag(9, key))
+		tags = append(tags, section.Values[key]...)
+	}
+	return sectionTags("HEADER", tags)
+}
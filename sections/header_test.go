@@ -72,7 +72,7 @@ func (suite *HeaderTestSuite) TestGetMissingValue() {
 func (suite *HeaderTestSuite) TestGetSimpleTagKey() {
 	result := suite.header.Get("$ACADVER")
 	expected := core.NewTag(1, core.NewStringValue("AC1021"))
-	suite.Equal(expected, result[0])
+	suite.True(expected.Equals(result[0]))
 }
 
 func (suite *HeaderTestSuite) TestMultipleTagsKey() {
@@ -82,7 +82,7 @@ func (suite *HeaderTestSuite) TestMultipleTagsKey() {
 		core.NewTag(20, core.NewFloatValue(22.0)),
 		core.NewTag(30, core.NewFloatValue(53.5)),
 	}
-	suite.Equal(expected, result)
+	suite.True(expected.Equals(result))
 }
 
 const testHeaderDuplicateKey = `  0
@@ -117,7 +117,7 @@ func (suite *HeaderTestSuite) TestDuplicateTagsKey() {
 		core.NewTag(20, core.NewFloatValue(22.0)),
 		core.NewTag(30, core.NewFloatValue(53.5)),
 	}
-	suite.Equal(expected, result)
+	suite.True(expected.Equals(result))
 }
 
 func (suite *HeaderTestSuite) TestHeaderEquality() {
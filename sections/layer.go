@@ -10,6 +10,8 @@ const frozenBit = 0x1
 // Layer representation.
 type Layer struct {
 	core.DxfParseable
+	Handle   string
+	Owner    string
 	Name     string
 	Color    int
 	LineType string
@@ -22,7 +24,9 @@ type Layer struct {
 // on Layer struct, not on parent core.DxfParseable.
 func (l Layer) Equals(other core.DxfElement) bool {
 	if otherLayer, ok := other.(*Layer); ok {
-		return l.Name == otherLayer.Name &&
+		return l.Handle == otherLayer.Handle &&
+			l.Owner == otherLayer.Owner &&
+			l.Name == otherLayer.Name &&
 			l.Color == otherLayer.Color &&
 			l.LineType == otherLayer.LineType &&
 			l.Locked == otherLayer.Locked &&
@@ -40,7 +44,9 @@ func NewLayer(tags core.TagSlice) (*Layer, error) {
 	layer.Color = 7
 
 	layer.Init(map[int]core.TypeParser{
-		2: core.NewStringTypeParserToVar(&layer.Name),
+		2:   core.NewStringTypeParserToVar(&layer.Name),
+		5:   core.NewStringTypeParserToVar(&layer.Handle),
+		330: core.NewStringTypeParserToVar(&layer.Owner),
 		70: core.NewIntTypeParser(func(flags int) {
 			layer.Frozen = flags&frozenBit != 0
 			layer.Locked = flags&lockBit != 0
@@ -63,6 +69,11 @@ func NewLayer(tags core.TagSlice) (*Layer, error) {
 // NewLayerTable parses the slice of tags into a table that maps the layer name to
 // the parsed Layer object.
 func NewLayerTable(tags core.TagSlice) (Table, error) {
+	return Parser{}.LayerTable(tags)
+}
+
+// LayerTable parses a LAYER table, skipping the entries it recovers from.
+func (p Parser) LayerTable(tags core.TagSlice) (Table, error) {
 	table := make(Table)
 
 	tableSlices, err := TableEntryTags(tags)
@@ -73,7 +84,10 @@ func NewLayerTable(tags core.TagSlice) (Table, error) {
 	for _, slice := range tableSlices {
 		layer, err := NewLayer(slice)
 		if err != nil {
-			return nil, err
+			if err = p.Recover(slice, err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 		table[layer.Name] = layer
 	}
@@ -81,6 +95,31 @@ func NewLayerTable(tags core.TagSlice) (Table, error) {
 	return table, nil
 }
 
+// Tags returns the tags of the Layer table entry, with its unparsed tags.
+func (l Layer) Tags() core.TagSlice {
+	flags := 0
+	if l.Frozen {
+		flags |= frozenBit
+	}
+	if l.Locked {
+		flags |= lockBit
+	}
+	color := l.Color
+	if !l.On {
+		color = -color
+	}
+
+	tags := recordTags("LAYER", l.Handle, l.Owner, "AcDbLayerTableRecord")
+	tags = append(tags,
+		core.NewStringTag(2, l.Name),
+		core.NewIntegerTag(70, flags),
+		core.NewIntegerTag(62, color))
+	if l.LineType != "" {
+		tags = append(tags, core.NewStringTag(6, l.LineType))
+	}
+	return l.WithUnparsedTags(tags)
+}
+
 // TODO:
 // 290 Plotting flag. If set to 0, do not plot this layer
 // 370 Lineweight enum value
@@ -147,7 +147,8 @@ func TestNewLayerTableWrongTagType(t *testing.T) {
 	_, err := NewLayerTable(tags)
 
 	assert.Equal(t,
-		"Error parsing type of &core.String{value:\"im an int ;-)\"} as an Integer",
+		"Error parsing \"im an int ;-)\" (line 13, group code 62, LAYER): "+
+			"Error parsing type of String \"im an int ;-)\" as an Integer",
 		err.Error())
 }
 
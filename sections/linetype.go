@@ -39,6 +39,8 @@ func (e LineElement) Equals(other LineElement) bool {
 // LineType representation
 type LineType struct {
 	core.DxfParseable
+	Handle      string
+	Owner       string
 	Name        string
 	Description string
 	Length      float64
@@ -48,7 +50,9 @@ type LineType struct {
 // Equals compares two LineType objects for equality.
 func (ltype LineType) Equals(other core.DxfElement) bool {
 	if otherLtype, ok := other.(*LineType); ok {
-		if ltype.Name != otherLtype.Name ||
+		if ltype.Handle != otherLtype.Handle ||
+			ltype.Owner != otherLtype.Owner ||
+			ltype.Name != otherLtype.Name ||
 			ltype.Description != otherLtype.Description ||
 			!core.FloatEquals(ltype.Length, otherLtype.Length) ||
 			len(ltype.Pattern) != len(otherLtype.Pattern) {
@@ -70,15 +74,31 @@ func (ltype LineType) Equals(other core.DxfElement) bool {
 
 // NewLineType creates a new LineType object from a slice of tags.
 func NewLineType(tags core.TagSlice) (*LineType, error) {
+	return Parser{}.lineType(tags)
+}
+
+// lineType creates a new LineType from a slice of tags, reporting its odd
+// tags to the Diagnostics.
+func (p Parser) lineType(tags core.TagSlice) (*LineType, error) {
 	ltype := new(LineType)
 	ltype.Pattern = make([]*LineElement, 0)
 
 	flags74 := 0
 	var lineElement *LineElement
+	// the element tags before the first 49 of a malformed file have no
+	// element, they are ignored.
+	var ignored LineElement
+	element := func() *LineElement {
+		if lineElement == nil {
+			return &ignored
+		}
+		return lineElement
+	}
 
 	ltype.Init(map[int]core.TypeParser{
 		2:  core.NewStringTypeParserToVar(&ltype.Name),
 		3:  core.NewStringTypeParserToVar(&ltype.Description),
+		5:  core.NewStringTypeParserToVar(&ltype.Handle),
 		40: core.NewFloatTypeParserToVar(&ltype.Length),
 		49: core.NewFloatTypeParser(func(length float64) {
 			if lineElement != nil {
@@ -91,35 +111,38 @@ func NewLineType(tags core.TagSlice) (*LineType, error) {
 		74: core.NewIntTypeParser(func(flags int) {
 			flags74 = flags
 			if flags74 > 0 {
-				lineElement.AbsoluteRotation = flags74&absRotationBit > 0
-				lineElement.IsTextString = flags74&textStringBit > 0
-				lineElement.IsShape = flags74&elementShapeBit > 0
+				current := element()
+				current.AbsoluteRotation = flags74&absRotationBit > 0
+				current.IsTextString = flags74&textStringBit > 0
+				current.IsShape = flags74&elementShapeBit > 0
 			}
 		}),
 		75: core.NewIntTypeParser(func(flags int) {
+			current := element()
 			if flags74 == 0 {
-				core.Log.Print("WARNING! there should be no 75 Code tag if 74 value is 0\n")
-			} else if lineElement.IsTextString && flags != 0 {
-				core.Log.Print("WARNING! Tag 75 should be 0 if 74 is a TextString\n")
-			} else if lineElement.IsShape {
-				lineElement.ShapeNumber = flags
+				p.Diagnostics.Report(core.SeverityWarning, tags, "There should be no 75 code tag if 74 value is 0")
+			} else if current.IsTextString && flags != 0 {
+				p.Diagnostics.Report(core.SeverityWarning, tags, "Tag 75 should be 0 if 74 is a text string")
+			} else if current.IsShape {
+				current.ShapeNumber = flags
 			}
 		}),
 		46: core.NewFloatTypeParser(func(scale float64) {
-			lineElement.Scale = scale
+			element().Scale = scale
 		}),
 		50: core.NewFloatTypeParser(func(angle float64) {
-			lineElement.RotationAngle = angle
+			element().RotationAngle = angle
 		}),
 		44: core.NewFloatTypeParser(func(xOffset float64) {
-			lineElement.XOffset = xOffset
+			element().XOffset = xOffset
 		}),
 		45: core.NewFloatTypeParser(func(yOffset float64) {
-			lineElement.YOffset = yOffset
+			element().YOffset = yOffset
 		}),
 		9: core.NewStringTypeParser(func(text string) {
-			lineElement.Text = text
+			element().Text = text
 		}),
+		330: core.NewStringTypeParserToVar(&ltype.Owner),
 	})
 
 	err := ltype.Parse(tags)
@@ -134,6 +157,11 @@ func NewLineType(tags core.TagSlice) (*LineType, error) {
 // NewLineTypeTable parses the slice of tags into a table that maps the LineType name to
 // the parsed LineType object.
 func NewLineTypeTable(tags core.TagSlice) (Table, error) {
+	return Parser{}.LineTypeTable(tags)
+}
+
+// LineTypeTable parses a LTYPE table, skipping the entries it recovers from.
+func (p Parser) LineTypeTable(tags core.TagSlice) (Table, error) {
 	table := make(Table)
 
 	tableSlices, err := TableEntryTags(tags)
@@ -142,12 +170,58 @@ func NewLineTypeTable(tags core.TagSlice) (Table, error) {
 	}
 
 	for _, slice := range tableSlices {
-		ltype, err := NewLineType(slice)
+		ltype, err := p.lineType(slice)
 		if err != nil {
-			return nil, err
+			if err = p.Recover(slice, err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 		table[ltype.Name] = ltype
 	}
 
 	return table, nil
 }
+
+// Tags returns the tags of the LineType table entry, with its unparsed
+// tags.
+func (ltype LineType) Tags() core.TagSlice {
+	tags := recordTags("LTYPE", ltype.Handle, ltype.Owner, "AcDbLinetypeTableRecord")
+	tags = append(tags,
+		core.NewStringTag(2, ltype.Name),
+		core.NewIntegerTag(70, 0),
+		core.NewStringTag(3, ltype.Description),
+		core.NewIntegerTag(72, 65),
+		core.NewIntegerTag(73, len(ltype.Pattern)),
+		core.NewFloatTag(40, ltype.Length))
+
+	for _, element := range ltype.Pattern {
+		tags = append(tags, core.NewFloatTag(49, element.Length))
+
+		flags := 0
+		if element.AbsoluteRotation {
+			flags |= absRotationBit
+		}
+		if element.IsTextString {
+			flags |= textStringBit
+		}
+		if element.IsShape {
+			flags |= elementShapeBit
+		}
+		tags = append(tags, core.NewIntegerTag(74, flags))
+		if flags == 0 {
+			continue
+		}
+
+		tags = append(tags,
+			core.NewIntegerTag(75, element.ShapeNumber),
+			core.NewFloatTag(46, element.Scale),
+			core.NewFloatTag(50, element.RotationAngle),
+			core.NewFloatTag(44, element.XOffset),
+			core.NewFloatTag(45, element.YOffset))
+		if element.IsTextString {
+			tags = append(tags, core.NewStringTag(9, element.Text))
+		}
+	}
+	return ltype.WithUnparsedTags(tags)
+}
@@ -157,11 +157,13 @@ ENDTAB
 func TestNewLineTypeTable(t *testing.T) {
 	expected := map[string]*LineType{
 		"CONTINUOUS": {
+			Handle:      "B",
 			Name:        "CONTINUOUS",
 			Description: "Solid line",
 			Length:      0.0,
 			Pattern:     []*LineElement{}},
 		"DASHED": {
+			Handle:      "3E1",
 			Name:        "DASHED",
 			Description: "__ __",
 			Length:      0.75,
@@ -238,7 +240,8 @@ func TestNewLineTypeTableWrongTagType(t *testing.T) {
 	_, err := NewLineTypeTable(tags)
 
 	assert.Equal(t,
-		"Error parsing type of &core.String{value:\"im a fake float\"} as a Float",
+		"Error parsing \"im a fake float\" (line 23, group code 40, LTYPE, handle B): "+
+			"Error parsing type of String \"im a fake float\" as a Float",
 		err.Error())
 }
 
@@ -332,6 +335,27 @@ func TestParseLineTypeShapeNrWhenIsText(t *testing.T) {
 	assert.Equal(t, 0, lineType.Pattern[0].ShapeNumber)
 }
 
+func TestParseLineTypeShapeNrDiagnostics(t *testing.T) {
+	parser := Parser{Diagnostics: core.NewDiagnostics(core.ParseOptions{})}
+	for _, fragment := range []string{dxfLineTypeShapeNrNoShapeOrText, dxfLineTypeShapeNrNot0AndText} {
+		_, err := parser.lineType(core.TagSlice(core.AllTags(core.Tagger(strings.NewReader(fragment)))))
+		assert.Nil(t, err)
+	}
+
+	assert.Len(t, parser.Diagnostics.List, 2)
+	assert.Equal(t, core.Diagnostic{Severity: core.SeverityWarning, Line: 1, Code: 0, EntityType: "LTYPE",
+		Handle: "3E1", Message: "There should be no 75 code tag if 74 value is 0"}, parser.Diagnostics.List[0])
+	assert.Equal(t, "Tag 75 should be 0 if 74 is a text string", parser.Diagnostics.List[1].Message)
+}
+
+func TestParseLineTypeElementTagsBeforeLength(t *testing.T) {
+	lineType, err := lineTypeFromDxfFragment("  0\nLTYPE\n  2\nDASHED\n  9\nTEXT\n 74\n2\n 75\n1\n 46\n2.0\n 49\n0.5\n")
+
+	assert.Nil(t, err)
+	assert.Len(t, lineType.Pattern, 1)
+	assert.True(t, lineType.Pattern[0].Equals(LineElement{Length: 0.5, Scale: 1.0}))
+}
+
 func TestCompareLineTypeWrongType(t *testing.T) {
 	layer, _ := lineTypeFromDxfFragment(dxfLineTypeShapeNrNot0AndText)
 	assert.False(t, layer.Equals(core.NewStringValue("str")))
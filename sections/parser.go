@@ -0,0 +1,31 @@
+package sections
+
+import (
+	"errors"
+
+	"github.com/rpaloschi/dxf-go/core"
+)
+
+// Parser parses the sections of a DXF file in the mode of its Diagnostics,
+// reporting to them the problems it finds, within the limits of its Limiter.
+// The zero Parser is strict, unlimited and discards its messages, as the
+// NewXSection functions (see core.Diagnostics).
+type Parser struct {
+	Diagnostics *core.Diagnostics
+	Limiter     *core.Limiter
+	// Workers is the number of goroutines building the entities of the
+	// ENTITIES and BLOCKS sections, they are built sequentially when it is 0
+	// or 1.
+	Workers int
+}
+
+// Recover handles the err parsing the element made of tags, see
+// core.Diagnostics.Recover. An err that is not a core.ParseError is located
+// at the first tag.
+func (p Parser) Recover(tags core.TagSlice, err error) error {
+	var parseError *core.ParseError
+	if len(tags) > 0 && !errors.As(err, &parseError) {
+		err = core.NewParseError(tags, tags[0], err)
+	}
+	return p.Diagnostics.Recover(tags, err)
+}
@@ -13,6 +13,8 @@ const upsideDownBit = 0x4
 // Style Table representation
 type Style struct {
 	core.DxfParseable
+	Handle         string
+	Owner          string
 	Name           string
 	Height         float64
 	Width          float64
@@ -28,7 +30,9 @@ type Style struct {
 // Equals compares two Style objects for equality.
 func (style Style) Equals(other core.DxfElement) bool {
 	if otherStyle, ok := other.(*Style); ok {
-		return style.Name == otherStyle.Name &&
+		return style.Handle == otherStyle.Handle &&
+			style.Owner == otherStyle.Owner &&
+			style.Name == otherStyle.Name &&
 			core.FloatEquals(style.Height, otherStyle.Height) &&
 			core.FloatEquals(style.Width, otherStyle.Width) &&
 			core.FloatEquals(style.Oblique, otherStyle.Oblique) &&
@@ -53,6 +57,7 @@ func NewStyle(tags core.TagSlice) (*Style, error) {
 		2:  core.NewStringTypeParserToVar(&style.Name),
 		3:  core.NewStringTypeParserToVar(&style.Font),
 		4:  core.NewStringTypeParserToVar(&style.BigFont),
+		5:  core.NewStringTypeParserToVar(&style.Handle),
 		40: core.NewFloatTypeParserToVar(&style.Height),
 		41: core.NewFloatTypeParserToVar(&style.Width),
 		50: core.NewFloatTypeParserToVar(&style.Oblique),
@@ -64,6 +69,7 @@ func NewStyle(tags core.TagSlice) (*Style, error) {
 			style.IsBackwards = flags&backwardsBit != 0
 			style.IsUpsideDown = flags&upsideDownBit != 0
 		}),
+		330: core.NewStringTypeParserToVar(&style.Owner),
 	})
 
 	err := style.Parse(tags)
@@ -73,6 +79,11 @@ func NewStyle(tags core.TagSlice) (*Style, error) {
 // NewStyleTable parses the slice of tags into a table that maps the Style name to
 // the parsed Style object.
 func NewStyleTable(tags core.TagSlice) (Table, error) {
+	return Parser{}.StyleTable(tags)
+}
+
+// StyleTable parses a STYLE table, skipping the entries it recovers from.
+func (p Parser) StyleTable(tags core.TagSlice) (Table, error) {
 	table := make(Table)
 
 	tableSlices, err := TableEntryTags(tags)
@@ -83,10 +94,43 @@ func NewStyleTable(tags core.TagSlice) (Table, error) {
 	for _, slice := range tableSlices {
 		style, err := NewStyle(slice)
 		if err != nil {
-			return nil, err
+			if err = p.Recover(slice, err); err != nil {
+				return nil, err
+			}
+			continue
 		}
 		table[style.Name] = style
 	}
 
 	return table, nil
 }
+
+// Tags returns the tags of the Style table entry, with its unparsed tags.
+func (style Style) Tags() core.TagSlice {
+	flags := 0
+	if style.IsShape {
+		flags |= shapeBit
+	}
+	if style.IsVerticalText {
+		flags |= verticalTextBit
+	}
+	generation := 0
+	if style.IsBackwards {
+		generation |= backwardsBit
+	}
+	if style.IsUpsideDown {
+		generation |= upsideDownBit
+	}
+
+	tags := recordTags("STYLE", style.Handle, style.Owner, "AcDbTextStyleTableRecord")
+	tags = append(tags,
+		core.NewStringTag(2, style.Name),
+		core.NewIntegerTag(70, flags),
+		core.NewFloatTag(40, style.Height),
+		core.NewFloatTag(41, style.Width),
+		core.NewFloatTag(50, style.Oblique),
+		core.NewIntegerTag(71, generation),
+		core.NewStringTag(3, style.Font),
+		core.NewStringTag(4, style.BigFont))
+	return style.WithUnparsedTags(tags)
+}
@@ -194,7 +194,8 @@ func TestNewStyleTableWrongTagType(t *testing.T) {
 	_, err := NewStyleTable(tags)
 
 	assert.Equal(t,
-		"Error parsing type of &core.String{value:\"im a fake int\"} as an Integer",
+		"Error parsing \"im a fake int\" (line 19, group code 70, STYLE): "+
+			"Error parsing type of String \"im a fake int\" as an Integer",
 		err.Error())
 }
 
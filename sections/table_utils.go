@@ -1,29 +1,33 @@
 package sections
 
 import (
-	"errors"
 	"github.com/rpaloschi/dxf-go/core"
 )
 
 // TableEntryTags splits a slice of tags that contains the TABLES entry of a DXF file, validates
 // its basic structure and returns only the tags to be parsed as tables. (Removes the
-// initial and final markup tags for table).
+// initial and final markup tags for table). The invalid tables return a
+// core.ErrInvalidTable.
 func TableEntryTags(tags core.TagSlice) ([]core.TagSlice, error) {
 	groups := core.TagGroups(tags, 0)
+	if len(groups) < 2 {
+		return []core.TagSlice{}, core.ErrInvalidTable
+	}
 	lastIndex := len(groups) - 1
 	first := groups[0][0].Value.ToString()
 	last := groups[lastIndex][0].Value.ToString()
 
 	if first != "TABLE" || last != "ENDTAB" {
-		return []core.TagSlice{},
-			errors.New("Invalid table. Missing TABLE AND/OR ENDTAB tags.")
+		return []core.TagSlice{}, core.ErrInvalidTable
 	}
 
 	return groups[1:lastIndex], nil
 }
 
 // SplitTagChunks splits a TagSlice into a series of TagSlices delimited by chunkDelimiter tags.
-// The Iteration ends at stopTag.
+// The Iteration ends at stopTag. The last chunk does not end with the
+// chunkDelimiter when the tags end before it, in a truncated file.
+// The chunks share the array of tags, but with no capacity past their end.
 func SplitTagChunks(tags core.TagSlice, stopTag *core.Tag, chunkDelimiter *core.Tag) []core.TagSlice {
 	chunks := make([]core.TagSlice, 0)
 
@@ -33,28 +37,26 @@ func SplitTagChunks(tags core.TagSlice, stopTag *core.Tag, chunkDelimiter *core.
 			break
 		}
 
-		chunk := make([]*core.Tag, 1)
-		chunk[0] = tags[tagIndex]
+		start := tagIndex
 		tagIndex++
 
 		foundStop := false
-
-		for {
+		end := len(tags)
+		for tagIndex < len(tags) {
 			if tags[tagIndex].Equals(chunkDelimiter) {
-				chunk = append(chunk, tags[tagIndex])
 				tagIndex++
+				end = tagIndex
 				break
 			}
 			if tags[tagIndex].Equals(stopTag) {
 				foundStop = true
+				end = tagIndex
 				tagIndex++
 				break
-			} else {
-				chunk = append(chunk, tags[tagIndex])
-				tagIndex++
 			}
+			tagIndex++
 		}
-		chunks = append(chunks, chunk)
+		chunks = append(chunks, tags[start:end:end])
 
 		if foundStop {
 			break
@@ -63,3 +65,27 @@ func SplitTagChunks(tags core.TagSlice, stopTag *core.Tag, chunkDelimiter *core.
 
 	return chunks
 }
+
+// recordTags returns the first tags of a table record of recordType: its
+// type, its handle and owner, the table, when it has them and its subclass
+// markers.
+func recordTags(recordType string, handle string, owner string, subclass string) core.TagSlice {
+	tags := core.TagSlice{core.NewStringTag(0, recordType)}
+	if handle != "" {
+		tags = append(tags, core.NewStringTag(5, handle))
+	}
+	if owner != "" {
+		tags = append(tags, core.NewHandleTag(330, owner))
+	}
+	return append(tags,
+		core.NewStringTag(100, "AcDbSymbolTableRecord"),
+		core.NewStringTag(100, subclass))
+}
+
+// sectionTags returns the tags of the section name: its SECTION and ENDSEC
+// markers around the tags.
+func sectionTags(name string, tags core.TagSlice) core.TagSlice {
+	result := core.TagSlice{core.NewStringTag(0, "SECTION"), core.NewStringTag(2, name)}
+	result = append(result, tags...)
+	return append(result, core.NewStringTag(0, "ENDSEC"))
+}
@@ -51,6 +51,18 @@ func TestTableEntryTagsInvalidTables(t *testing.T) {
 	assert.Equal(t, expectedMessage, err.Error())
 }
 
+func TestTableEntryTagsEmptyTable(t *testing.T) {
+	groups, err := TableEntryTags(core.TagSlice{})
+
+	assert.Equal(t, []core.TagSlice{}, groups)
+	assert.Equal(t, core.ErrInvalidTable, err)
+
+	groups, err = tableEntryTagsFromDxfFragment("  0\nTABLE\n  2\nLAYER\n")
+
+	assert.Equal(t, []core.TagSlice{}, groups)
+	assert.Equal(t, core.ErrInvalidTable, err)
+}
+
 const tableTags = `  0
 TABLE
   2
@@ -73,7 +85,8 @@ func TestTableEntryTagsValidTable(t *testing.T) {
 	groups, err := tableEntryTagsFromDxfFragment(tableTags)
 
 	assert.Nil(t, err)
-	assert.Equal(t, expected, groups)
+	assert.Len(t, groups, len(expected))
+	assert.True(t, expected[0].Equals(groups[0]))
 }
 
 const tagsToSplit = `  0
@@ -123,3 +136,17 @@ func TestSplitTagChunks(t *testing.T) {
 		assert.True(t, slice.Equals(otherSlice))
 	}
 }
+
+func TestSplitTagChunksWithoutStopTag(t *testing.T) {
+	next := core.Tagger(strings.NewReader("  0\nTABLE\n  2\nLAYER\n  0\nENDTAB\n  0\nTABLE\n  2\nSTYLE\n"))
+	tags := core.TagSlice(core.AllTags(next))
+
+	chunks := SplitTagChunks(tags,
+		core.NewTag(0, core.NewStringValue("ENDSEC")),
+		core.NewTag(0, core.NewStringValue("ENDTAB")))
+
+	// the last chunk of a truncated file does not end with the delimiter.
+	assert.Len(t, chunks, 2)
+	assert.True(t, tags[:3].Equals(chunks[0]))
+	assert.True(t, tags[3:].Equals(chunks[1]))
+}
@@ -1,6 +1,8 @@
 package sections
 
 import (
+	"sort"
+
 	"github.com/rpaloschi/dxf-go/core"
 )
 
@@ -35,11 +37,31 @@ type TablesSection struct {
 	Layers    Table
 	Styles    Table
 	LineTypes Table
+	// Handles has the handles of the Layers, Styles and LineTypes tables,
+	// by table type, for the versions from R13 on.
+	Handles map[string]string
+	// Others has the tables that are not parsed, like VPORT or APPID, by
+	// table type. Each one keeps all its tags, from TABLE to ENDTAB.
+	Others map[string]core.TagSlice
 }
 
 // Equals Compare two TablesSection for equality
 func (t TablesSection) Equals(other core.DxfElement) bool {
 	if otherTable, ok := other.(*TablesSection); ok {
+		if len(t.Others) != len(otherTable.Others) || len(t.Handles) != len(otherTable.Handles) {
+			return false
+		}
+		for tableType, handle := range t.Handles {
+			if otherTable.Handles[tableType] != handle {
+				return false
+			}
+		}
+		for tableType, tags := range t.Others {
+			if otherTags, ok := otherTable.Others[tableType]; !ok || !tags.Equals(otherTags) {
+				return false
+			}
+		}
+
 		return t.Layers.Equals(otherTable.Layers) &&
 			t.Styles.Equals(otherTable.Styles) &&
 			t.LineTypes.Equals(otherTable.LineTypes)
@@ -50,37 +72,64 @@ func (t TablesSection) Equals(other core.DxfElement) bool {
 
 // NewTablesSection parses the TablesSection from a slice of tags.
 func NewTablesSection(tags core.TagSlice) (*TablesSection, error) {
+	return Parser{}.TablesSection(tags)
+}
+
+// TablesSection parses the TablesSection from a slice of tags.
+func (p Parser) TablesSection(tags core.TagSlice) (*TablesSection, error) {
 	tables := new(TablesSection)
 
 	tableParsers := map[string]func(slice core.TagSlice) error{
 		"LAYER": func(slice core.TagSlice) error {
-			layerTables, err := NewLayerTable(slice)
+			layerTables, err := p.LayerTable(slice)
 			tables.Layers = layerTables
 			return err
 
 		},
 		"STYLE": func(slice core.TagSlice) error {
-			styleTables, err := NewStyleTable(slice)
+			styleTables, err := p.StyleTable(slice)
 			tables.Styles = styleTables
 			return err
 		},
 		"LTYPE": func(slice core.TagSlice) error {
-			lineTypeTables, err := NewLineTypeTable(slice)
+			lineTypeTables, err := p.LineTypeTable(slice)
 			tables.LineTypes = lineTypeTables
 			return err
 		},
 	}
 
 	// skip (0, 'SECTION') and (2, 'TABLES')
+	if len(tags) < 2 {
+		return tables, nil
+	}
 	tags = tags[2:]
 	stopTag := core.NewTag(0, core.NewStringValue("ENDSEC"))
 	endOfChunk := core.NewTag(0, core.NewStringValue("ENDTAB"))
 	for _, tableTags := range SplitTagChunks(tags, stopTag, endOfChunk) {
 		entryTagsList, err := TableEntryTags(tableTags)
 		if err != nil {
-			return nil, err
+			if err = p.Recover(tableTags, err); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		// (0, 'TABLE') is followed by (2, table type)
+		if tableType := tableTags[1].Value.ToString(); tableParsers[tableType] == nil {
+			p.Diagnostics.Report(core.SeverityInfo, tableTags, "Keeping unknown table type: %+v", tableType)
+			if tables.Others == nil {
+				tables.Others = make(map[string]core.TagSlice)
+			}
+			tables.Others[tableType] = tableTags
+			continue
 		}
 
+		if handle := tableHandle(tableTags); handle != "" {
+			if tables.Handles == nil {
+				tables.Handles = make(map[string]string)
+			}
+			tables.Handles[tableTags[1].Value.ToString()] = handle
+		}
 		for _, entryTags := range entryTagsList {
 			tableType := entryTags[0].Value.ToString()
 			if tableFactory, ok := tableParsers[tableType]; ok {
@@ -88,10 +137,96 @@ func NewTablesSection(tags core.TagSlice) (*TablesSection, error) {
 					return nil, err
 				}
 			} else {
-				core.Log.Printf("Ignoring unknown table type: %+v\n", tableType)
+				p.Diagnostics.Report(core.SeverityWarning, entryTags, "Ignoring unknown table type: %+v", tableType)
 			}
 		}
 	}
 
 	return tables, nil
 }
+
+// tableEntry is implemented by the entries of the tables that can be written.
+type tableEntry interface {
+	Tags() core.TagSlice
+}
+
+// tableHandle returns the handle of the table of the tags, the 5 of its
+// TABLE tags before the first entry, or "".
+func tableHandle(tags core.TagSlice) string {
+	for _, tag := range tags[1:] {
+		if tag.Code == 0 {
+			break
+		}
+		if tag.Code == 5 {
+			return tag.Value.ToString()
+		}
+	}
+	return ""
+}
+
+// tags returns the tags of the table of tableType with the handle, if any,
+// the entries sorted by name. The entries that cannot be written are logged
+// to the Logger of the options.
+func (t Table) tags(tableType string, handle string, options core.WriteOptions) core.TagSlice {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tags := core.TagSlice{core.NewStringTag(0, "TABLE"), core.NewStringTag(2, tableType)}
+	if handle != "" {
+		tags = append(tags, core.NewStringTag(5, handle), core.NewHandleTag(330, "0"))
+	}
+	tags = append(tags,
+		core.NewStringTag(100, "AcDbSymbolTable"),
+		core.NewIntegerTag(70, len(t)))
+	for _, name := range names {
+		if entry, ok := t[name].(tableEntry); ok {
+			tags = append(tags, entry.Tags()...)
+		} else {
+			options.Warn("Skipping table entry, it cannot be written", "table", tableType, "name", name)
+		}
+	}
+	return append(tags, core.NewStringTag(0, "ENDTAB"))
+}
+
+// tableOrder is the order of the tables in the DXF reference.
+var tableOrder = []string{
+	"VPORT", "LTYPE", "LAYER", "STYLE", "VIEW", "UCS", "APPID", "DIMSTYLE", "BLOCK_RECORD",
+}
+
+// Tags returns the tags of the TABLES section, the tables in the order of
+// the DXF reference, followed by the other unknown ones sorted by type. Nil
+// tables are skipped.
+func (t TablesSection) Tags() core.TagSlice {
+	return t.TagsOptions(core.WriteOptions{})
+}
+
+// TagsOptions returns the tags of the TABLES section as Tags, logging the
+// entries skipped to the Logger of the options.
+func (t TablesSection) TagsOptions(options core.WriteOptions) core.TagSlice {
+	parsed := map[string]Table{"LTYPE": t.LineTypes, "LAYER": t.Layers, "STYLE": t.Styles}
+
+	var others []string
+	for tableType := range t.Others {
+		others = append(others, tableType)
+	}
+	sort.Strings(others)
+
+	var tags core.TagSlice
+	written := make(map[string]bool)
+	for _, tableType := range append(tableOrder, others...) {
+		if written[tableType] {
+			continue
+		}
+		written[tableType] = true
+
+		if table := parsed[tableType]; table != nil {
+			tags = append(tags, table.tags(tableType, t.Handles[tableType], options)...)
+		} else if tableTags, ok := t.Others[tableType]; ok {
+			tags = append(tags, tableTags...)
+		}
+	}
+	return sectionTags("TABLES", tags)
+}
@@ -1,6 +1,7 @@
 package sections
 
 import (
+	"errors"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/rpaloschi/dxf-go/core"
 	"github.com/stretchr/testify/assert"
@@ -71,6 +72,7 @@ func TestNewTablesSection(t *testing.T) {
 		},
 		LineTypes: Table{
 			"CONTINUOUS": &LineType{
+				Handle:      "B",
 				Name:        "CONTINUOUS",
 				Description: "Solid line",
 				Length:      1.0,
@@ -91,6 +93,7 @@ func TestNewTablesSection(t *testing.T) {
 				BigFont:        "stxt",
 			},
 		},
+		Handles: map[string]string{"LTYPE": "21"},
 	}
 
 	next := core.Tagger(strings.NewReader(dxfTablesSection))
@@ -175,7 +178,9 @@ func TestNewTablesSectionInvalidTableEntryTags(t *testing.T) {
 
 	_, err := NewTablesSection(tags)
 
-	assert.Equal(t, "Invalid table. Missing TABLE AND/OR ENDTAB tags.", err.Error())
+	assert.True(t, errors.Is(err, core.ErrInvalidTable))
+	assert.Equal(t, "Error parsing \"TABLE\" (line 5, group code 0, TABLE): "+
+		"Invalid table. Missing TABLE AND/OR ENDTAB tags.", err.Error())
 }
 
 const dxfTablesSectionInvalidTableEntryTags = `  0
@@ -233,9 +238,22 @@ func TestNewTablesSectionUnknownTableTypeDoesNotFail(t *testing.T) {
 	next := core.Tagger(strings.NewReader(dxfTablesUnknownType))
 	tags := core.TagSlice(core.AllTags(next))
 
-	_, err := NewTablesSection(tags)
+	section, err := NewTablesSection(tags)
+
+	assert.Nil(t, err)
+	assert.Len(t, section.Layers, 1)
+	assert.Len(t, section.Styles, 1)
+
+	// the unknown table is kept whole and written back.
+	assert.Len(t, section.Others, 1)
+	unknown := section.Others["INVALID_TYPE"]
+	assert.Len(t, unknown, 11)
+	assert.Equal(t, "TABLE", unknown[0].Value.ToString())
+	assert.Equal(t, "ENDTAB", unknown[len(unknown)-1].Value.ToString())
 
+	written, err := NewTablesSection(section.Tags())
 	assert.Nil(t, err)
+	assert.True(t, section.Equals(written))
 }
 
 const dxfTablesUnknownType = `  0
@@ -0,0 +1,155 @@
+package sections
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"golang.org/x/image/bmp"
+)
+
+// thumbnailChunkSize is the number of bytes of each 310 tag of the section.
+const thumbnailChunkSize = 127
+
+// bmpFileHeaderSize is the size of the BITMAPFILEHEADER missing from the
+// bitmaps of the section.
+const bmpFileHeaderSize = 14
+
+// ThumbnailImageSection THUMBNAILIMAGE section representation, the preview
+// image saved with the drawing since R2000.
+type ThumbnailImageSection struct {
+	// Data has the image as stored in the file, a BMP without its file
+	// header (a device independent bitmap) or, in newer files, a PNG.
+	Data []byte
+	// Image is the decoded Data, nil if its format is not supported.
+	Image image.Image
+}
+
+// Equals Compare two ThumbnailImageSection for equality.
+func (t ThumbnailImageSection) Equals(other core.DxfElement) bool {
+	if otherSection, ok := other.(*ThumbnailImageSection); ok {
+		return bytes.Equal(t.Data, otherSection.Data)
+	}
+	return false
+}
+
+// NewThumbnailImageSection parses the ThumbnailImageSection from a slice of
+// tags, joining the hex encoded chunks of its 310 tags. A Data that cannot
+// be decoded leaves the Image nil and is reported as a warning to the
+// Diagnostics, see Parser.ThumbnailImageSection.
+func NewThumbnailImageSection(tags core.TagSlice) (*ThumbnailImageSection, error) {
+	return Parser{}.ThumbnailImageSection(tags)
+}
+
+// ThumbnailImageSection parses the ThumbnailImageSection from a slice of
+// tags, reporting a Data that cannot be decoded as a warning to the
+// Diagnostics of the Parser.
+func (p Parser) ThumbnailImageSection(tags core.TagSlice) (*ThumbnailImageSection, error) {
+	section := new(ThumbnailImageSection)
+
+	if len(tags) <= 3 {
+		return section, nil
+	}
+
+	size := -1
+	var encoded strings.Builder
+	for _, tag := range tags[2 : len(tags)-1] {
+		switch tag.Code {
+		case 90:
+			if value, ok := core.AsInt(tag.Value); ok {
+				size = value
+			}
+		case 310:
+			encoded.WriteString(tag.Value.ToString())
+		}
+	}
+
+	data, err := hex.DecodeString(encoded.String())
+	if err != nil {
+		return nil, fmt.Errorf("Invalid THUMBNAILIMAGE section. %v", err)
+	}
+	if size >= 0 && size != len(data) {
+		return nil, fmt.Errorf("Invalid THUMBNAILIMAGE section. Expected %v bytes, got %v.", size, len(data))
+	}
+	section.Data = data
+
+	if len(data) > 0 {
+		section.Image, err = DecodeThumbnail(data)
+		if err != nil {
+			p.Diagnostics.Report(core.SeverityWarning, tags, "Unable to decode the thumbnail image: %v", err)
+		}
+	}
+
+	return section, nil
+}
+
+// DecodeThumbnail decodes the data of a THUMBNAILIMAGE section, a PNG or a
+// BMP with or without its file header.
+func DecodeThumbnail(data []byte) (image.Image, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG")):
+		return png.Decode(bytes.NewReader(data))
+	case bytes.HasPrefix(data, []byte("BM")):
+		return bmp.Decode(bytes.NewReader(data))
+	}
+
+	header, err := bmpFileHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	return bmp.Decode(bytes.NewReader(append(header, data...)))
+}
+
+// bmpFileHeader builds the BITMAPFILEHEADER of a device independent bitmap,
+// the pixels start after its info header, color masks and palette.
+func bmpFileHeader(dib []byte) ([]byte, error) {
+	if len(dib) < 40 {
+		return nil, errors.New("Unsupported thumbnail image format.")
+	}
+
+	infoSize := binary.LittleEndian.Uint32(dib[0:4])
+	bitCount := binary.LittleEndian.Uint16(dib[14:16])
+	compression := binary.LittleEndian.Uint32(dib[16:20])
+	colorsUsed := binary.LittleEndian.Uint32(dib[32:36])
+	if infoSize < 40 || int(infoSize) > len(dib) {
+		return nil, errors.New("Unsupported thumbnail image format.")
+	}
+
+	offset := bmpFileHeaderSize + infoSize
+	if infoSize == 40 && compression == 3 {
+		// BI_BITFIELDS color masks
+		offset += 12
+	}
+	if bitCount <= 8 {
+		if colorsUsed == 0 {
+			colorsUsed = 1 << bitCount
+		}
+		offset += 4 * colorsUsed
+	}
+
+	header := make([]byte, bmpFileHeaderSize)
+	copy(header, "BM")
+	binary.LittleEndian.PutUint32(header[2:6], uint32(bmpFileHeaderSize+len(dib)))
+	binary.LittleEndian.PutUint32(header[10:14], offset)
+	return header, nil
+}
+
+// Tags returns the tags of the THUMBNAILIMAGE section, the Data split in
+// hex encoded 310 tags.
+func (t ThumbnailImageSection) Tags() core.TagSlice {
+	tags := core.TagSlice{core.NewIntegerTag(90, len(t.Data))}
+	for start := 0; start < len(t.Data); start += thumbnailChunkSize {
+		end := start + thumbnailChunkSize
+		if end > len(t.Data) {
+			end = len(t.Data)
+		}
+		tags = append(tags, core.NewBinaryTag(310, t.Data[start:end]))
+	}
+	return sectionTags("THUMBNAILIMAGE", tags)
+}
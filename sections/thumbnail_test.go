@@ -0,0 +1,103 @@
+package sections
+
+import (
+	"bytes"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/image/bmp"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func testThumbnail() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	img.Set(2, 1, color.RGBA{B: 255, A: 255})
+	return img
+}
+
+// the thumbnails of the DXF files are bitmaps without their file header.
+func testThumbnailDIB(t *testing.T) []byte {
+	var buffer bytes.Buffer
+	assert.Nil(t, bmp.Encode(&buffer, testThumbnail()))
+	return buffer.Bytes()[bmpFileHeaderSize:]
+}
+
+func TestNewThumbnailImageSectionBMP(t *testing.T) {
+	dib := testThumbnailDIB(t)
+	tags := ThumbnailImageSection{Data: dib}.Tags()
+	assert.Len(t, tags, 4+(len(dib)+thumbnailChunkSize-1)/thumbnailChunkSize)
+
+	section, err := NewThumbnailImageSection(writeAndParseTags(t, tags))
+	assert.Nil(t, err)
+	assert.Equal(t, dib, section.Data)
+	assert.True(t, section.Equals(&ThumbnailImageSection{Data: dib}))
+	assert.False(t, section.Equals(&ThumbnailImageSection{}))
+	assert.False(t, section.Equals(core.NewStringValue("THUMBNAILIMAGE")))
+
+	assert.NotNil(t, section.Image)
+	assert.Equal(t, image.Rect(0, 0, 3, 2), section.Image.Bounds())
+	r, g, b, _ := section.Image.At(0, 0).RGBA()
+	assert.Equal(t, []uint32{0xffff, 0, 0}, []uint32{r, g, b})
+	r, g, b, _ = section.Image.At(2, 1).RGBA()
+	assert.Equal(t, []uint32{0, 0, 0xffff}, []uint32{r, g, b})
+}
+
+func TestNewThumbnailImageSectionPNG(t *testing.T) {
+	var buffer bytes.Buffer
+	assert.Nil(t, png.Encode(&buffer, testThumbnail()))
+
+	section, err := NewThumbnailImageSection(
+		writeAndParseTags(t, ThumbnailImageSection{Data: buffer.Bytes()}.Tags()))
+	assert.Nil(t, err)
+	assert.Equal(t, buffer.Bytes(), section.Data)
+	assert.NotNil(t, section.Image)
+	assert.Equal(t, image.Rect(0, 0, 3, 2), section.Image.Bounds())
+}
+
+func TestNewThumbnailImageSectionUnsupportedImage(t *testing.T) {
+	section, err := NewThumbnailImageSection(parseTags(`  0
+SECTION
+  2
+THUMBNAILIMAGE
+ 90
+4
+310
+01020304
+  0
+ENDSEC
+`))
+	// the data is kept to be written back.
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{1, 2, 3, 4}, section.Data)
+	assert.Nil(t, section.Image)
+}
+
+func TestNewThumbnailImageSectionInvalid(t *testing.T) {
+	thumbnail := `  0
+SECTION
+  2
+THUMBNAILIMAGE
+ 90
+4
+310
+01020304
+  0
+ENDSEC
+`
+	_, err := NewThumbnailImageSection(parseTags(strings.Replace(thumbnail, "\n4\n", "\n5\n", 1)))
+	assert.NotNil(t, err)
+
+	_, err = NewThumbnailImageSection(parseTags(strings.Replace(thumbnail, "01020304", "010203XY", 1)))
+	assert.NotNil(t, err)
+}
+
+func TestNewThumbnailImageSectionEmpty(t *testing.T) {
+	section, err := NewThumbnailImageSection(parseTags("  0\nSECTION\n  2\nTHUMBNAILIMAGE\n  0\nENDSEC\n"))
+	assert.Nil(t, err)
+	assert.Len(t, section.Data, 0)
+	assert.Nil(t, section.Image)
+}
@@ -0,0 +1,69 @@
+package sections
+
+import (
+	"sync"
+
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+)
+
+// entityChunkSize is the number of entities each worker builds at a time.
+const entityChunkSize = 256
+
+// parsedEntity is an entity built from its tags, or the error building it.
+type parsedEntity struct {
+	entity entities.Entity
+	err    error
+}
+
+// parseEntities builds the entities of the groups with the Workers of the
+// Parser, each group being independent of the others. It returns nil when
+// the entities are to be built sequentially, by a single worker or when
+// there are too few of them. The building stops with the error of the
+// Limiter when its context is done, and before the chunk of entities that
+// would exceed its MaxEntities.
+func (p Parser) parseEntities(groups []core.TagSlice) ([]parsedEntity, error) {
+	if p.Workers <= 1 || len(groups) <= entityChunkSize {
+		return nil, nil
+	}
+
+	parsed := make([]parsedEntity, len(groups))
+	chunks := make(chan int)
+	chunkEnd := func(start int) int {
+		if end := start + entityChunkSize; end < len(groups) {
+			return end
+		}
+		return len(groups)
+	}
+	var wg sync.WaitGroup
+	for worker := 0; worker < p.Workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range chunks {
+				for i := start; i < chunkEnd(start); i++ {
+					parsed[i].entity, parsed[i].err = newEntity(groups[i])
+				}
+			}
+		}()
+	}
+
+	var err error
+	for start := 0; start < len(groups); start += entityChunkSize {
+		if err = p.Limiter.Err(); err != nil {
+			break
+		}
+		// the entities are counted by entityList, once built.
+		if err = p.Limiter.CheckEntities(chunkEnd(start)); err != nil {
+			break
+		}
+		chunks <- start
+	}
+	close(chunks)
+	wg.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
@@ -0,0 +1,154 @@
+package sections
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/stretchr/testify/assert"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// testLargeEntities returns the tags of count entities of every kind: the
+// POLYLINEs and INSERTs with their nested entities, the unsupported types
+// and, when invalid, a POLYLINE with the BAD handle every 100 entities, see
+// breakEntities.
+func testLargeEntities(count int, invalid bool) string {
+	var builder strings.Builder
+	for i := 0; i < count; i++ {
+		switch {
+		case invalid && i%100 == 50:
+			builder.WriteString("  0\nPOLYLINE\n  5\nBAD\n 70\n0\n 66\n1\n  0\nVERTEX\n 10\n1.0\n  0\nSEQEND\n")
+		case i%4 == 0:
+			fmt.Fprintf(&builder, "  0\nLINE\n  5\n%X\n 10\n%v.0\n 20\n1.0\n 11\n2.0\n 21\n3.0\n", i, i)
+		case i%4 == 1:
+			fmt.Fprintf(&builder, "  0\nPOLYLINE\n  5\n%X\n 66\n1\n", i)
+			for v := 0; v < 3; v++ {
+				fmt.Fprintf(&builder, "  0\nVERTEX\n 10\n%v.0\n 20\n%v.0\n", i, v)
+			}
+			builder.WriteString("  0\nSEQEND\n")
+		case i%4 == 2:
+			fmt.Fprintf(&builder, "  0\nINSERT\n  5\n%X\n 66\n1\n  2\nBLOCK\n  0\nATTRIB\n  1\n%v\n  0\nSEQEND\n", i, i)
+		default:
+			fmt.Fprintf(&builder, "  0\nMTEXT\n  5\n%X\n  1\nText %v\n", i, i)
+		}
+	}
+	return builder.String()
+}
+
+// breakEntities makes the entities with the BAD handle fail to parse, with
+// a string value after their handle.
+func breakEntities(tags core.TagSlice) core.TagSlice {
+	for i, tag := range tags {
+		if tag.Code == 5 && tag.Value.ToString() == "BAD" {
+			tags[i+1] = &core.Tag{Code: tags[i+1].Code, Value: core.NewStringValue("ERROR"), Line: tags[i+1].Line}
+		}
+	}
+	return tags
+}
+
+func testLargeEntitiesSection(count int, invalid bool) core.TagSlice {
+	return breakEntities(parseTags("  0\nSECTION\n  2\nENTITIES\n" + testLargeEntities(count, invalid) + "  0\nENDSEC\n"))
+}
+
+func TestParallelEntitiesSection(t *testing.T) {
+	tags := testLargeEntitiesSection(2000, false)
+
+	expected, err := Parser{}.EntitiesSection(tags)
+	assert.Nil(t, err)
+	assert.Equal(t, 2000, len(expected.Entities))
+
+	for _, workers := range []int{2, 3, 8} {
+		section, err := Parser{Workers: workers}.EntitiesSection(tags)
+		assert.Nil(t, err)
+		assert.Equal(t, 2000, len(section.Entities))
+		assert.True(t, expected.Equals(section), "%v workers", workers)
+	}
+}
+
+func TestParallelEntitiesSectionErrors(t *testing.T) {
+	tags := testLargeEntitiesSection(2000, true)
+
+	_, expectedErr := Parser{}.EntitiesSection(tags)
+	_, err := Parser{Workers: 4}.EntitiesSection(tags)
+	assert.NotNil(t, err)
+	assert.Equal(t, expectedErr, err)
+
+	// the diagnostics keep the order of the entities.
+	sequential := Parser{Diagnostics: core.NewDiagnostics(core.ParseOptions{Mode: core.Lenient})}
+	expected, err := sequential.EntitiesSection(tags)
+	assert.Nil(t, err)
+
+	parallel := Parser{Diagnostics: core.NewDiagnostics(core.ParseOptions{Mode: core.Lenient}), Workers: 4}
+	section, err := parallel.EntitiesSection(tags)
+	assert.Nil(t, err)
+	assert.Equal(t, 1980, len(section.Entities))
+	assert.True(t, expected.Equals(section))
+	assert.Equal(t, sequential.Diagnostics.List, parallel.Diagnostics.List)
+}
+
+func TestParallelBlocksSection(t *testing.T) {
+	var dxf strings.Builder
+	dxf.WriteString("  0\nSECTION\n  2\nBLOCKS\n")
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&dxf, "  0\nBLOCK\n  2\nBLOCK%v\n 10\n0.0\n 20\n0.0\n", i)
+		dxf.WriteString(testLargeEntities(50+i, i%5 == 0))
+		dxf.WriteString("  0\nENDBLK\n")
+	}
+	// an ENDBLK without its BLOCK.
+	dxf.WriteString("  0\nLINE\n 10\n1.0\n  0\nENDBLK\n  0\nENDSEC\n")
+	tags := breakEntities(parseTags(dxf.String()))
+
+	sequential := Parser{Diagnostics: core.NewDiagnostics(core.ParseOptions{Mode: core.Lenient})}
+	expected, err := sequential.BlocksSection(tags)
+	assert.Nil(t, err)
+	assert.Equal(t, 20, len(expected))
+
+	parallel := Parser{Diagnostics: core.NewDiagnostics(core.ParseOptions{Mode: core.Lenient}), Workers: 4}
+	blocks, err := parallel.BlocksSection(tags)
+	assert.Nil(t, err)
+	assert.True(t, expected.Equals(blocks))
+	assert.Equal(t, sequential.Diagnostics.List, parallel.Diagnostics.List)
+}
+
+func TestParallelEntitiesSectionMaxEntities(t *testing.T) {
+	tags := testLargeEntitiesSection(2000, false)
+	limits := core.Limits{MaxEntities: 300}
+
+	_, expectedErr := Parser{Limiter: core.NewLimiter(context.Background(), limits)}.EntitiesSection(tags)
+	assert.True(t, errors.Is(expectedErr, core.ErrLimitExceeded))
+	parser := Parser{Limiter: core.NewLimiter(context.Background(), limits), Workers: 4}
+	_, err := parser.EntitiesSection(tags)
+	assert.Equal(t, expectedErr, err)
+
+	// the chunks past the limit are not built.
+	parser.Limiter = core.NewLimiter(context.Background(), limits)
+	parsed, err := parser.parseEntities(core.TagGroups(tags[2:len(tags)-1], 0))
+	assert.Nil(t, parsed)
+	assert.True(t, errors.Is(err, core.ErrLimitExceeded))
+
+	_, err = Parser{Limiter: core.NewLimiter(context.Background(), core.Limits{MaxEntities: 10000}),
+		Workers: 4}.EntitiesSection(tags)
+	assert.Nil(t, err)
+}
+
+func BenchmarkEntitiesSection(b *testing.B) {
+	tags := testLargeEntitiesSection(100000, false)
+
+	workerCounts := []int{1, 2, 4}
+	if procs := runtime.GOMAXPROCS(0); procs > 4 {
+		workerCounts = append(workerCounts, procs)
+	}
+	for _, workers := range workerCounts {
+		b.Run(fmt.Sprintf("workers=%v", workers), func(b *testing.B) {
+			parser := Parser{Workers: workers}
+			for i := 0; i < b.N; i++ {
+				if _, err := parser.EntitiesSection(tags); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package sections
+
+import (
+	"bytes"
+	"github.com/davecgh/go-spew/spew"
+	"github.com/rpaloschi/dxf-go/core"
+	"github.com/rpaloschi/dxf-go/entities"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func parseTags(dxf string) core.TagSlice {
+	return core.TagSlice(core.AllTags(core.Tagger(strings.NewReader(dxf))))
+}
+
+func writeAndParseTags(t *testing.T, tags core.TagSlice) core.TagSlice {
+	var buffer bytes.Buffer
+	assert.Nil(t, core.WriteTags(&buffer, tags))
+	return parseTags(buffer.String())
+}
+
+func TestTablesSectionTags(t *testing.T) {
+	tables := &TablesSection{}
+	var err error
+	tables.LineTypes, err = NewLineTypeTable(parseTags(lineTypeTable))
+	assert.Nil(t, err)
+	tables.Styles, err = NewStyleTable(parseTags(dxfStyleTable))
+	assert.Nil(t, err)
+	tables.Layers, err = NewLayerTable(parseTags(sampleLayerTable))
+	assert.Nil(t, err)
+
+	tags := writeAndParseTags(t, tables.Tags())
+	written, err := NewTablesSection(tags)
+	assert.Nil(t, err)
+	assert.True(t, tables.Equals(written), "%v\n%v", spew.Sdump(tables), spew.Sdump(written))
+
+	// the tables are sorted, as their entries.
+	assert.Equal(t, "LTYPE", tags[3].Value.ToString())
+	assert.True(t, core.NewStringTag(0, "ENDSEC").Equals(tags[len(tags)-1]))
+}
+
+func TestHeaderSectionTags(t *testing.T) {
+	header := NewHeaderSection(parseTags(testHeader))
+	written := NewHeaderSection(writeAndParseTags(t, header.Tags()))
+	assert.True(t, header.Equals(written))
+
+	// the variables giving the encoding of the strings go first.
+	tags := header.Tags()
+	assert.Equal(t, "$ACADVER", tags[2].Value.ToString())
+	assert.Equal(t, "$DWGCODEPAGE", tags[4].Value.ToString())
+}
+
+func TestBlocksSectionTags(t *testing.T) {
+	blocks, err := NewBlocksSection(parseTags(dxfBlocksSection))
+	assert.Nil(t, err)
+
+	written, err := NewBlocksSection(writeAndParseTags(t, blocks.Tags()))
+	assert.Nil(t, err)
+	assert.True(t, blocks.Equals(written), "%v\n%v", spew.Sdump(blocks), spew.Sdump(written))
+}
+
+func TestEntitiesSectionTags(t *testing.T) {
+	section, err := NewEntitiesSection(parseTags(dxfEntitiesSection))
+	assert.Nil(t, err)
+
+	written, err := NewEntitiesSection(writeAndParseTags(t, section.Tags()))
+	assert.Nil(t, err)
+	assert.Len(t, written.Entities, len(section.Entities))
+	assert.True(t, section.Equals(written), "%v\n%v", spew.Sdump(section), spew.Sdump(written))
+}
+
+// unwritable is an Entity that cannot be written.
+type unwritable struct {
+	entities.Entity
+}
+
+func TestEntitiesSectionTagsOptions(t *testing.T) {
+	var buffer bytes.Buffer
+	options := core.WriteOptions{Logger: slog.New(slog.NewTextHandler(&buffer, nil))}
+	section := EntitiesSection{Entities: entities.EntitySlice{unwritable{&entities.Point{}}}}
+
+	assert.Len(t, section.TagsOptions(options), 3)
+	assert.Contains(t, buffer.String(), "Skipping entity, it cannot be written")
+
+	block := Block{Name: "BLOCK", Entities: section.Entities}
+	buffer.Reset()
+	BlocksSection{"BLOCK": &block}.TagsOptions(options)
+	assert.Contains(t, buffer.String(), "Skipping entity, it cannot be written")
+}